@@ -10,7 +10,13 @@
     fmt: $.condition.format,
     format: {
       json(settings={}): {
+        local default = {
+          object: $.config.object,
+          type: 'any',
+        },
+
         type: 'format_json',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
       mime(settings={}): {
         local default = {
@@ -90,6 +96,31 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      modulo(settings={}): {
+        local default = {
+          object: $.config.object,
+          modulo: null,
+          less_than: null,
+          hash: false,
+        },
+
+        type: 'number_modulo',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+    },
+    geo: {
+      within(settings={}): {
+        local default = {
+          latitude_key: null,
+          longitude_key: null,
+          center: null,
+          radius: null,
+          box: null,
+        },
+
+        type: 'geo_within',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
     },
     meta: {
       condition(settings={}): {
@@ -157,6 +188,12 @@
           type: 'network_ip_private',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        type(settings={}): {
+          local default = $.condition.network.ip.default { type: null },
+
+          type: 'network_ip_type',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         unicast(settings={}): {
           local default = $.condition.network.ip.default,
 
@@ -234,9 +271,70 @@
         type: 'string_match',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      match_list(settings={}): {
+        local default = {
+          object: $.config.object,
+          patterns: null,
+          match: 'any',
+        },
+
+        type: 'string_match_list',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      glob(settings={}): {
+        local default = {
+          object: $.config.object,
+          pattern: null,
+          case_insensitive: false,
+        },
+
+        type: 'string_glob',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
     },
     util: $.transform.utility,
     utility: {
+      checksum(settings={}): {
+        local default = {
+          object: $.config.object,
+          checksum_key: null,
+          algorithm: 'sha256',
+          encoding: 'hex',
+        },
+
+        type: 'utility_checksum',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      compare(settings={}): {
+        local default = {
+          left_key: null,
+          right_key: null,
+          operator: null,
+          mode: 'string',
+        },
+
+        type: 'utility_compare',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      empty(settings={}): {
+        local default = {
+          object: $.config.object,
+          trim_space: false,
+        },
+
+        type: 'utility_empty',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      expr(settings={}): $.condition.utility.expression(settings=settings),
+      expression(settings={}): {
+        local default = {
+          object: $.config.object,
+          expression: null,
+        },
+
+        type: 'utility_expression',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       random(settings={}): {
         type: 'utility_random',
       },
@@ -247,11 +345,34 @@
   transform: {
     agg: $.transform.aggregate,
     aggregate: {
+      cumulative_sum(settings={}): {
+        local default = {
+          object: $.config.object,
+          batch: $.config.batch,
+          sort_key: null,
+          window: 0,
+        },
+
+        type: 'aggregate_cumulative_sum',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      multiline(settings={}): {
+        local default = {
+          object: $.config.object,
+          pattern: null,
+          separator: '\n',
+          max_lines: 500,
+        },
+
+        type: 'aggregate_multiline',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       from: {
         arr(settings={}): $.transform.aggregate.from.array(settings=settings),
         array(settings={}): {
           local default = {
             object: $.config.object,
+            envelope: null,
           },
 
           type: 'aggregate_from_array',
@@ -278,6 +399,18 @@
           type: 'aggregate_to_array',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        stats(settings={}): {
+          local default = {
+            object: $.config.object,
+            batch: $.config.batch,
+            percentiles: [50, 95, 99],
+            algorithm: 'exact',
+            compression: 100,
+          },
+
+          type: 'aggregate_to_stats',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         str(settings={}): $.transform.aggregate.to.string(settings=settings),
         string(settings={}): {
           local default = {
@@ -292,6 +425,17 @@
     },
     arr: $.transform.array,
     array: {
+      first(settings={}): $.transform.array.index(settings=std.mergePatch(settings, { index: 0 })),
+      last(settings={}): $.transform.array.index(settings=std.mergePatch(settings, { index: -1 })),
+      index(settings={}): {
+        local default = {
+          object: $.config.object,
+          index: null,
+        },
+
+        type: 'array_index',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       join(settings={}): {
         local default = {
           object: $.config.object,
@@ -301,6 +445,36 @@
         type: 'array_join',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      set(settings={}): {
+        local default = {
+          object: $.config.object,
+          operation: null,
+          other_key: null,
+        },
+
+        type: 'array_set',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      slice(settings={}): {
+        local default = {
+          object: $.config.object,
+          start: null,
+          end: null,
+        },
+
+        type: 'array_slice',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      sort(settings={}): {
+        local default = {
+          object: $.config.object,
+          by: null,
+          order: 'asc',
+        },
+
+        type: 'array_sort',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       to: {
         obj: $.transform.array.to.object,
         object(settings={}): {
@@ -313,6 +487,15 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      unique(settings={}): {
+        local default = {
+          object: $.config.object,
+          by: null,
+        },
+
+        type: 'array_unique',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       zip(settings={}): {
         local default = {
           object: $.config.object,
@@ -342,6 +525,22 @@
           type: 'enrich_aws_dynamodb',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        dynamodb_get_item(settings={}): {
+          local default = {
+            object: $.config.object,
+            aws: $.config.aws,
+            retry: $.config.retry,
+            table_name: null,
+            key: null,
+            attributes: null,
+            consistent_read: false,
+            cache_ttl: '5m',
+            cache_capacity: 1024,
+          },
+
+          type: 'enrich_aws_dynamodb_get_item',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         lambda(settings={}): {
           local default = {
             object: $.config.object,
@@ -378,6 +577,17 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      geofence(settings={}): {
+        local default = {
+          object: $.config.object,
+          latitude_key: null,
+          longitude_key: null,
+          regions: null,
+        },
+
+        type: 'enrich_geofence',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       http: {
         default: {
           object: $.config.object,
@@ -432,13 +642,66 @@
           type: 'format_from_base64',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        clf(settings={}): {
+          local default = $.transform.format.default { format: null, pattern: null, error_key: null },
+
+          type: 'format_from_clf',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        fixed_width(settings={}): {
+          local default = $.transform.format.default { columns: null, measurement: 'char' },
+
+          type: 'format_from_fixed_width',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        geohash(settings={}): {
+          local default = $.transform.format.default,
+
+          type: 'format_from_geohash',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         gz(settings={}): $.transform.format.from.gzip(settings=settings),
         gzip(settings={}): {
           type: 'format_from_gzip',
         },
+        json(settings={}): {
+          local default = $.transform.format.default,
+
+          type: 'format_from_json',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        jwt(settings={}): {
+          local default = $.transform.format.default { verify_key: null, algorithm: null },
+
+          type: 'format_from_jwt',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        msgpack(settings={}): {
+          type: 'format_from_msgpack',
+        },
         pretty_print(settings={}): {
           type: 'format_from_pretty_print',
         },
+        // Form bodies (application/x-www-form-urlencoded) use the same encoding as query strings.
+        form(settings={}): $.transform.format.from.query_string(settings=settings),
+        query_string(settings={}): {
+          local default = $.transform.format.default,
+
+          type: 'format_from_query_string',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        syslog(settings={}): {
+          local default = $.transform.format.default { format: null, error_key: null },
+
+          type: 'format_from_syslog',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        url(settings={}): {
+          local default = $.transform.format.default { plus_as_space: false, ignore_invalid: false },
+
+          type: 'format_from_url',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
       },
       to: {
         b64(settings={}): $.transform.format.to.base64(settings=settings),
@@ -448,16 +711,43 @@
           type: 'format_to_base64',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        geohash(settings={}): {
+          local default = $.transform.format.default { latitude_key: null, longitude_key: null, precision: 12 },
+
+          type: 'format_to_geohash',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         gz(settings={}): $.transform.format.to.gzip(settings=settings),
         gzip(settings={}): {
           type: 'format_to_gzip',
         },
+        json(settings={}): {
+          local default = $.transform.format.default,
+
+          type: 'format_to_json',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+        msgpack(settings={}): {
+          type: 'format_to_msgpack',
+        },
+        url(settings={}): {
+          local default = $.transform.format.default { plus_as_space: false },
+
+          type: 'format_to_url',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
       },
     },
     hash: {
       default: {
         object: $.config.object,
       },
+      id(settings={}): {
+        local default = $.transform.hash.default { keys: null, encoding: 'hex' },
+
+        type: 'hash_id',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       md5(settings={}): {
         local default = $.transform.hash.default,
 
@@ -473,6 +763,40 @@
     },
     num: $.transform.number,
     number: {
+      base(settings={}): {
+        local default = {
+          object: $.config.object,
+          from: 10,
+          to: 10,
+        },
+
+        type: 'number_base',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      bucket(settings={}): {
+        local default = {
+          object: $.config.object,
+          boundaries: null,
+          labels: null,
+          underflow: null,
+        },
+
+        type: 'number_bucket',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      format(settings={}): {
+        local default = {
+          object: $.config.object,
+          decimals: 0,
+          thousands_separator: null,
+          decimal_separator: '.',
+          prefix: null,
+          suffix: null,
+        },
+
+        type: 'number_format',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       math: {
         default: {
           object: $.config.object,
@@ -505,9 +829,31 @@
           type: 'number_math_division',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        fields(settings={}): {
+          local default = {
+            object: $.config.object,
+            operation: null,
+            values: null,
+          },
+
+          type: 'number_math_fields',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
       },
     },
     meta: {
+      concurrency(settings={}): {
+        local default = { transform: null, limit: null },
+
+        type: 'meta_concurrency',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      disk_buffer(settings={}): {
+        local default = { transform: null, directory: null, size: 1000 },
+
+        type: 'meta_disk_buffer',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       err(settings={}): {
         local default = { transform: null },
 
@@ -544,6 +890,8 @@
         type: 'meta_pipeline',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      // Each case supports either a single transform (transform) or
+      // a series of transforms (transforms).
       switch(settings={}): {
         local default = { cases: null },
 
@@ -577,15 +925,42 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      http_status(settings={}): {
+        local default = {
+          object: $.config.object,
+          reason_key: null,
+        },
+
+        type: 'network_http_status',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
     },
     obj: $.transform.object,
     object: {
       default: {
         object: $.config.object,
       },
+      canonicalize(settings={}): {
+        local default = $.transform.object.default {
+          keys: null,
+          recursive: false,
+        },
+
+        type: 'object_canonicalize',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      coerce(settings={}): {
+        local default = $.transform.object.default {
+          allow_keys: null,
+          deny_keys: null,
+        },
+
+        type: 'object_coerce',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       cp(settings={}): $.transform.object.copy(settings=settings),
       copy(settings={}): {
-        local default = $.transform.object.default,
+        local default = $.transform.object.default { no_overwrite: false },
 
         type: 'object_copy',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
@@ -597,8 +972,39 @@
         type: 'object_delete',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      diff(settings={}): {
+        local default = {
+          object: $.config.object,
+          before_key: null,
+          after_key: null,
+        },
+
+        type: 'object_diff',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      envelope(settings={}): {
+        local default = {
+          key: 'data',
+          fields: null,
+          keys: null,
+          unwrap: false,
+        },
+
+        type: 'object_envelope',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      expr(settings={}): $.transform.object.expression(settings=settings),
+      expression(settings={}): {
+        local default = {
+          object: $.config.object,
+          expression: null,
+        },
+
+        type: 'object_expression',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       insert(settings={}): {
-        local default = $.transform.object.default,
+        local default = $.transform.object.default { no_overwrite: false },
 
         type: 'object_insert',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
@@ -609,10 +1015,77 @@
         type: 'object_jq',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      keys_diff(settings={}): {
+        local default = {
+          object: $.config.object,
+          keys: null,
+          missing_key: null,
+          extra_key: null,
+        },
+
+        type: 'object_keys_diff',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      len: $.transform.object.length,
+      length(settings={}): {
+        local default = $.transform.object.default,
+
+        type: 'object_length',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      merge(settings={}): {
+        local default = $.transform.object.default { value: null, strategy: 'deep', no_overwrite: false, arrays: 'replace' },
+
+        type: 'object_merge',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      mv: $.transform.object.move,
+      move(settings={}): {
+        local default = $.transform.object.default,
+
+        type: 'object_move',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      patch(settings={}): {
+        local default = $.transform.object.default { operations: null },
+
+        type: 'object_patch',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      pivot(settings={}): {
+        local default = $.transform.object.default { name_key: 'name', value_key: 'value', duplicates: 'last' },
+
+        type: 'object_pivot',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      project(settings={}): {
+        local default = {
+          keys: null,
+        },
+
+        type: 'object_project',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      resolve_refs(settings={}): {
+        local default = $.transform.object.default { key: '$ref' },
+
+        type: 'object_resolve_refs',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       to: {
+        array(settings={}): {
+          local default = $.transform.object.default { unwrap: false },
+
+          type: 'object_to_array',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         bool(settings={}): $.transform.object.to.boolean(settings=settings),
         boolean(settings={}): {
-          local default = $.transform.object.default,
+          local default = $.transform.object.default {
+            true_values: null,
+            false_values: null,
+            error_on_unknown: false,
+          },
 
           type: 'object_to_boolean',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
@@ -645,6 +1118,12 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      unpivot(settings={}): {
+        local default = $.transform.object.default { name_key: 'key', value_key: 'value' },
+
+        type: 'object_unpivot',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
     },
     send: {
       aws: {
@@ -691,7 +1170,10 @@
             retry: $.config.retry,
             stream_name: null,
             use_batch_key_as_partition_key: false,
+            partition_key: null,
+            partition_key_hash: null,
             enable_record_aggregation: false,
+            split_oversized_arrays: false,
           },
 
           local s = std.mergePatch(settings, {
@@ -782,6 +1264,25 @@
         type: 'send_file',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(s))),
       },
+      grpc(settings={}): {
+        local default = {
+          batch: $.config.batch,
+          auxiliary_transforms: null,
+          retry: $.config.retry,
+          target: null,
+          method: null,
+          insecure: false,
+          tls: $.config.tls,
+        },
+
+        local s = std.mergePatch(settings, {
+          auxiliary_transforms: if std.objectHas(settings, 'auxiliary_transforms') then settings.auxiliary_transforms else if std.objectHas(settings, 'aux_tforms') then settings.aux_tforms else null,
+          aux_tforms: null,
+        }),
+
+        type: 'send_grpc',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(s))),
+      },
       http: {
         post(settings={}): {
           local default = {
@@ -789,6 +1290,9 @@
             auxiliary_transforms: null,
             url: null,
             headers: null,
+            idempotency_header: null,
+            idempotency_key: null,
+            tls: $.config.tls,
           },
 
           local s = std.mergePatch(settings, {
@@ -802,6 +1306,48 @@
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(s))),
         },
       },
+      prometheus: {
+        pushgateway(settings={}): {
+          local default = {
+            url: null,
+            headers: null,
+            name_key: null,
+            value_key: null,
+            labels_key: null,
+            type: 'untyped',
+            max_series: 1000,
+          },
+
+          type: 'send_prometheus_pushgateway',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
+      },
+      // null is a reserved word, so this can also be accessed as discard.
+      discard: $.transform.send['null'],
+      'null'(settings={}): {
+        local default = { log_count: false },
+
+        type: 'send_null',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      sqlite(settings={}): {
+        local default = {
+          batch: $.config.batch,
+          auxiliary_transforms: null,
+          file_path: null,
+          table: null,
+          columns: null,
+          record_column: 'record',
+        },
+
+        local s = std.mergePatch(settings, {
+          auxiliary_transforms: if std.objectHas(settings, 'auxiliary_transforms') then settings.auxiliary_transforms else if std.objectHas(settings, 'aux_tforms') then settings.aux_tforms else null,
+          aux_tforms: null,
+        }),
+
+        type: 'send_sqlite',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(s))),
+      },
       stdout(settings={}): {
         local default = {
           batch: $.config.batch,
@@ -838,12 +1384,106 @@
         type: 'string_capture',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      checksum(settings={}): {
+        local default = {
+          object: $.config.object,
+          algorithm: 'luhn',
+        },
+
+        type: 'string_checksum',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      email(settings={}): {
+        local default = {
+          object: $.config.object,
+          strip_tags: false,
+          lowercase_local: false,
+          valid_key: null,
+        },
+
+        type: 'string_email',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      entropy(settings={}): {
+        local default = {
+          object: $.config.object,
+          base: '2',
+        },
+
+        type: 'string_entropy',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      find(settings={}): {
+        local default = {
+          object: $.config.object,
+          pattern: null,
+          limit: 0,
+          nth: 0,
+        },
+
+        type: 'string_find',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      mask(settings={}): {
+        local default = {
+          object: $.config.object,
+          pattern: null,
+          character: '*',
+          preserve_last: 0,
+        },
+
+        type: 'string_mask',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      normalize(settings={}): {
+        local default = {
+          object: $.config.object,
+          form: 'nfc',
+          case_fold: false,
+        },
+
+        type: 'string_normalize',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      phone(settings={}): {
+        local default = {
+          object: $.config.object,
+          region: 'US',
+          valid_key: null,
+          type_key: null,
+        },
+
+        type: 'string_phone',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      pii(settings={}): {
+        local default = {
+          object: $.config.object,
+          detectors: null,
+          mask: false,
+          character: '*',
+        },
+
+        type: 'string_pii',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      repeat(settings={}): {
+        local default = {
+          object: $.config.object,
+          count: null,
+          separator: null,
+        },
+
+        type: 'string_repeat',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       repl: $.transform.string.replace,
       replace(settings={}): {
         local default = {
           object: $.config.object,
           pattern: null,
           replacement: null,
+          template: null,
         },
 
         local s = std.mergePatch(settings, {
@@ -869,6 +1509,57 @@
         type: 'string_split',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(s))),
       },
+      stats(settings={}): {
+        local default = {
+          object: $.config.object,
+          tokenizer: 'whitespace',
+        },
+
+        type: 'string_stats',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      strip_ansi(settings={}): {
+        local default = {
+          object: $.config.object,
+        },
+
+        type: 'string_strip_ansi',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      substr: $.transform.string.substring,
+      substring(settings={}): {
+        local default = {
+          object: $.config.object,
+          start: null,
+          end: null,
+        },
+
+        type: 'string_substring',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      tokenize(settings={}): {
+        local default = {
+          object: $.config.object,
+          key: null,
+          tweak: null,
+          alphabet: '0123456789',
+          decrypt: false,
+        },
+
+        type: 'string_tokenize',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      truncate(settings={}): {
+        local default = {
+          object: $.config.object,
+          max: null,
+          measurement: 'byte',
+          suffix: null,
+        },
+
+        type: 'string_truncate',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       to: {
         default: {
           object: $.config.object,
@@ -879,6 +1570,12 @@
           type: 'string_to_lower',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        severity(settings={}): {
+          local default = $.transform.string.to.default { mapping: null, default: null, number_key: null },
+
+          type: 'string_to_severity',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         upper(settings={}): {
           local default = $.transform.string.to.default,
 
@@ -891,6 +1588,12 @@
           type: 'string_to_snake',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
+        utf8(settings={}): {
+          local default = $.transform.string.to.default { charset: 'auto', fallback: 'windows-1252' },
+
+          type: 'string_to_utf8',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
       },
       uuid(settings={}): {
         local default = {
@@ -902,7 +1605,52 @@
       },
     },
     time: {
+      bucket(settings={}): {
+        local default = {
+          object: $.config.object,
+          duration: null,
+          alignment: 'epoch',
+        },
+
+        type: 'time_bucket',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      delta(settings={}): {
+        local default = {
+          object: $.config.object,
+          start_key: null,
+          start_format: 'unix_nano',
+          end_key: null,
+          end_format: 'unix_nano',
+          location: null,
+          unit: 'ms',
+          error_on_negative: false,
+        },
+
+        type: 'time_delta',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
+      epoch_scale(settings={}): {
+        local default = {
+          object: $.config.object,
+          from: 'auto',
+          to: 'ns',
+        },
+
+        type: 'time_epoch_scale',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       from: {
+        duration(settings={}): {
+          local default = {
+            object: $.config.object,
+            format: null,
+            unit: 's',
+          },
+
+          type: 'time_from_duration',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         str(settings={}): $.transform.time.from.string(settings=settings),
         string(settings={}): {
           local default = {
@@ -940,6 +1688,16 @@
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
       to: {
+        duration(settings={}): {
+          local default = {
+            object: $.config.object,
+            format: 'go',
+            unit: 's',
+          },
+
+          type: 'time_to_duration',
+          settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+        },
         str(settings={}): $.transform.time.to.string(settings=settings),
         string(settings={}): {
           local default = {
@@ -971,6 +1729,14 @@
     },
     util: $.transform.utility,
     utility: {
+      control(settings={}): {
+        local default = {
+          batch: $.config.batch,
+        },
+
+        type: 'utility_control',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       delay(settings={}): {
         local default = {
           duration: null,
@@ -1002,18 +1768,38 @@
         count(settings={}): {
           local default = {
             metric: $.config.metric,
+            attribute_keys: null,
+            max_cardinality: 100,
           },
 
           type: 'utility_metric_count',
           settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
         },
       },
+      rate_limit(settings={}): {
+        local default = {
+          rate: null,
+          burst: 1,
+        },
+
+        type: 'utility_rate_limit',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
       secret(settings={}): {
         local default = { secret: null },
 
         type: 'utility_secret',
         settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
       },
+      sequence(settings={}): {
+        local default = {
+          object: $.config.object,
+          start: 0,
+        },
+
+        type: 'utility_sequence',
+        settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
+      },
     },
   },
   // Mirrors interfaces from the internal/kv_store package.
@@ -1031,13 +1817,13 @@
       settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
     },
     csv_file(settings={}): {
-      local default = { file: null, column: null, delimiter: ',', header: null },
+      local default = { file: null, column: null, delimiter: ',', header: null, refresh_interval: null },
 
       type: 'csv_file',
       settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
     },
     json_file(settings=$.defaults.kv_store.json_file.settings): {
-      local default = { file: null, is_lines: false },
+      local default = { file: null, is_lines: false, refresh_interval: null },
 
       type: 'json_file',
       settings: std.prune(std.mergePatch(default, $.helpers.abbv(settings))),
@@ -1068,7 +1854,8 @@
     metric: { name: null, attributes: null, destination: null },
     object: { source_key: null, target_key: null, batch_key: null },
     request: { timeout: '1s' },
-    retry: { count: 3, error_messages: null },
+    retry: { count: 3, error_messages: null, max_delay: null },
+    tls: { ca_certificate: null, client_certificate: null, client_key: null, server_name: null, insecure_skip_verify: false },
   },
   // Mirrors config from the internal/file package.
   file_path: { prefix: null, time_format: '2006/01/02', uuid: true, suffix: null },
@@ -1152,6 +1939,14 @@
         type: 'meta_switch',
         settings: { cases: [{ condition: c, transform: transform }] },
       },
+      // Compacts data into newline delimited text. Data is emitted when adding
+      // more data would exceed the size (in bytes), which reduces the number of
+      // small objects that are sent to destinations like AWS S3. Remaining data
+      // is emitted when the pipeline is flushed.
+      compact(size=1000 * 1000): $.tf.agg.to.string({
+        separator: '\n',
+        batch: { count: 1000 * 1000, size: size },
+      }),
       fmt: $.pattern.transform.format,
       format: {
         // Creates JSON Lines text from data. Only valid JSON text is included.
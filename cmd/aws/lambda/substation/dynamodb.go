@@ -47,6 +47,21 @@ func dynamodbHandler(ctx context.Context, event events.DynamoDBEvent) error {
 	// Data transformation. Transforms are executed concurrently using a worker pool
 	// managed by an errgroup. Each message is processed in a separate goroutine.
 	group.Go(func() error {
+		// If an ordering key is configured, then messages with the same key
+		// are transformed in the order they were received.
+		if cfg.OrderingKey != "" {
+			if err := transformOrdered(ctx, sub, cfg.OrderingKey, cfg.Concurrency, ch.Recv()); err != nil {
+				return err
+			}
+
+			ctrl := message.New().AsControl()
+			if _, err := sub.Transform(ctx, ctrl); err != nil {
+				return err
+			}
+
+			return nil
+		}
+
 		tfGroup, tfCtx := errgroup.WithContext(ctx)
 		tfGroup.SetLimit(cfg.Concurrency)
 
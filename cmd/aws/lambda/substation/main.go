@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"os"
+	"runtime"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/brexhq/substation"
 	"github.com/brexhq/substation/internal/file"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -29,6 +33,13 @@ type customConfig struct {
 	substation.Config
 
 	Concurrency int `json:"concurrency"`
+	// OrderingKey retrieves a value from each message that is used to preserve
+	// the order of messages. Messages with the same value are transformed by the
+	// same goroutine in the order that they were received. This is only used by
+	// the AWS_DYNAMODB_STREAM and AWS_KINESIS_DATA_STREAM handlers.
+	//
+	// This is optional and has no default (messages are not ordered).
+	OrderingKey string `json:"ordering_key"`
 }
 
 // getConfig contextually retrieves a Substation configuration.
@@ -60,6 +71,56 @@ func getConfig(ctx context.Context) (io.Reader, error) {
 	return buf, nil
 }
 
+// transformOrdered transforms messages using a fixed number of goroutines. Each
+// message is assigned to a goroutine by the hash of the value retrieved by key,
+// so messages with the same value are transformed in the order they are received.
+func transformOrdered(ctx context.Context, sub *substation.Substation, key string, concurrency int, recv <-chan *message.Message) error {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	workers := make([]chan *message.Message, concurrency)
+	for i := range workers {
+		ch := make(chan *message.Message)
+		workers[i] = ch
+
+		group.Go(func() error {
+			for msg := range ch {
+				if _, err := sub.Transform(ctx, msg); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		defer func() {
+			for _, ch := range workers {
+				close(ch)
+			}
+		}()
+
+		for msg := range recv {
+			h := fnv.New32a()
+			_, _ = h.Write(msg.GetValue(key).Bytes())
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case workers[h.Sum32()%uint32(concurrency)] <- msg:
+			}
+		}
+
+		return nil
+	})
+
+	return group.Wait()
+}
+
 func main() {
 	switch h := handler; h {
 	case "AWS_API_GATEWAY":
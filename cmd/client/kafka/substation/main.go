@@ -0,0 +1,256 @@
+// Consumes messages from Kafka topics as a member of a consumer group and
+// sends them through Substation. Offsets are committed after each batch of
+// messages is transformed and the pipeline is flushed, which provides
+// at-least-once delivery.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/brexhq/substation"
+	"github.com/brexhq/substation/internal/channel"
+	"github.com/brexhq/substation/internal/file"
+	"github.com/brexhq/substation/internal/log"
+	"github.com/brexhq/substation/message"
+)
+
+// Fetch errors are retried with an exponential backoff between these limits.
+const (
+	fetchBackoffMin = 100 * time.Millisecond
+	fetchBackoffMax = 30 * time.Second
+)
+
+type options struct {
+	ConfigFile  string
+	Brokers     string
+	GroupID     string
+	Topics      string
+	Concurrency int
+	BatchCount  int
+	BatchWait   time.Duration
+}
+
+type kafkaMetadata struct {
+	Time      time.Time `json:"time"`
+	Topic     string    `json:"topic"`
+	Partition int       `json:"partition"`
+	Offset    int64     `json:"offset"`
+	Key       string    `json:"key"`
+}
+
+func main() {
+	var opts options
+
+	flag.StringVar(&opts.ConfigFile, "config", os.Getenv("SUBSTATION_CONFIG"), "Substation configuration file (default: SUBSTATION_CONFIG)")
+	flag.StringVar(&opts.Brokers, "brokers", os.Getenv("SUBSTATION_KAFKA_BROKERS"), "Comma-separated list of Kafka brokers (default: SUBSTATION_KAFKA_BROKERS)")
+	flag.StringVar(&opts.GroupID, "group", os.Getenv("SUBSTATION_KAFKA_GROUP_ID"), "Kafka consumer group ID (default: SUBSTATION_KAFKA_GROUP_ID)")
+	flag.StringVar(&opts.Topics, "topics", os.Getenv("SUBSTATION_KAFKA_TOPICS"), "Comma-separated list of Kafka topics (default: SUBSTATION_KAFKA_TOPICS)")
+	flag.IntVar(&opts.Concurrency, "concurrency", -1, "Number of concurrent data transformation functions to run (default: number of CPUs available)")
+	flag.IntVar(&opts.BatchCount, "batch-count", 1000, "Maximum number of messages processed before offsets are committed (default: 1000)")
+	flag.DurationVar(&opts.BatchWait, "batch-wait", 5*time.Second, "Maximum amount of time to wait for a batch to fill before offsets are committed (default: 5s)")
+	flag.Parse()
+
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = runtime.NumCPU()
+	}
+
+	if err := run(opts); err != nil {
+		log.WithField("error", err).Error("kafka consumer failed")
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	if opts.ConfigFile == "" || opts.Brokers == "" || opts.GroupID == "" || opts.Topics == "" {
+		return fmt.Errorf("missing required flags -config, -brokers, -group, or -topics")
+	}
+
+	// Cancelling the context stops reading new messages, but the current
+	// batch is always processed and committed before the consumer leaves
+	// the group.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := getConfig(ctx, opts.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	sub, err := substation.New(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     strings.Split(opts.Brokers, ","),
+		GroupID:     opts.GroupID,
+		GroupTopics: strings.Split(opts.Topics, ","),
+	})
+	// Closing the reader leaves the consumer group, which triggers a
+	// rebalance for the remaining members.
+	defer reader.Close()
+
+	var backoff time.Duration
+	for {
+		msgs, fetchErr := fetch(ctx, reader, opts.BatchCount, opts.BatchWait)
+		if len(msgs) > 0 {
+			// Processing is not cancelled by signals so that fetched messages
+			// are not dropped before they are committed.
+			if err := process(context.Background(), sub, opts.Concurrency, msgs); err != nil {
+				return err
+			}
+
+			if err := reader.CommitMessages(context.Background(), msgs...); err != nil {
+				return err
+			}
+		}
+
+		if fetchErr != nil {
+			backoff = min(max(backoff*2, fetchBackoffMin), fetchBackoffMax)
+			log.WithField("error", fetchErr).WithField("backoff", backoff).Warn("kafka fetch failed")
+
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+
+			continue
+		}
+
+		backoff = 0
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
+// fetch returns messages from Kafka until the batch is full, the wait
+// time is exceeded, or the context is cancelled. Any messages fetched
+// before an error are returned with the error.
+func fetch(ctx context.Context, reader *kafka.Reader, count int, wait time.Duration) ([]kafka.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	var msgs []kafka.Message
+	for len(msgs) < count {
+		m, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				break
+			}
+
+			return msgs, err
+		}
+
+		msgs = append(msgs, m)
+	}
+
+	return msgs, nil
+}
+
+// process sends a batch of messages through Substation and then flushes
+// the pipeline, which guarantees that all sends are complete before the
+// offsets are committed.
+func process(ctx context.Context, sub *substation.Substation, concurrency int, msgs []kafka.Message) error {
+	ch := channel.New[*message.Message]()
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		tfGroup, tfCtx := errgroup.WithContext(ctx)
+		tfGroup.SetLimit(concurrency)
+
+		for message := range ch.Recv() {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			msg := message
+			tfGroup.Go(func() error {
+				if _, err := sub.Transform(tfCtx, msg); err != nil {
+					return err
+				}
+
+				return nil
+			})
+		}
+
+		if err := tfGroup.Wait(); err != nil {
+			return err
+		}
+
+		// CTRL messages flush the pipeline. This must be done
+		// after all messages have been processed.
+		ctrl := message.New().AsControl()
+		if _, err := sub.Transform(ctx, ctrl); err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	group.Go(func() error {
+		defer ch.Close()
+
+		for _, m := range msgs {
+			metadata, err := json.Marshal(kafkaMetadata{
+				Time:      m.Time,
+				Topic:     m.Topic,
+				Partition: m.Partition,
+				Offset:    m.Offset,
+				Key:       string(m.Key),
+			})
+			if err != nil {
+				return err
+			}
+
+			msg := message.New().SetData(m.Value).SetMetadata(metadata)
+			ch.Send(msg)
+		}
+
+		return nil
+	})
+
+	return group.Wait()
+}
+
+func getConfig(ctx context.Context, cfg string) (substation.Config, error) {
+	var conf substation.Config
+
+	path, err := file.Get(ctx, cfg)
+	defer os.Remove(path)
+
+	if err != nil {
+		return conf, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return conf, err
+	}
+
+	if err := json.Unmarshal(b, &conf); err != nil {
+		return conf, err
+	}
+
+	return conf, nil
+}
@@ -31,6 +31,9 @@ func newInspector(ctx context.Context, cfg config.Config) (inspector, error) { /
 		return newFormatMIME(ctx, cfg)
 	case "format_json":
 		return newFormatJSON(ctx, cfg)
+	// Geo inspectors.
+	case "geo_within":
+		return newGeoWithin(ctx, cfg)
 	// Meta inspectors.
 	case "meta_condition":
 		return newMetaCondition(ctx, cfg)
@@ -51,6 +54,8 @@ func newInspector(ctx context.Context, cfg config.Config) (inspector, error) { /
 		return newNetworkIPMulticast(ctx, cfg)
 	case "network_ip_private":
 		return newNetworkIPPrivate(ctx, cfg)
+	case "network_ip_type":
+		return newNetworkIPType(ctx, cfg)
 	case "network_ip_unicast":
 		return newNetworkIPUnicast(ctx, cfg)
 	case "network_ip_unspecified":
@@ -68,6 +73,8 @@ func newInspector(ctx context.Context, cfg config.Config) (inspector, error) { /
 		return newNumberBitwiseNOT(ctx, cfg)
 	case "number_length_less_than":
 		return newNumberLengthLessThan(ctx, cfg)
+	case "number_modulo":
+		return newNumberModulo(ctx, cfg)
 	case "number_length_greater_than":
 		return newNumberLengthGreaterThan(ctx, cfg)
 	case "number_length_equal_to":
@@ -87,7 +94,19 @@ func newInspector(ctx context.Context, cfg config.Config) (inspector, error) { /
 		return newStringStartsWith(ctx, cfg)
 	case "string_match":
 		return newStringMatch(ctx, cfg)
+	case "string_match_list":
+		return newStringMatchList(ctx, cfg)
+	case "string_glob":
+		return newStringGlob(ctx, cfg)
 	// Utility inspectors.
+	case "utility_checksum":
+		return newUtilityChecksum(ctx, cfg)
+	case "utility_compare":
+		return newUtilityCompare(ctx, cfg)
+	case "utility_empty":
+		return newUtilityEmpty(ctx, cfg)
+	case "utility_expression":
+		return newUtilityExpression(ctx, cfg)
 	case "utility_random":
 		return newUtilityRandom(ctx, cfg)
 	default:
@@ -1,26 +1,66 @@
 package condition
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/brexhq/substation/config"
 	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
 	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
 )
 
-type formatJSONConfig struct{}
+type formatJSONConfig struct {
+	Object iconfig.Object `json:"object"`
+
+	// Type is the type of JSON value that is valid.
+	//
+	// Must be one of:
+	//	- any: any JSON value
+	//	- object: JSON objects
+	//	- array: JSON arrays
+	//	- scalar: JSON strings, numbers, booleans, and null
+	//
+	// This is optional and defaults to any.
+	Type string `json:"type"`
+}
 
 func (c *formatJSONConfig) Decode(in interface{}) error {
 	return iconfig.Decode(in, c)
 }
 
+func (c *formatJSONConfig) Validate() error {
+	if !slices.Contains(
+		[]string{
+			"any",
+			"object",
+			"array",
+			"scalar",
+		},
+		c.Type) {
+		return fmt.Errorf("type %q: %v", c.Type, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func newFormatJSON(_ context.Context, cfg config.Config) (*formatJSON, error) {
 	conf := formatJSONConfig{}
 	if err := conf.Decode(cfg.Settings); err != nil {
 		return nil, err
 	}
 
+	if conf.Type == "" {
+		conf.Type = "any"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: format_json: %v", err)
+	}
+
 	insp := formatJSON{
 		conf: conf,
 	}
@@ -28,6 +68,9 @@ func newFormatJSON(_ context.Context, cfg config.Config) (*formatJSON, error) {
 	return &insp, nil
 }
 
+// formatJSON evaluates if data is valid JSON. If a source key is set, then the
+// value of the key is evaluated, which is useful for values that contain JSON
+// text (e.g., {"a":"{\"b\":1}"}).
 type formatJSON struct {
 	conf formatJSONConfig
 }
@@ -37,7 +80,31 @@ func (c *formatJSON) Inspect(ctx context.Context, msg *message.Message) (bool, e
 		return false, nil
 	}
 
-	return json.Valid(msg.Data()), nil
+	b := msg.Data()
+	if c.conf.Object.SourceKey != "" {
+		value := msg.GetValue(c.conf.Object.SourceKey)
+		if !value.Exists() {
+			return false, nil
+		}
+
+		b = value.Bytes()
+	}
+
+	if !json.Valid(b) {
+		return false, nil
+	}
+
+	b = bytes.TrimSpace(b)
+	switch c.conf.Type {
+	case "object":
+		return b[0] == '{', nil
+	case "array":
+		return b[0] == '[', nil
+	case "scalar":
+		return b[0] != '{' && b[0] != '[', nil
+	}
+
+	return true, nil
 }
 
 func (c *formatJSON) String() string {
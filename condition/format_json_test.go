@@ -40,6 +40,51 @@ var jsonValidTests = []struct {
 		[]byte(`a`),
 		false,
 	},
+	{
+		"pass type",
+		config.Config{
+			Settings: map[string]interface{}{
+				"type": "array",
+			},
+		},
+		[]byte(` ["a"]`),
+		true,
+	},
+	{
+		"fail type",
+		config.Config{
+			Settings: map[string]interface{}{
+				"type": "scalar",
+			},
+		},
+		[]byte(`{"a":1}`),
+		false,
+	},
+	{
+		"pass object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"type": "object",
+			},
+		},
+		[]byte(`{"a":"{\"b\":1}"}`),
+		true,
+	},
+	{
+		"fail object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"{b:1}"}`),
+		false,
+	},
 }
 
 func TestFormatJSON(t *testing.T) {
@@ -0,0 +1,182 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// geoEarthRadius is the mean radius of the Earth in meters.
+const geoEarthRadius = 6371008.8
+
+type geoWithinPoint struct {
+	// Latitude is the latitude of the point in decimal degrees.
+	Latitude float64 `json:"latitude"`
+	// Longitude is the longitude of the point in decimal degrees.
+	Longitude float64 `json:"longitude"`
+}
+
+type geoWithinBox struct {
+	// MinLatitude is the southern edge of the box in decimal degrees.
+	MinLatitude float64 `json:"min_latitude"`
+	// MinLongitude is the western edge of the box in decimal degrees.
+	MinLongitude float64 `json:"min_longitude"`
+	// MaxLatitude is the northern edge of the box in decimal degrees.
+	MaxLatitude float64 `json:"max_latitude"`
+	// MaxLongitude is the eastern edge of the box in decimal degrees. If this is
+	// less than MinLongitude, then the box crosses the antimeridian.
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type geoWithinConfig struct {
+	// LatitudeKey retrieves the latitude of the coordinate from a JSON object.
+	LatitudeKey string `json:"latitude_key"`
+	// LongitudeKey retrieves the longitude of the coordinate from a JSON object.
+	LongitudeKey string `json:"longitude_key"`
+	// Center is the center of the circle that the coordinate must be within.
+	//
+	// This is optional and has no default. If set, then Radius must also be set.
+	Center *geoWithinPoint `json:"center"`
+	// Radius is the radius of the circle in meters. Distance is calculated using
+	// the haversine formula.
+	//
+	// This is optional and has no default.
+	Radius float64 `json:"radius"`
+	// Box is the bounding box that the coordinate must be within.
+	//
+	// This is optional and has no default. Either Center or Box must be set,
+	// but not both.
+	Box *geoWithinBox `json:"box"`
+}
+
+func (c *geoWithinConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *geoWithinConfig) Validate() error {
+	if c.LatitudeKey == "" {
+		return fmt.Errorf("latitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.LongitudeKey == "" {
+		return fmt.Errorf("longitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if (c.Center == nil) == (c.Box == nil) {
+		return fmt.Errorf("center or box: %v", errors.ErrInvalidOption)
+	}
+
+	if c.Center != nil && c.Radius <= 0 {
+		return fmt.Errorf("radius: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Box != nil && c.Box.MinLatitude > c.Box.MaxLatitude {
+		return fmt.Errorf("box: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newGeoWithin(_ context.Context, cfg config.Config) (*geoWithin, error) {
+	conf := geoWithinConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: geo_within: %v", err)
+	}
+
+	insp := geoWithin{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+type geoWithin struct {
+	conf geoWithinConfig
+}
+
+func (insp *geoWithin) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	lat, ok := geoCoordinate(msg.GetValue(insp.conf.LatitudeKey))
+	if !ok {
+		return false, nil
+	}
+
+	lon, ok := geoCoordinate(msg.GetValue(insp.conf.LongitudeKey))
+	if !ok {
+		return false, nil
+	}
+
+	p := geoWithinPoint{
+		Latitude:  lat,
+		Longitude: lon,
+	}
+
+	// Invalid coordinates are never inside of a region.
+	if math.Abs(p.Latitude) > 90 || math.Abs(p.Longitude) > 180 {
+		return false, nil
+	}
+
+	if insp.conf.Center != nil {
+		return geoHaversine(*insp.conf.Center, p) <= insp.conf.Radius, nil
+	}
+
+	box := insp.conf.Box
+	if p.Latitude < box.MinLatitude || p.Latitude > box.MaxLatitude {
+		return false, nil
+	}
+
+	if box.MinLongitude <= box.MaxLongitude {
+		return p.Longitude >= box.MinLongitude && p.Longitude <= box.MaxLongitude, nil
+	}
+
+	// The box crosses the antimeridian.
+	return p.Longitude >= box.MinLongitude || p.Longitude <= box.MaxLongitude, nil
+}
+
+func (insp *geoWithin) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
+
+// geoCoordinate returns the value as a number. Only numbers and strings that
+// contain a number are valid coordinates.
+func geoCoordinate(v message.Value) (float64, bool) {
+	switch c := v.Value().(type) {
+	case float64:
+		return c, true
+	case string:
+		f, err := strconv.ParseFloat(c, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+
+		return f, true
+	}
+
+	return 0, false
+}
+
+// geoHaversine returns the great-circle distance in meters between two points.
+func geoHaversine(a, b geoWithinPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * geoEarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
@@ -0,0 +1,232 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &geoWithin{}
+
+var geoWithinTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass radius",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"center": map[string]interface{}{
+					"latitude":  37.7749,
+					"longitude": -122.4194,
+				},
+				"radius": 20000,
+			},
+		},
+		[]byte(`{"lat":37.8044,"lon":-122.2712}`),
+		true,
+	},
+	{
+		"fail radius",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"center": map[string]interface{}{
+					"latitude":  37.7749,
+					"longitude": -122.4194,
+				},
+				"radius": 10000,
+			},
+		},
+		[]byte(`{"lat":37.8044,"lon":-122.2712}`),
+		false,
+	},
+	{
+		"fail missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"center": map[string]interface{}{
+					"latitude":  37.7749,
+					"longitude": -122.4194,
+				},
+				"radius": 20000,
+			},
+		},
+		[]byte(`{"lat":37.8044}`),
+		false,
+	},
+	{
+		"pass box",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  24.5,
+					"min_longitude": -125,
+					"max_latitude":  49.5,
+					"max_longitude": -66.9,
+				},
+			},
+		},
+		[]byte(`{"lat":"39.7392","lon":"-104.9903"}`),
+		true,
+	},
+	{
+		"fail box",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  24.5,
+					"min_longitude": -125,
+					"max_latitude":  49.5,
+					"max_longitude": -66.9,
+				},
+			},
+		},
+		[]byte(`{"lat":51.5074,"lon":-0.1278}`),
+		false,
+	},
+	{
+		"fail box",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  -1,
+					"min_longitude": -1,
+					"max_latitude":  1,
+					"max_longitude": 1,
+				},
+			},
+		},
+		[]byte(`{"lat":"unknown","lon":"n/a"}`),
+		false,
+	},
+	{
+		"fail box",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  -1,
+					"min_longitude": -1,
+					"max_latitude":  1,
+					"max_longitude": 1,
+				},
+			},
+		},
+		[]byte(`{"lat":null,"lon":""}`),
+		false,
+	},
+	{
+		"fail box",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  -1,
+					"min_longitude": -1,
+					"max_latitude":  1,
+					"max_longitude": 1,
+				},
+			},
+		},
+		[]byte(`{"lat":"0, 0","lon":true}`),
+		false,
+	},
+	{
+		"pass box antimeridian",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  -50,
+					"min_longitude": 170,
+					"max_latitude":  -30,
+					"max_longitude": -170,
+				},
+			},
+		},
+		[]byte(`{"lat":-41.2865,"lon":174.7762}`),
+		true,
+	},
+	{
+		"fail box antimeridian",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"box": map[string]interface{}{
+					"min_latitude":  -50,
+					"min_longitude": 170,
+					"max_latitude":  -30,
+					"max_longitude": -170,
+				},
+			},
+		},
+		[]byte(`{"lat":-41.2865,"lon":0}`),
+		false,
+	},
+}
+
+func TestGeoWithin(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range geoWithinTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newGeoWithin(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkGeoWithinByte(b *testing.B, insp *geoWithin, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkGeoWithinByte(b *testing.B) {
+	for _, test := range geoWithinTests {
+		insp, err := newGeoWithin(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkGeoWithinByte(b, insp, message)
+			},
+		)
+	}
+}
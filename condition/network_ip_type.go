@@ -0,0 +1,129 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type networkIPTypeConfig struct {
+	// Type is the class of IP address that is matched during inspection.
+	//
+	// Must be one of:
+	//	- global_unicast
+	//	- link_local_multicast
+	//	- link_local_unicast
+	//	- loopback
+	//	- multicast
+	//	- private
+	//	- public: global unicast addresses that are not private
+	//	- unspecified
+	Type string `json:"type"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *networkIPTypeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *networkIPTypeConfig) Validate() error {
+	if c.Type == "" {
+		return fmt.Errorf("type: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"global_unicast",
+			"link_local_multicast",
+			"link_local_unicast",
+			"loopback",
+			"multicast",
+			"private",
+			"public",
+			"unspecified",
+		},
+		c.Type) {
+		return fmt.Errorf("type %q: %v", c.Type, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newNetworkIPType(_ context.Context, cfg config.Config) (*networkIPType, error) {
+	conf := networkIPTypeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: network_ip_type: %v", err)
+	}
+
+	insp := networkIPType{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+type networkIPType struct {
+	conf networkIPTypeConfig
+}
+
+func (insp *networkIPType) Inspect(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	if insp.conf.Object.SourceKey == "" {
+		ip := net.ParseIP(string(msg.Data()))
+		return insp.match(ip), nil
+	}
+
+	value := msg.GetValue(insp.conf.Object.SourceKey)
+	ip := net.ParseIP(value.String())
+
+	return insp.match(ip), nil
+}
+
+func (insp *networkIPType) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
+
+// match returns true if the IP address is the configured type. Invalid
+// addresses never match.
+func (insp *networkIPType) match(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	switch insp.conf.Type {
+	case "global_unicast":
+		return ip.IsGlobalUnicast()
+	case "link_local_multicast":
+		return ip.IsLinkLocalMulticast()
+	case "link_local_unicast":
+		return ip.IsLinkLocalUnicast()
+	case "loopback":
+		return ip.IsLoopback()
+	case "multicast":
+		return ip.IsMulticast()
+	case "private":
+		return ip.IsPrivate()
+	case "public":
+		return ip.IsGlobalUnicast() && !ip.IsPrivate()
+	case "unspecified":
+		return ip.IsUnspecified()
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &networkIPType{}
+
+var networkIPTypeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"public",
+		config.Config{
+			Settings: map[string]interface{}{
+				"type": "public",
+			},
+		},
+		[]byte(`8.8.8.8`),
+		true,
+	},
+	{
+		"public private",
+		config.Config{
+			Settings: map[string]interface{}{
+				"type": "public",
+			},
+		},
+		[]byte(`10.0.0.1`),
+		false,
+	},
+	{
+		"private",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "ip_address",
+				},
+				"type": "private",
+			},
+		},
+		[]byte(`{"ip_address":"192.168.1.2"}`),
+		true,
+	},
+	{
+		"loopback",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "ip_address",
+				},
+				"type": "loopback",
+			},
+		},
+		[]byte(`{"ip_address":"::1"}`),
+		true,
+	},
+	{
+		"link_local_unicast",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "ip_address",
+				},
+				"type": "link_local_unicast",
+			},
+		},
+		[]byte(`{"ip_address":"169.254.1.1"}`),
+		true,
+	},
+	{
+		"invalid",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "ip_address",
+				},
+				"type": "private",
+			},
+		},
+		[]byte(`{"ip_address":"foo"}`),
+		false,
+	},
+	{
+		"unspecified invalid",
+		config.Config{
+			Settings: map[string]interface{}{
+				"type": "unspecified",
+			},
+		},
+		[]byte(``),
+		false,
+	},
+}
+
+func TestNetworkIPType(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range networkIPTypeTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newNetworkIPType(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v, %v", test.expected, check, string(test.test))
+			}
+		})
+	}
+}
+
+func benchmarkNetworkIPTypeByte(b *testing.B, insp *networkIPType, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkNetworkIPTypeByte(b *testing.B) {
+	for _, test := range networkIPTypeTests {
+		insp, err := newNetworkIPType(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkNetworkIPTypeByte(b, insp, message)
+			},
+		)
+	}
+}
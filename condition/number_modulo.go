@@ -0,0 +1,117 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type numberModuloConfig struct {
+	Object iconfig.Object `json:"object"`
+
+	// Modulo is the divisor that the value is divided by (e.g., 100 for
+	// percentages).
+	Modulo uint64 `json:"modulo"`
+	// LessThan is the value that the remainder must be less than. For example,
+	// a modulo of 100 and a value of 10 matches 10% of values.
+	LessThan uint64 `json:"less_than"`
+	// Hash determines if the value is hashed (using FNV-1a) before the modulo
+	// is calculated. This is required for values that are not integers (e.g.,
+	// user IDs) and distributes sequential integers evenly.
+	//
+	// This is optional and defaults to false.
+	Hash bool `json:"hash"`
+}
+
+func (c *numberModuloConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *numberModuloConfig) Validate() error {
+	if c.Modulo == 0 {
+		return fmt.Errorf("modulo: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.LessThan > c.Modulo {
+		return fmt.Errorf("less_than: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newNumberModulo(_ context.Context, cfg config.Config) (*numberModulo, error) {
+	conf := numberModuloConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition: number_modulo: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: number_modulo: %v", err)
+	}
+
+	insp := numberModulo{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+// numberModulo evaluates if the remainder of a value divided by a modulo is
+// less than a value. This can be used to deterministically sample or route a
+// percentage of data, because the same value is always in the same group.
+type numberModulo struct {
+	conf numberModuloConfig
+}
+
+func (insp *numberModulo) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	var b []byte
+	if insp.conf.Object.SourceKey == "" {
+		b = msg.Data()
+	} else {
+		value := msg.GetValue(insp.conf.Object.SourceKey)
+		if !value.Exists() {
+			return false, nil
+		}
+
+		b = value.Bytes()
+	}
+
+	var n uint64
+	if insp.conf.Hash {
+		h := fnv.New64a()
+		_, _ = h.Write(b)
+		n = h.Sum64()
+	} else {
+		i, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("condition: number_modulo: %v", err)
+		}
+
+		// Negative values use the absolute value, so they are in the same
+		// group as positive values.
+		if i < 0 {
+			n = uint64(-i)
+		} else {
+			n = uint64(i)
+		}
+	}
+
+	return n%insp.conf.Modulo < insp.conf.LessThan, nil
+}
+
+func (insp *numberModulo) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
@@ -0,0 +1,142 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &numberModulo{}
+
+var numberModuloTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"modulo":    100,
+				"less_than": 10,
+			},
+		},
+		[]byte("1205"),
+		true,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"modulo":    100,
+				"less_than": 10,
+			},
+		},
+		[]byte("1210"),
+		false,
+	},
+	{
+		"pass negative",
+		config.Config{
+			Settings: map[string]interface{}{
+				"modulo":    10,
+				"less_than": 5,
+			},
+		},
+		[]byte("-13"),
+		true,
+	},
+	{
+		"pass object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"modulo":    2,
+				"less_than": 1,
+			},
+		},
+		[]byte(`{"a":42}`),
+		true,
+	},
+	{
+		"pass hash",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "user",
+				},
+				"modulo":    100,
+				"less_than": 50,
+				"hash":      true,
+			},
+		},
+		[]byte(`{"user":"erin"}`),
+		true,
+	},
+	{
+		"fail hash",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "user",
+				},
+				"modulo":    100,
+				"less_than": 50,
+				"hash":      true,
+			},
+		},
+		[]byte(`{"user":"alice"}`),
+		false,
+	},
+}
+
+func TestNumberModulo(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range numberModuloTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newNumberModulo(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkNumberModuloByte(b *testing.B, insp *numberModulo, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkNumberModuloByte(b *testing.B) {
+	for _, test := range numberModuloTests {
+		insp, err := newNumberModulo(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkNumberModuloByte(b, insp, message)
+			},
+		)
+	}
+}
@@ -0,0 +1,97 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringGlobConfig struct {
+	Object iconfig.Object `json:"object"`
+
+	// Pattern is the glob pattern used during inspection (e.g., *.example.com).
+	//
+	// The pattern uses the syntax of path.Match: "*" matches any sequence of
+	// characters except "/", "?" matches any single character except "/", and
+	// "[...]" matches a character class.
+	Pattern string `json:"pattern"`
+	// CaseInsensitive determines if the pattern is matched without regard to case.
+	//
+	// This is optional and defaults to false.
+	CaseInsensitive bool `json:"case_insensitive"`
+}
+
+func (c *stringGlobConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringGlobConfig) Validate() error {
+	if c.Pattern == "" {
+		return fmt.Errorf("pattern: %v", errors.ErrMissingRequiredOption)
+	}
+
+	// path.Match only returns an error for an invalid pattern.
+	if _, err := path.Match(c.Pattern, ""); err != nil {
+		return fmt.Errorf("pattern %q: %v", c.Pattern, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringGlob(_ context.Context, cfg config.Config) (*stringGlob, error) {
+	conf := stringGlobConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, err
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: string_glob: %v", err)
+	}
+
+	if conf.CaseInsensitive {
+		conf.Pattern = strings.ToLower(conf.Pattern)
+	}
+
+	insp := stringGlob{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+type stringGlob struct {
+	conf stringGlobConfig
+}
+
+func (insp *stringGlob) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	var str string
+	if insp.conf.Object.SourceKey == "" {
+		str = string(msg.Data())
+	} else {
+		str = msg.GetValue(insp.conf.Object.SourceKey).String()
+	}
+
+	if insp.conf.CaseInsensitive {
+		str = strings.ToLower(str)
+	}
+
+	// The pattern is validated when the inspector is created.
+	match, _ := path.Match(insp.conf.Pattern, str)
+	return match, nil
+}
+
+func (insp *stringGlob) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
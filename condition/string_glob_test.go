@@ -0,0 +1,132 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &stringGlob{}
+
+var stringGlobTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": "*.internal.example.com",
+			},
+		},
+		[]byte("api.internal.example.com"),
+		true,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": "*.internal.example.com",
+			},
+		},
+		[]byte("api.example.com"),
+		false,
+	},
+	{
+		"fail case",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": "*.internal.example.com",
+			},
+		},
+		[]byte("API.Internal.Example.com"),
+		false,
+	},
+	{
+		"pass case_insensitive",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":          "*.internal.example.com",
+				"case_insensitive": true,
+			},
+		},
+		[]byte("API.Internal.Example.com"),
+		true,
+	},
+	{
+		"pass object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"pattern": "/var/log/*.log",
+			},
+		},
+		[]byte(`{"a":"/var/log/syslog.log"}`),
+		true,
+	},
+	{
+		"fail object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"pattern": "/var/log/*.log",
+			},
+		},
+		[]byte(`{"a":"/var/log/nginx/access.log"}`),
+		false,
+	},
+}
+
+func TestStringGlob(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range stringGlobTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newStringGlob(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkStringGlobByte(b *testing.B, insp *stringGlob, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkStringGlobByte(b *testing.B) {
+	for _, test := range stringGlobTests {
+		insp, err := newStringGlob(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkStringGlobByte(b, insp, message)
+			},
+		)
+	}
+}
@@ -0,0 +1,117 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type stringMatchListConfig struct {
+	Object iconfig.Object `json:"object"`
+
+	// Patterns are the regular expressions used during inspection.
+	Patterns []string `json:"patterns"`
+	// Match determines how many patterns must match.
+	//
+	// Must be one of:
+	//	- any: at least one pattern matches
+	//	- all: every pattern matches
+	//
+	// This is optional and defaults to any.
+	Match string `json:"match"`
+}
+
+func (c *stringMatchListConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringMatchListConfig) Validate() error {
+	if len(c.Patterns) == 0 {
+		return fmt.Errorf("patterns: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"any",
+			"all",
+		},
+		c.Match) {
+		return fmt.Errorf("match %q: %v", c.Match, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringMatchList(_ context.Context, cfg config.Config) (*stringMatchList, error) {
+	conf := stringMatchListConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition: string_match_list: %v", err)
+	}
+
+	if conf.Match == "" {
+		conf.Match = "any"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: string_match_list: %v", err)
+	}
+
+	insp := stringMatchList{
+		conf: conf,
+		re:   make([]*regexp.Regexp, len(conf.Patterns)),
+	}
+
+	for i, p := range conf.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("condition: string_match_list: pattern %q: %v", p, err)
+		}
+
+		insp.re[i] = re
+	}
+
+	return &insp, nil
+}
+
+// stringMatchList evaluates data against a list of regular expressions.
+// Patterns are evaluated in order and inspection stops as soon as the
+// result is known, so frequently matching patterns should be first.
+type stringMatchList struct {
+	conf stringMatchListConfig
+
+	re []*regexp.Regexp
+}
+
+func (insp *stringMatchList) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	var b []byte
+	if insp.conf.Object.SourceKey == "" {
+		b = msg.Data()
+	} else {
+		b = msg.GetValue(insp.conf.Object.SourceKey).Bytes()
+	}
+
+	all := insp.conf.Match == "all"
+	for _, re := range insp.re {
+		if re.Match(b) != all {
+			return !all, nil
+		}
+	}
+
+	return all, nil
+}
+
+func (insp *stringMatchList) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
@@ -0,0 +1,120 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &stringMatchList{}
+
+var stringMatchListTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"any pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"patterns": []string{"^Test", "timeout$"},
+			},
+		},
+		[]byte("connection timeout"),
+		true,
+	},
+	{
+		"any fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"patterns": []string{"^Test", "timeout$"},
+			},
+		},
+		[]byte("connection refused"),
+		false,
+	},
+	{
+		"all pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"patterns": []string{"^conn", "timeout$"},
+				"match":    "all",
+			},
+		},
+		[]byte("connection timeout"),
+		true,
+	},
+	{
+		"all fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"patterns": []string{"^conn", "refused$"},
+				"match":    "all",
+			},
+		},
+		[]byte("connection timeout"),
+		false,
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"patterns": []string{"^x", "^E[0-9]+"},
+			},
+		},
+		[]byte(`{"a":"E500"}`),
+		true,
+	},
+}
+
+func TestStringMatchList(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range stringMatchListTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newStringMatchList(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkStringMatchListByte(b *testing.B, insp *stringMatchList, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkStringMatchListByte(b *testing.B) {
+	for _, test := range stringMatchListTests {
+		insp, err := newStringMatchList(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkStringMatchListByte(b, insp, message)
+			},
+		)
+	}
+}
@@ -0,0 +1,172 @@
+package condition
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type utilityChecksumConfig struct {
+	// ChecksumKey retrieves the expected checksum from the message.
+	ChecksumKey string `json:"checksum_key"`
+	// Algorithm is the hashing algorithm that computes the checksum.
+	//
+	// Must be one of:
+	//	- crc32
+	//	- md5
+	//	- sha1
+	//	- sha256
+	//	- sha512
+	//
+	// This is optional and defaults to sha256.
+	Algorithm string `json:"algorithm"`
+	// Encoding is the encoding of the expected checksum.
+	//
+	// Must be one of:
+	//	- hex: case insensitive
+	//	- base64
+	//
+	// This is optional and defaults to hex.
+	Encoding string `json:"encoding"`
+
+	// Object.SourceKey retrieves the value that is hashed. If not set, then the
+	// entire message (without the checksum key) is hashed.
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *utilityChecksumConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *utilityChecksumConfig) Validate() error {
+	if c.ChecksumKey == "" {
+		return fmt.Errorf("checksum_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"crc32",
+			"md5",
+			"sha1",
+			"sha256",
+			"sha512",
+		},
+		c.Algorithm) {
+		return fmt.Errorf("algorithm %q: %v", c.Algorithm, errors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"hex",
+			"base64",
+		},
+		c.Encoding) {
+		return fmt.Errorf("encoding %q: %v", c.Encoding, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newUtilityChecksum(_ context.Context, cfg config.Config) (*utilityChecksum, error) {
+	conf := utilityChecksumConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition: utility_checksum: %v", err)
+	}
+
+	if conf.Algorithm == "" {
+		conf.Algorithm = "sha256"
+	}
+
+	if conf.Encoding == "" {
+		conf.Encoding = "hex"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: utility_checksum: %v", err)
+	}
+
+	insp := utilityChecksum{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+// utilityChecksum verifies the integrity of data by recomputing its checksum
+// and comparing it to the expected checksum that is stored in the message.
+type utilityChecksum struct {
+	conf utilityChecksumConfig
+}
+
+func (insp *utilityChecksum) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	expected := msg.GetValue(insp.conf.ChecksumKey)
+	if !expected.Exists() {
+		return false, nil
+	}
+
+	var b []byte
+	if insp.conf.Object.SourceKey != "" {
+		value := msg.GetValue(insp.conf.Object.SourceKey)
+		if !value.Exists() {
+			return false, nil
+		}
+
+		b = value.Bytes()
+	} else {
+		// The checksum cannot be part of the data that it verifies.
+		tmp := message.New().SetData(msg.Data())
+		if err := tmp.DeleteValue(insp.conf.ChecksumKey); err != nil {
+			return false, fmt.Errorf("condition: utility_checksum: %v", err)
+		}
+
+		b = tmp.Data()
+	}
+
+	var h hash.Hash
+	switch insp.conf.Algorithm {
+	case "crc32":
+		h = crc32.NewIEEE()
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	}
+
+	_, _ = h.Write(b)
+	sum := h.Sum(nil)
+
+	switch insp.conf.Encoding {
+	case "base64":
+		return expected.String() == base64.StdEncoding.EncodeToString(sum), nil
+	default:
+		return strings.EqualFold(expected.String(), hex.EncodeToString(sum)), nil
+	}
+}
+
+func (insp *utilityChecksum) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
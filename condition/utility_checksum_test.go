@@ -0,0 +1,169 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &utilityChecksum{}
+
+var utilityChecksumTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello","sum":"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}`),
+		true,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello","sum":"2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"}`),
+		true,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hellp","sum":"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}`),
+		false,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello"}`),
+		false,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"algorithm":    "md5",
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello","sum":"5d41402abc4b2a76b9719d911017c592"}`),
+		true,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"algorithm":    "crc32",
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello","sum":"3610a686"}`),
+		true,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"encoding":     "base64",
+				"checksum_key": "sum",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello","sum":"LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}`),
+		true,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"checksum_key": "sum",
+			},
+		},
+		[]byte(`{"a":"hello","sum":"7dee12d1e30857e2bd84d9dff7f63e2d5b97f575d015f4b4f26d86c671110134"}`),
+		true,
+	},
+}
+
+func TestUtilityChecksum(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range utilityChecksumTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newUtilityChecksum(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkUtilityChecksumByte(b *testing.B, insp *utilityChecksum, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkUtilityChecksumByte(b *testing.B) {
+	for _, test := range utilityChecksumTests {
+		insp, err := newUtilityChecksum(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkUtilityChecksumByte(b, insp, message)
+			},
+		)
+	}
+}
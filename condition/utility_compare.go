@@ -0,0 +1,163 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type utilityCompareConfig struct {
+	// LeftKey retrieves the value on the left side of the comparison.
+	LeftKey string `json:"left_key"`
+	// RightKey retrieves the value on the right side of the comparison.
+	RightKey string `json:"right_key"`
+	// Operator is the comparison made between the left and right values.
+	//
+	// Must be one of:
+	//	- eq: equal to
+	//	- ne: not equal to
+	//	- lt: less than
+	//	- le: less than or equal to
+	//	- gt: greater than
+	//	- ge: greater than or equal to
+	Operator string `json:"operator"`
+	// Mode determines how the values are compared.
+	//
+	// Must be one of:
+	//	- string: values are compared lexically
+	//	- number: values are compared numerically
+	//
+	// This is optional and defaults to string. If the mode is number and
+	// either value is not a number, then the inspection is false.
+	Mode string `json:"mode"`
+}
+
+func (c *utilityCompareConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *utilityCompareConfig) Validate() error {
+	if c.LeftKey == "" {
+		return fmt.Errorf("left_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.RightKey == "" {
+		return fmt.Errorf("right_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"eq",
+			"ne",
+			"lt",
+			"le",
+			"gt",
+			"ge",
+		},
+		c.Operator) {
+		return fmt.Errorf("operator %q: %v", c.Operator, errors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"string",
+			"number",
+		},
+		c.Mode) {
+		return fmt.Errorf("mode %q: %v", c.Mode, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newUtilityCompare(_ context.Context, cfg config.Config) (*utilityCompare, error) {
+	conf := utilityCompareConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, err
+	}
+
+	if conf.Mode == "" {
+		conf.Mode = "string"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: utility_compare: %v", err)
+	}
+
+	insp := utilityCompare{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+type utilityCompare struct {
+	conf utilityCompareConfig
+}
+
+func (insp *utilityCompare) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	left := msg.GetValue(insp.conf.LeftKey)
+	right := msg.GetValue(insp.conf.RightKey)
+
+	// Missing values cannot be compared.
+	if !left.Exists() || !right.Exists() {
+		return false, nil
+	}
+
+	var cmp int
+	switch insp.conf.Mode {
+	case "number":
+		l, err := strconv.ParseFloat(left.String(), 64)
+		if err != nil {
+			return false, nil
+		}
+
+		r, err := strconv.ParseFloat(right.String(), 64)
+		if err != nil {
+			return false, nil
+		}
+
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	default:
+		cmp = strings.Compare(left.String(), right.String())
+	}
+
+	switch insp.conf.Operator {
+	case "eq":
+		return cmp == 0, nil
+	case "ne":
+		return cmp != 0, nil
+	case "lt":
+		return cmp < 0, nil
+	case "le":
+		return cmp <= 0, nil
+	case "gt":
+		return cmp > 0, nil
+	case "ge":
+		return cmp >= 0, nil
+	}
+
+	return false, nil
+}
+
+func (insp *utilityCompare) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
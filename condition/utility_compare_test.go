@@ -0,0 +1,202 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &utilityCompare{}
+
+var utilityCompareTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass eq",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "eq",
+			},
+		},
+		[]byte(`{"a":"x","b":"x"}`),
+		true,
+	},
+	{
+		"fail eq",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "eq",
+			},
+		},
+		[]byte(`{"a":"x","b":"y"}`),
+		false,
+	},
+	{
+		"pass ne",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "ne",
+			},
+		},
+		[]byte(`{"a":"x","b":"y"}`),
+		true,
+	},
+	{
+		"pass lt string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "lt",
+			},
+		},
+		[]byte(`{"a":"2023-01-01T00:00:00Z","b":"2023-01-02T00:00:00Z"}`),
+		true,
+	},
+	{
+		"pass lt string lexical",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "lt",
+			},
+		},
+		[]byte(`{"a":10,"b":9}`),
+		true,
+	},
+	{
+		"fail lt number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "lt",
+				"mode":      "number",
+			},
+		},
+		[]byte(`{"a":10,"b":9}`),
+		false,
+	},
+	{
+		"pass gt number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "gt",
+				"mode":      "number",
+			},
+		},
+		[]byte(`{"a":10,"b":"9.5"}`),
+		true,
+	},
+	{
+		"pass le number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "le",
+				"mode":      "number",
+			},
+		},
+		[]byte(`{"a":1,"b":1.0}`),
+		true,
+	},
+	{
+		"pass ge number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "ge",
+				"mode":      "number",
+			},
+		},
+		[]byte(`{"a":2,"b":1}`),
+		true,
+	},
+	{
+		"fail number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "eq",
+				"mode":      "number",
+			},
+		},
+		[]byte(`{"a":"x","b":"x"}`),
+		false,
+	},
+	{
+		"fail missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"left_key":  "a",
+				"right_key": "b",
+				"operator":  "eq",
+			},
+		},
+		[]byte(`{"a":"x"}`),
+		false,
+	},
+}
+
+func TestUtilityCompare(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range utilityCompareTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newUtilityCompare(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkUtilityCompareByte(b *testing.B, insp *utilityCompare, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkUtilityCompareByte(b *testing.B) {
+	for _, test := range utilityCompareTests {
+		insp, err := newUtilityCompare(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkUtilityCompareByte(b, insp, message)
+			},
+		)
+	}
+}
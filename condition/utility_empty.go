@@ -0,0 +1,89 @@
+package condition
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/message"
+)
+
+type utilityEmptyConfig struct {
+	// TrimSpace determines if strings that only contain whitespace are empty.
+	//
+	// This is optional and defaults to false.
+	TrimSpace bool `json:"trim_space"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *utilityEmptyConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func newUtilityEmpty(_ context.Context, cfg config.Config) (*utilityEmpty, error) {
+	conf := utilityEmptyConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, err
+	}
+
+	insp := utilityEmpty{
+		conf: conf,
+	}
+
+	return &insp, nil
+}
+
+type utilityEmpty struct {
+	conf utilityEmptyConfig
+}
+
+// Inspect returns true if the value is missing, null, an empty string, an
+// empty array, or an empty object. Numbers and booleans are never empty.
+//
+// If no key is configured, then the message data is empty if it contains
+// no bytes.
+func (insp *utilityEmpty) Inspect(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	if insp.conf.Object.SourceKey == "" {
+		data := msg.Data()
+		if insp.conf.TrimSpace {
+			data = bytes.TrimSpace(data)
+		}
+
+		return len(data) == 0, nil
+	}
+
+	value := msg.GetValue(insp.conf.Object.SourceKey)
+	if !value.Exists() {
+		return true, nil
+	}
+
+	switch v := value.Value().(type) {
+	case nil:
+		return true, nil
+	case string:
+		if insp.conf.TrimSpace {
+			v = strings.TrimSpace(v)
+		}
+
+		return v == "", nil
+	case []interface{}:
+		return len(v) == 0, nil
+	case map[string]interface{}:
+		return len(v) == 0, nil
+	default:
+		return false, nil
+	}
+}
+
+func (insp *utilityEmpty) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
@@ -0,0 +1,208 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &utilityEmpty{}
+
+var utilityEmptyTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass data",
+		config.Config{},
+		[]byte(""),
+		true,
+	},
+	{
+		"fail data",
+		config.Config{},
+		[]byte(" "),
+		false,
+	},
+	{
+		"pass data trim_space",
+		config.Config{
+			Settings: map[string]interface{}{
+				"trim_space": true,
+			},
+		},
+		[]byte(" \n"),
+		true,
+	},
+	{
+		"pass missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"b":"c"}`),
+		true,
+	},
+	{
+		"pass null",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":null}`),
+		true,
+	},
+	{
+		"pass string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":""}`),
+		true,
+	},
+	{
+		"fail string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"  "}`),
+		false,
+	},
+	{
+		"pass string trim_space",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"trim_space": true,
+			},
+		},
+		[]byte(`{"a":"  "}`),
+		true,
+	},
+	{
+		"pass array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[]}`),
+		true,
+	},
+	{
+		"fail array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[""]}`),
+		false,
+	},
+	{
+		"pass object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{}}`),
+		true,
+	},
+	{
+		"fail number",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":0}`),
+		false,
+	},
+	{
+		"fail bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":false}`),
+		false,
+	},
+}
+
+func TestUtilityEmpty(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range utilityEmptyTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newUtilityEmpty(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkUtilityEmptyByte(b *testing.B, insp *utilityEmpty, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkUtilityEmptyByte(b *testing.B) {
+	for _, test := range utilityEmptyTests {
+		insp, err := newUtilityEmpty(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkUtilityEmptyByte(b, insp, message)
+			},
+		)
+	}
+}
@@ -0,0 +1,120 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	iexpr "github.com/brexhq/substation/internal/expr"
+	"github.com/brexhq/substation/message"
+)
+
+type utilityExpressionConfig struct {
+	// Expression is the boolean expression that is evaluated against the
+	// message. Keys in the object are referenced by name (e.g., "status >= 400
+	// && path contains '/api'") and nested keys are referenced using dot
+	// notation (e.g., "request.method == 'POST'").
+	//
+	// The expression supports arithmetic operators (+, -, *, /, %, **),
+	// comparison operators (==, !=, <, <=, >, >=), logical operators (&&,
+	// ||, !, and, or, not), string operators (contains, startsWith, endsWith,
+	// matches), and parentheses. These functions are supported:
+	//	- abs, ceil, floor, round
+	//	- min, max, sum, mean, median
+	//	- int, float, string, len
+	//	- lower, upper, trim
+	//
+	// Keys that do not exist in the object are nil. Comparing nil to a number
+	// is an error, so optional keys should be checked first (e.g., "status !=
+	// nil && status >= 400").
+	//
+	// Refer to https://expr-lang.org/docs/language-definition for the
+	// full language definition.
+	Expression string `json:"expression"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *utilityExpressionConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *utilityExpressionConfig) Validate() error {
+	if c.Expression == "" {
+		return fmt.Errorf("expression: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newUtilityExpression(_ context.Context, cfg config.Config) (*utilityExpression, error) {
+	conf := utilityExpressionConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition: utility_expression: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("condition: utility_expression: %v", err)
+	}
+
+	prog, err := iexpr.Compile(conf.Expression, expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("condition: utility_expression: %v", err)
+	}
+
+	insp := utilityExpression{
+		conf: conf,
+		prog: prog,
+	}
+
+	return &insp, nil
+}
+
+// utilityExpression evaluates a boolean expression against an object. The
+// expression is compiled once when the inspector is created.
+type utilityExpression struct {
+	conf utilityExpressionConfig
+
+	prog *vm.Program
+}
+
+func (insp *utilityExpression) Inspect(ctx context.Context, msg *message.Message) (output bool, err error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	b := msg.Data()
+	if insp.conf.Object.SourceKey != "" {
+		value := msg.GetValue(insp.conf.Object.SourceKey)
+		if !value.IsObject() {
+			return false, nil
+		}
+
+		b = value.Bytes()
+	}
+
+	env, err := iexpr.Env(b)
+	if err != nil {
+		return false, fmt.Errorf("condition: utility_expression: %v", err)
+	}
+
+	res, err := iexpr.Run(insp.prog, env)
+	if err != nil {
+		return false, fmt.Errorf("condition: utility_expression: %v", err)
+	}
+
+	// Expressions that reference a key that does not exist evaluate to nil.
+	ok, _ := res.(bool)
+	return ok, nil
+}
+
+func (insp *utilityExpression) String() string {
+	b, _ := json.Marshal(insp.conf)
+	return string(b)
+}
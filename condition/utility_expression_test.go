@@ -0,0 +1,163 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ inspector = &utilityExpression{}
+
+var utilityExpressionTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected bool
+}{
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": `status >= 400 and path contains "/api"`,
+			},
+		},
+		[]byte(`{"status":404,"path":"/api/users"}`),
+		true,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": `status >= 400 and path contains "/api"`,
+			},
+		},
+		[]byte(`{"status":200,"path":"/api/users"}`),
+		false,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": `status >= 400 and path contains "/api"`,
+			},
+		},
+		[]byte(`{"status":500,"path":"/health"}`),
+		false,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "(a + b) % 2 == 1 || lower(c) startsWith \"x\"",
+			},
+		},
+		[]byte(`{"a":1,"b":2,"c":"y"}`),
+		true,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "req.method in [\"POST\", \"PUT\"] && len(req.body) > 0",
+			},
+		},
+		[]byte(`{"req":{"method":"PUT","body":"a"}}`),
+		true,
+	},
+	{
+		"fail",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "missing",
+			},
+		},
+		[]byte(`{"a":1}`),
+		false,
+	},
+	{
+		"pass",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "b == 2",
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":2.0}}`),
+		true,
+	},
+}
+
+func TestUtilityExpression(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range utilityExpressionTests {
+		t.Run(test.name, func(t *testing.T) {
+			message := message.New().SetData(test.test)
+			insp, err := newUtilityExpression(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			check, err := insp.Inspect(ctx, message)
+			if err != nil {
+				t.Error(err)
+			}
+
+			if test.expected != check {
+				t.Errorf("expected %v, got %v", test.expected, check)
+			}
+		})
+	}
+}
+
+func benchmarkUtilityExpressionByte(b *testing.B, insp *utilityExpression, message *message.Message) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		_, _ = insp.Inspect(ctx, message)
+	}
+}
+
+func BenchmarkUtilityExpressionByte(b *testing.B) {
+	for _, test := range utilityExpressionTests {
+		insp, err := newUtilityExpression(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				message := message.New().SetData(test.test)
+				benchmarkUtilityExpressionByte(b, insp, message)
+			},
+		)
+	}
+}
+
+func TestUtilityExpressionInvalid(t *testing.T) {
+	ctx := context.TODO()
+	if _, err := newUtilityExpression(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"expression": "a +",
+		},
+	}); err == nil {
+		t.Error("expected error")
+	}
+
+	insp, err := newUtilityExpression(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"expression": "a > 1",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"b":1}`))
+	if _, err := insp.Inspect(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	backoffBaseDelay = 100 * time.Millisecond
+	backoffMaxDelay  = 20 * time.Second
+	// backoffMaxRetries is used if retries are not configured, which
+	// prevents failed items from being dropped without any retries.
+	backoffMaxRetries = 3
+)
+
+// ErrMaxRetriesExceeded is returned when items in a batch request continue to
+// fail after all retries are exhausted.
+var ErrMaxRetriesExceeded = fmt.Errorf("maximum retries exceeded")
+
+// Backoff retries items that fail in batch requests (e.g., throttled records in a
+// Kinesis PutRecords request). These are not retried by the SDK because the
+// request succeeds even if some items fail.
+type Backoff struct {
+	// MaxRetries is the maximum number of times that failed items are retried.
+	MaxRetries int
+	// MaxDelay is the maximum amount of time to wait between retries.
+	MaxDelay time.Duration
+}
+
+// NewBackoff returns a Backoff that uses the same retry settings as the SDK.
+func NewBackoff(cfg Config) Backoff {
+	b := Backoff{
+		MaxRetries: cfg.MaxRetries,
+		MaxDelay:   backoffMaxDelay,
+	}
+
+	if d, err := time.ParseDuration(cfg.MaxRetryDelay); err == nil && d > 0 {
+		b.MaxDelay = d
+	}
+
+	// Configurations take precedence over environment variables.
+	if cfg.MaxRetries != 0 {
+		return b
+	}
+
+	if v, ok := os.LookupEnv("AWS_MAX_ATTEMPTS"); ok {
+		if max, err := strconv.Atoi(v); err == nil && max > 0 {
+			b.MaxRetries = max
+			return b
+		}
+	}
+
+	b.MaxRetries = backoffMaxRetries
+	return b
+}
+
+// Wait blocks for an exponential backoff duration with full jitter. It returns
+// false if the attempt exceeds the maximum number of retries or the context is
+// done, which means that the items should not be retried.
+func (b Backoff) Wait(ctx context.Context, attempt int) bool {
+	if attempt >= b.MaxRetries || ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.delay(attempt)):
+		return true
+	}
+}
+
+// delay returns a random duration between zero and the exponential backoff
+// for the attempt, which is capped by MaxDelay.
+func (b Backoff) delay(attempt int) time.Duration {
+	d := backoffBaseDelay << attempt
+	if d <= 0 || d > b.MaxDelay {
+		d = b.MaxDelay
+	}
+
+	//nolint: gosec // Jitter does not require a secure random number generator.
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
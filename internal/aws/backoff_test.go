@@ -0,0 +1,149 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		env      string
+		expected Backoff
+	}{
+		{
+			"defaults",
+			Config{},
+			"",
+			Backoff{MaxRetries: backoffMaxRetries, MaxDelay: backoffMaxDelay},
+		},
+		{
+			"config",
+			Config{MaxRetries: 5, MaxRetryDelay: "1s"},
+			"10",
+			Backoff{MaxRetries: 5, MaxDelay: time.Second},
+		},
+		{
+			"environment",
+			Config{},
+			"10",
+			Backoff{MaxRetries: 10, MaxDelay: backoffMaxDelay},
+		},
+		{
+			"invalid environment",
+			Config{},
+			"ten",
+			Backoff{MaxRetries: backoffMaxRetries, MaxDelay: backoffMaxDelay},
+		},
+		{
+			"invalid delay",
+			Config{MaxRetries: 1, MaxRetryDelay: "soon"},
+			"",
+			Backoff{MaxRetries: 1, MaxDelay: backoffMaxDelay},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("AWS_MAX_ATTEMPTS", test.env)
+
+			b := NewBackoff(test.cfg)
+			if b != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, b)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{MaxRetries: 100, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, backoffBaseDelay},
+		{1, 2 * backoffBaseDelay},
+		{3, 8 * backoffBaseDelay},
+		// Capped by MaxDelay.
+		{4, time.Second},
+		{10, time.Second},
+		// The shift overflows and is capped by MaxDelay.
+		{64, time.Second},
+	}
+
+	for _, test := range tests {
+		seen := make(map[time.Duration]struct{})
+		for i := 0; i < 100; i++ {
+			d := b.delay(test.attempt)
+			if d < 0 || d > test.max {
+				t.Fatalf("attempt %d: expected delay between 0 and %v, got %v", test.attempt, test.max, d)
+			}
+
+			seen[d] = struct{}{}
+		}
+
+		// Full jitter produces a range of delays.
+		if len(seen) < 2 {
+			t.Errorf("attempt %d: expected jitter, got %v", test.attempt, seen)
+		}
+	}
+}
+
+func TestBackoffWait(t *testing.T) {
+	ctx := context.TODO()
+	b := Backoff{MaxRetries: 2, MaxDelay: time.Millisecond}
+
+	for attempt := 0; attempt < b.MaxRetries; attempt++ {
+		if !b.Wait(ctx, attempt) {
+			t.Errorf("attempt %d: expected retry", attempt)
+		}
+	}
+
+	if b.Wait(ctx, b.MaxRetries) {
+		t.Errorf("attempt %d: expected no retry", b.MaxRetries)
+	}
+}
+
+func TestBackoffWaitMaxDelay(t *testing.T) {
+	ctx := context.TODO()
+	b := Backoff{MaxRetries: 100, MaxDelay: 10 * time.Millisecond}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if !b.Wait(ctx, 50) {
+			t.Fatal("expected retry")
+		}
+	}
+
+	// Without the cap, a single wait at this attempt would take years.
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected waits to be capped by %v, took %v", b.MaxDelay, elapsed)
+	}
+}
+
+func TestBackoffWaitCancel(t *testing.T) {
+	b := Backoff{MaxRetries: 100, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if b.Wait(ctx, 0) {
+		t.Error("expected no retry after the context is canceled")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	// Attempt 10 waits for up to an hour unless the context is done.
+	if b.Wait(ctx, 10) {
+		t.Error("expected no retry after the context is done")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected wait to return when the context is done, took %v", elapsed)
+	}
+}
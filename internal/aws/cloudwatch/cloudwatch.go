@@ -13,6 +13,9 @@ import (
 )
 
 const (
+	// This is the maximum number of metrics that can be sent in a single
+	// PutMetricData request.
+	putMetricDataMaxItems = 1000
 	// This is the period in seconds that the AWS Kinesis CloudWatch alarms
 	// will evaluate the metrics over.
 	kinesisMetricsPeriod = 60
@@ -92,6 +95,29 @@ func (a *API) IsEnabled() bool {
 	return a.Client != nil
 }
 
+// PutMetricData is a convenience wrapper for sending metrics to CloudWatch. Metrics are
+// sent in multiple requests if there are more than the API limit.
+func (a *API) PutMetricData(ctx aws.Context, namespace string, data []*cloudwatch.MetricDatum) error {
+	for i := 0; i < len(data); i += putMetricDataMaxItems {
+		end := i + putMetricDataMaxItems
+		if end > len(data) {
+			end = len(data)
+		}
+
+		if _, err := a.Client.PutMetricDataWithContext(
+			ctx,
+			&cloudwatch.PutMetricDataInput{
+				Namespace:  aws.String(namespace),
+				MetricData: data[i:end],
+			},
+		); err != nil {
+			return fmt.Errorf("put_metric_data: namespace %s: %v", namespace, err)
+		}
+	}
+
+	return nil
+}
+
 // UpdateKinesisDownscaleAlarm updates CloudWatch alarms that manage the scale down tracking for Kinesis streams.
 func (a *API) UpdateKinesisDownscaleAlarm(ctx aws.Context, name, stream, topic string, shards int64) error {
 	downscaleThreshold := kinesisThreshold - 0.35
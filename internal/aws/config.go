@@ -4,6 +4,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/brexhq/substation/internal/config"
 
@@ -19,6 +20,9 @@ type Config struct {
 	RoleARN         string   `json:"role_arn"`
 	MaxRetries      int      `json:"max_retries"`
 	RetryableErrors []string `json:"retryable_errors"`
+	// MaxRetryDelay is the maximum amount of time to wait between retries
+	// (e.g., 10s). If this is not set, then the SDK defaults are used.
+	MaxRetryDelay string `json:"max_retry_delay"`
 }
 
 // New returns a new AWS configuration and session.
@@ -36,6 +40,7 @@ func New(cfg Config) (*aws.Config, *session.Session) {
 	retryer := NewRetryer(config.Retry{
 		Count:         cfg.MaxRetries,
 		ErrorMessages: cfg.RetryableErrors,
+		MaxDelay:      cfg.MaxRetryDelay,
 	})
 
 	// Configurations take precedence over environment variables.
@@ -67,18 +72,29 @@ func NewDefault() (*aws.Config, *session.Session) {
 	return New(Config{})
 }
 
+// NewRetryer returns a retryer that uses exponential backoff with jitter. Throttling
+// errors, 5xx errors, and any errors that match the configured error messages are
+// retried.
 func NewRetryer(cfg config.Retry) customRetryer {
 	errMsg := make([]*regexp.Regexp, len(cfg.ErrorMessages))
 	for i, err := range cfg.ErrorMessages {
 		errMsg[i] = regexp.MustCompile(err)
 	}
 
-	return customRetryer{
+	r := customRetryer{
 		DefaultRetryer: client.DefaultRetryer{
 			NumMaxRetries: cfg.Count,
 		},
 		errorMessages: errMsg,
 	}
+
+	// If the delay is not set or is invalid, then the SDK defaults are used.
+	if d, err := time.ParseDuration(cfg.MaxDelay); err == nil && d > 0 {
+		r.MaxRetryDelay = d
+		r.MaxThrottleDelay = d
+	}
+
+	return r
 }
 
 type customRetryer struct {
@@ -88,7 +104,7 @@ type customRetryer struct {
 	errorMessages []*regexp.Regexp
 }
 
-func (r customRetryer) SetMaxRetries(max int) {
+func (r *customRetryer) SetMaxRetries(max int) {
 	r.NumMaxRetries = max
 }
 
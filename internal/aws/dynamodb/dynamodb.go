@@ -30,11 +30,14 @@ func New(cfg iaws.Config) *dynamodb.DynamoDB {
 // API wraps the DynamoDB API interface.
 type API struct {
 	Client dynamodbiface.DynamoDBAPI
+	// Backoff is used to retry items that fail in batch requests.
+	Backoff iaws.Backoff
 }
 
 // Setup creates a new DynamoDB client.
 func (a *API) Setup(cfg iaws.Config) {
 	a.Client = New(cfg)
+	a.Backoff = iaws.NewBackoff(cfg)
 }
 
 // IsEnabled returns true if the client is enabled and ready for use.
@@ -44,6 +47,10 @@ func (a *API) IsEnabled() bool {
 
 // BatchPutItem is a convenience wrapper for putting multiple items into a DynamoDB table.
 func (a *API) BatchPutItem(ctx aws.Context, table string, items []map[string]*dynamodb.AttributeValue) (resp *dynamodb.BatchWriteItemOutput, err error) {
+	return a.batchPutItem(ctx, table, items, 0)
+}
+
+func (a *API) batchPutItem(ctx aws.Context, table string, items []map[string]*dynamodb.AttributeValue, attempt int) (resp *dynamodb.BatchWriteItemOutput, err error) {
 	var requests []*dynamodb.WriteRequest
 	for _, item := range items {
 		requests = append(requests, &dynamodb.WriteRequest{
@@ -68,13 +75,18 @@ func (a *API) BatchPutItem(ctx aws.Context, table string, items []map[string]*dy
 			switch aerr.Code() {
 			case dynamodb.ErrCodeProvisionedThroughputExceededException:
 				var retry []map[string]*dynamodb.AttributeValue
-
-				for _, item := range resp.UnprocessedItems[table] {
-					retry = append(retry, item.PutRequest.Item)
+				if resp != nil {
+					for _, item := range resp.UnprocessedItems[table] {
+						retry = append(retry, item.PutRequest.Item)
+					}
 				}
 
 				if len(retry) > 0 {
-					return a.BatchPutItem(ctx, table, retry)
+					if !a.Backoff.Wait(ctx, attempt) {
+						return nil, fmt.Errorf("batch_put_item: table %s: %d items failed: %v", table, len(retry), iaws.ErrMaxRetriesExceeded)
+					}
+
+					return a.batchPutItem(ctx, table, retry, attempt+1)
 				}
 
 				fallthrough
@@ -84,6 +96,25 @@ func (a *API) BatchPutItem(ctx aws.Context, table string, items []map[string]*dy
 		}
 	}
 
+	if err != nil {
+		return nil, fmt.Errorf("batch_put_item: table %s: %v", table, err)
+	}
+
+	// Items that were not processed (e.g., due to throttling) are retried
+	// with backoff.
+	if len(resp.UnprocessedItems[table]) > 0 {
+		var retry []map[string]*dynamodb.AttributeValue
+		for _, item := range resp.UnprocessedItems[table] {
+			retry = append(retry, item.PutRequest.Item)
+		}
+
+		if !a.Backoff.Wait(ctx, attempt) {
+			return nil, fmt.Errorf("batch_put_item: table %s: %d items failed: %v", table, len(retry), iaws.ErrMaxRetriesExceeded)
+		}
+
+		return a.batchPutItem(ctx, table, retry, attempt+1)
+	}
+
 	return resp, nil
 }
 
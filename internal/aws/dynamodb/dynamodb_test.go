@@ -2,13 +2,16 @@ package dynamodb
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	iaws "github.com/brexhq/substation/internal/aws"
 )
 
 type mockedGetItem struct {
@@ -42,7 +45,7 @@ func TestGetItem(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedGetItem{Resp: test.resp},
+			Client: mockedGetItem{Resp: test.resp},
 		}
 
 		m := make(map[string]interface{})
@@ -99,7 +102,7 @@ func TestBatchPutItem(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedBatchPutItem{Resp: test.resp},
+			Client: mockedBatchPutItem{Resp: test.resp},
 		}
 
 		resp, err := a.BatchPutItem(ctx, "", []map[string]*dynamodb.AttributeValue{})
@@ -151,7 +154,7 @@ func TestPutItem(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPutItem{Resp: test.resp},
+			Client: mockedPutItem{Resp: test.resp},
 		}
 
 		resp, err := a.PutItem(ctx, "", map[string]*dynamodb.AttributeValue{})
@@ -203,7 +206,7 @@ func TestQuery(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedQuery{Resp: test.resp},
+			Client: mockedQuery{Resp: test.resp},
 		}
 
 		resp, err := a.Query(ctx, "", "", "", "", 0, true)
@@ -227,3 +230,63 @@ func TestQuery(t *testing.T) {
 		}
 	}
 }
+
+// mockedBatchPutItemRetry leaves every item unprocessed in the first failures requests.
+type mockedBatchPutItemRetry struct {
+	dynamodbiface.DynamoDBAPI
+	failures int
+	calls    int
+}
+
+func (m *mockedBatchPutItemRetry) BatchWriteItemWithContext(ctx aws.Context, in *dynamodb.BatchWriteItemInput, opts ...request.Option) (*dynamodb.BatchWriteItemOutput, error) {
+	m.calls++
+
+	resp := &dynamodb.BatchWriteItemOutput{}
+	if m.calls <= m.failures {
+		resp.UnprocessedItems = in.RequestItems
+	}
+
+	return resp, nil
+}
+
+func TestBatchPutItemRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		calls    int
+		err      bool
+	}{
+		{"success", 0, 1, false},
+		{"retry", 2, 3, false},
+		// MaxRetries is 2, so the items are sent 3 times before failing.
+		{"max retries", 3, 3, true},
+	}
+
+	ctx := context.TODO()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockedBatchPutItemRetry{failures: test.failures}
+			a := API{
+				Client:  m,
+				Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+			}
+
+			_, err := a.BatchPutItem(ctx, "table", []map[string]*dynamodb.AttributeValue{
+				{"pk": {S: aws.String("a")}},
+				{"pk": {S: aws.String("b")}},
+			})
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), iaws.ErrMaxRetriesExceeded.Error()) {
+					t.Errorf("expected %v, got %v", iaws.ErrMaxRetriesExceeded, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, m.calls)
+			}
+		})
+	}
+}
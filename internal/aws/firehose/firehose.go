@@ -27,6 +27,8 @@ func New(cfg iaws.Config) *firehose.Firehose {
 // API wraps a Kinesis Firehose client interface
 type API struct {
 	Client firehoseiface.FirehoseAPI
+	// Backoff is used to retry items that fail in batch requests.
+	Backoff iaws.Backoff
 }
 
 // IsEnabled checks whether a new client has been set
@@ -37,6 +39,7 @@ func (a *API) IsEnabled() bool {
 // Setup creates a Kinesis Firehose client
 func (a *API) Setup(cfg iaws.Config) {
 	a.Client = New(cfg)
+	a.Backoff = iaws.NewBackoff(cfg)
 }
 
 // PutRecord is a convenience wrapper for putting a record into a Kinesis Firehose stream.
@@ -57,6 +60,10 @@ func (a *API) PutRecord(ctx aws.Context, data []byte, stream string) (*firehose.
 
 // PutRecordBatch is a convenience wrapper for putting multiple records into a Kinesis Firehose stream. This function becomes recursive for any records that failed the PutRecord operation.
 func (a *API) PutRecordBatch(ctx aws.Context, stream string, data [][]byte) (*firehose.PutRecordBatchOutput, error) {
+	return a.putRecordBatch(ctx, stream, data, 0)
+}
+
+func (a *API) putRecordBatch(ctx aws.Context, stream string, data [][]byte, attempt int) (*firehose.PutRecordBatchOutput, error) {
 	var records []*firehose.Record
 	for _, d := range data {
 		records = append(records, &firehose.Record{Data: d})
@@ -71,10 +78,14 @@ func (a *API) PutRecordBatch(ctx aws.Context, stream string, data [][]byte) (*fi
 		},
 	)
 
+	if err != nil {
+		return nil, fmt.Errorf("putrecordbatch stream %s: %v", stream, err)
+	}
+
 	// failed records are identified by the existence of an error code.
 	// if an error code exists, then data is stored in a new slice and
 	// recursively input into the function.
-	if resp.FailedPutCount != aws.Int64(0) {
+	if aws.Int64Value(resp.FailedPutCount) > 0 {
 		var retry [][]byte
 		for idx, r := range resp.RequestResponses {
 			if r.ErrorCode == nil {
@@ -85,12 +96,12 @@ func (a *API) PutRecordBatch(ctx aws.Context, stream string, data [][]byte) (*fi
 		}
 
 		if len(retry) > 0 {
-			return a.PutRecordBatch(ctx, stream, retry)
-		}
-	}
+			if !a.Backoff.Wait(ctx, attempt) {
+				return nil, fmt.Errorf("putrecordbatch stream %s: %d records failed: %v", stream, len(retry), iaws.ErrMaxRetriesExceeded)
+			}
 
-	if err != nil {
-		return nil, fmt.Errorf("putrecordbatch stream %s: %v", stream, err)
+			return a.putRecordBatch(ctx, stream, retry, attempt+1)
+		}
 	}
 
 	return resp, nil
@@ -2,12 +2,15 @@ package firehose
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/firehose"
 	"github.com/aws/aws-sdk-go/service/firehose/firehoseiface"
+	iaws "github.com/brexhq/substation/internal/aws"
 )
 
 type mockedPutRecord struct {
@@ -37,7 +40,7 @@ func TestPutRecord(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPutRecord{Resp: test.resp},
+			Client: mockedPutRecord{Resp: test.resp},
 		}
 		resp, err := a.PutRecord(ctx, []byte{}, "")
 		if err != nil {
@@ -88,7 +91,7 @@ func TestPutRecordBatch(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPutRecordBatch{Resp: test.resp},
+			Client: mockedPutRecordBatch{Resp: test.resp},
 		}
 
 		resp, err := a.PutRecordBatch(ctx, "", [][]byte{})
@@ -103,3 +106,66 @@ func TestPutRecordBatch(t *testing.T) {
 		}
 	}
 }
+
+// mockedPutRecordBatchRetry fails every record in the first failures requests.
+type mockedPutRecordBatchRetry struct {
+	firehoseiface.FirehoseAPI
+	failures int
+	calls    int
+}
+
+func (m *mockedPutRecordBatchRetry) PutRecordBatchWithContext(ctx aws.Context, in *firehose.PutRecordBatchInput, opts ...request.Option) (*firehose.PutRecordBatchOutput, error) {
+	m.calls++
+
+	resp := &firehose.PutRecordBatchOutput{FailedPutCount: aws.Int64(0)}
+	for range in.Records {
+		entry := &firehose.PutRecordBatchResponseEntry{RecordId: aws.String("ABCDEF")}
+		if m.calls <= m.failures {
+			entry = &firehose.PutRecordBatchResponseEntry{ErrorCode: aws.String(firehose.ErrCodeServiceUnavailableException)}
+			*resp.FailedPutCount++
+		}
+
+		resp.RequestResponses = append(resp.RequestResponses, entry)
+	}
+
+	return resp, nil
+}
+
+func TestPutRecordBatchRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		calls    int
+		err      bool
+	}{
+		{"success", 0, 1, false},
+		{"retry", 2, 3, false},
+		// MaxRetries is 2, so the items are sent 3 times before failing.
+		{"max retries", 3, 3, true},
+	}
+
+	ctx := context.TODO()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockedPutRecordBatchRetry{failures: test.failures}
+			a := API{
+				Client:  m,
+				Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+			}
+
+			_, err := a.PutRecordBatch(ctx, "stream", [][]byte{[]byte("a"), []byte("b")})
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), iaws.ErrMaxRetriesExceeded.Error()) {
+					t.Errorf("expected %v, got %v", iaws.ErrMaxRetriesExceeded, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, m.calls)
+			}
+		})
+	}
+}
@@ -184,11 +184,14 @@ func New(cfg iaws.Config) *kinesis.Kinesis {
 // API wraps the Kinesis API interface.
 type API struct {
 	Client kinesisiface.KinesisAPI
+	// Backoff is used to retry items that fail in batch requests.
+	Backoff iaws.Backoff
 }
 
 // Setup creates a new Kinesis client.
 func (a *API) Setup(cfg iaws.Config) {
 	a.Client = New(cfg)
+	a.Backoff = iaws.NewBackoff(cfg)
 }
 
 // IsEnabled returns true if the client is enabled and ready for use.
@@ -198,13 +201,28 @@ func (a *API) IsEnabled() bool {
 
 // PutRecords is a convenience wrapper for putting multiple records into a Kinesis stream.
 func (a *API) PutRecords(ctx aws.Context, stream, partitionKey string, data [][]byte) (*kinesis.PutRecordsOutput, error) {
+	partitionKeys := make([]string, len(data))
+	for i := range partitionKeys {
+		partitionKeys[i] = partitionKey
+	}
+
+	return a.PutRecordsWithPartitionKeys(ctx, stream, partitionKeys, data)
+}
+
+// PutRecordsWithPartitionKeys is a convenience wrapper for putting multiple records into a
+// Kinesis stream. Each record uses the partition key at the same index in partitionKeys.
+func (a *API) PutRecordsWithPartitionKeys(ctx aws.Context, stream string, partitionKeys []string, data [][]byte) (*kinesis.PutRecordsOutput, error) {
+	return a.putRecords(ctx, stream, partitionKeys, data, 0)
+}
+
+func (a *API) putRecords(ctx aws.Context, stream string, partitionKeys []string, data [][]byte, attempt int) (*kinesis.PutRecordsOutput, error) {
 	var records []*kinesis.PutRecordsRequestEntry
 
 	ctx = context.WithoutCancel(ctx)
-	for _, d := range data {
+	for i, d := range data {
 		records = append(records, &kinesis.PutRecordsRequestEntry{
 			Data:         d,
-			PartitionKey: aws.String(partitionKey),
+			PartitionKey: aws.String(partitionKeys[i]),
 		})
 	}
 
@@ -216,23 +234,30 @@ func (a *API) PutRecords(ctx aws.Context, stream, partitionKey string, data [][]
 		},
 	)
 
-	// If any record fails, then the record is recursively retried.
+	if err != nil {
+		return nil, fmt.Errorf("put_records: stream %s: %v", stream, err)
+	}
+
+	// If any record fails (e.g., the shard is throttled), then the record
+	// is recursively retried with backoff.
 	if resp.FailedRecordCount != nil && *resp.FailedRecordCount > 0 {
 		var retry [][]byte
+		var retryKeys []string
 
 		for idx, r := range resp.Records {
 			if r.ErrorCode != nil {
 				retry = append(retry, data[idx])
+				retryKeys = append(retryKeys, partitionKeys[idx])
 			}
 		}
 
 		if len(retry) > 0 {
-			return a.PutRecords(ctx, stream, partitionKey, retry)
-		}
-	}
+			if !a.Backoff.Wait(ctx, attempt) {
+				return nil, fmt.Errorf("put_records: stream %s: %d records failed: %v", stream, len(retry), iaws.ErrMaxRetriesExceeded)
+			}
 
-	if err != nil {
-		return nil, fmt.Errorf("put_records: stream %s: %v", stream, err)
+			return a.putRecords(ctx, stream, retryKeys, retry, attempt+1)
+		}
 	}
 
 	return resp, nil
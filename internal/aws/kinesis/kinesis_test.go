@@ -3,12 +3,16 @@ package kinesis
 import (
 	"bytes"
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	iaws "github.com/brexhq/substation/internal/aws"
 )
 
 type mockedPutRecords struct {
@@ -45,7 +49,7 @@ func TestPutRecords(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPutRecords{Resp: test.resp},
+			Client: mockedPutRecords{Resp: test.resp},
 		}
 
 		b := [][]byte{
@@ -108,7 +112,7 @@ func TestGetTags(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedGetTags{Resp: test.resp},
+			Client: mockedGetTags{Resp: test.resp},
 		}
 		tags, err := a.GetTags(ctx, "")
 		if err != nil {
@@ -168,3 +172,85 @@ func TestSize(t *testing.T) {
 		}
 	}
 }
+
+// mockedPutRecordsRetry fails every record in the first failures requests.
+type mockedPutRecordsRetry struct {
+	kinesisiface.KinesisAPI
+	failures int
+	calls    int
+}
+
+func (m *mockedPutRecordsRetry) PutRecordsWithContext(ctx aws.Context, in *kinesis.PutRecordsInput, opts ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	m.calls++
+
+	resp := &kinesis.PutRecordsOutput{FailedRecordCount: aws.Int64(0)}
+	for range in.Records {
+		entry := &kinesis.PutRecordsResultEntry{SequenceNumber: aws.String("ABCDEF")}
+		if m.calls <= m.failures {
+			entry = &kinesis.PutRecordsResultEntry{ErrorCode: aws.String(kinesis.ErrCodeProvisionedThroughputExceededException)}
+			*resp.FailedRecordCount++
+		}
+
+		resp.Records = append(resp.Records, entry)
+	}
+
+	return resp, nil
+}
+
+func TestPutRecordsRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		calls    int
+		err      bool
+	}{
+		{"success", 0, 1, false},
+		{"retry", 2, 3, false},
+		// MaxRetries is 2, so the items are sent 3 times before failing.
+		{"max retries", 3, 3, true},
+	}
+
+	ctx := context.TODO()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockedPutRecordsRetry{failures: test.failures}
+			a := API{
+				Client:  m,
+				Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+			}
+
+			_, err := a.PutRecords(ctx, "stream", "key", [][]byte{[]byte("a"), []byte("b")})
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), iaws.ErrMaxRetriesExceeded.Error()) {
+					t.Errorf("expected %v, got %v", iaws.ErrMaxRetriesExceeded, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, m.calls)
+			}
+		})
+	}
+}
+
+type mockedPutRecordsError struct {
+	kinesisiface.KinesisAPI
+}
+
+func (m mockedPutRecordsError) PutRecordsWithContext(ctx aws.Context, in *kinesis.PutRecordsInput, opts ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	return nil, errors.New("request failed")
+}
+
+func TestPutRecordsError(t *testing.T) {
+	a := API{
+		Client:  mockedPutRecordsError{},
+		Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+	}
+
+	if _, err := a.PutRecords(context.TODO(), "stream", "key", [][]byte{[]byte("a")}); err == nil {
+		t.Error("expected error")
+	}
+}
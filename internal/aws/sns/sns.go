@@ -30,6 +30,8 @@ func New(cfg iaws.Config) *sns.SNS {
 // API wraps an SNS client interface.
 type API struct {
 	Client snsiface.SNSAPI
+	// Backoff is used to retry items that fail in batch requests.
+	Backoff iaws.Backoff
 }
 
 // IsEnabled checks whether a new client has been set.
@@ -40,6 +42,7 @@ func (a *API) IsEnabled() bool {
 // Setup creates an SNS client.
 func (a *API) Setup(cfg iaws.Config) {
 	a.Client = New(cfg)
+	a.Backoff = iaws.NewBackoff(cfg)
 }
 
 // Publish is a convenience wrapper for publishing a message to an SNS topic.
@@ -65,6 +68,10 @@ func (a *API) Publish(ctx aws.Context, arn string, data []byte) (*sns.PublishOut
 
 // PublishBatch is a convenience wrapper for publishing a batch of messages to an SNS topic.
 func (a *API) PublishBatch(ctx aws.Context, topic string, data [][]byte) (*sns.PublishBatchOutput, error) {
+	return a.publishBatch(ctx, topic, data, 0)
+}
+
+func (a *API) publishBatch(ctx aws.Context, topic string, data [][]byte, attempt int) (*sns.PublishBatchOutput, error) {
 	mgid := uuid.New().String()
 
 	var entries []*sns.PublishBatchRequestEntry
@@ -90,6 +97,10 @@ func (a *API) PublishBatch(ctx aws.Context, topic string, data [][]byte) (*sns.P
 		},
 	)
 
+	if err != nil {
+		return nil, fmt.Errorf("publish_batch: topic %s: %v", topic, err)
+	}
+
 	// if a message fails, then the message ID is used to select the
 	// original data that was in the message. this data is put in a
 	// new slice and recursively input into the function.
@@ -105,12 +116,12 @@ func (a *API) PublishBatch(ctx aws.Context, topic string, data [][]byte) (*sns.P
 		}
 
 		if len(retry) > 0 {
-			return a.PublishBatch(ctx, topic, retry)
-		}
-	}
+			if !a.Backoff.Wait(ctx, attempt) {
+				return nil, fmt.Errorf("publish_batch: topic %s: %d messages failed: %v", topic, len(retry), iaws.ErrMaxRetriesExceeded)
+			}
 
-	if err != nil {
-		return nil, fmt.Errorf("publish_batch: topic %s: %v", topic, err)
+			return a.publishBatch(ctx, topic, retry, attempt+1)
+		}
 	}
 
 	return resp, nil
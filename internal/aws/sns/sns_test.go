@@ -2,12 +2,15 @@ package sns
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	iaws "github.com/brexhq/substation/internal/aws"
 )
 
 type mockedPublish struct {
@@ -36,7 +39,7 @@ func TestPublish(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPublish{Resp: test.resp},
+			Client: mockedPublish{Resp: test.resp},
 		}
 
 		resp, err := a.Publish(ctx, "", []byte(""))
@@ -87,7 +90,7 @@ func TestPublishBatch(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedPublishBatch{Resp: test.resp},
+			Client: mockedPublishBatch{Resp: test.resp},
 		}
 
 		resp, err := a.PublishBatch(ctx, "", [][]byte{})
@@ -102,3 +105,65 @@ func TestPublishBatch(t *testing.T) {
 		}
 	}
 }
+
+// mockedPublishBatchRetry fails every message in the first failures requests.
+type mockedPublishBatchRetry struct {
+	snsiface.SNSAPI
+	failures int
+	calls    int
+}
+
+func (m *mockedPublishBatchRetry) PublishBatchWithContext(ctx aws.Context, in *sns.PublishBatchInput, opts ...request.Option) (*sns.PublishBatchOutput, error) {
+	m.calls++
+
+	resp := &sns.PublishBatchOutput{}
+	for _, e := range in.PublishBatchRequestEntries {
+		if m.calls <= m.failures {
+			resp.Failed = append(resp.Failed, &sns.BatchResultErrorEntry{Id: e.Id})
+			continue
+		}
+
+		resp.Successful = append(resp.Successful, &sns.PublishBatchResultEntry{Id: e.Id})
+	}
+
+	return resp, nil
+}
+
+func TestPublishBatchRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		calls    int
+		err      bool
+	}{
+		{"success", 0, 1, false},
+		{"retry", 2, 3, false},
+		// MaxRetries is 2, so the items are sent 3 times before failing.
+		{"max retries", 3, 3, true},
+	}
+
+	ctx := context.TODO()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockedPublishBatchRetry{failures: test.failures}
+			a := API{
+				Client:  m,
+				Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+			}
+
+			_, err := a.PublishBatch(ctx, "topic", [][]byte{[]byte("a"), []byte("b")})
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), iaws.ErrMaxRetriesExceeded.Error()) {
+					t.Errorf("expected %v, got %v", iaws.ErrMaxRetriesExceeded, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, m.calls)
+			}
+		})
+	}
+}
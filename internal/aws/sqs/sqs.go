@@ -30,6 +30,8 @@ func New(cfg iaws.Config) *sqs.SQS {
 // API wraps an SQS client interface.
 type API struct {
 	Client sqsiface.SQSAPI
+	// Backoff is used to retry items that fail in batch requests.
+	Backoff iaws.Backoff
 }
 
 // IsEnabled checks whether a new client has been set.
@@ -40,6 +42,7 @@ func (a *API) IsEnabled() bool {
 // Setup creates an SQS client.
 func (a *API) Setup(cfg iaws.Config) {
 	a.Client = New(cfg)
+	a.Backoff = iaws.NewBackoff(cfg)
 }
 
 // SendMessage is a convenience wrapper for sending a message to an SQS queue.
@@ -66,6 +69,10 @@ func (a *API) SendMessage(ctx aws.Context, queue string, data []byte) (*sqs.Send
 
 // SendMessageBatch is a convenience wrapper for sending multiple messages to an SQS queue. This function becomes recursive for any messages that failed the SendMessage operation.
 func (a *API) SendMessageBatch(ctx aws.Context, queue string, data [][]byte) (*sqs.SendMessageBatchOutput, error) {
+	return a.sendMessageBatch(ctx, queue, data, 0)
+}
+
+func (a *API) sendMessageBatch(ctx aws.Context, queue string, data [][]byte, attempt int) (*sqs.SendMessageBatchOutput, error) {
 	mgid := uuid.New().String()
 
 	var messages []*sqs.SendMessageBatchRequestEntry
@@ -91,6 +98,10 @@ func (a *API) SendMessageBatch(ctx aws.Context, queue string, data [][]byte) (*s
 		},
 	)
 
+	if err != nil {
+		return nil, fmt.Errorf("send_message_batch: queue %s: %v", queue, err)
+	}
+
 	// if a message fails, then the message ID is used to select the
 	// original data that was in the message. this data is put in a
 	// new slice and recursively input into the function.
@@ -106,12 +117,12 @@ func (a *API) SendMessageBatch(ctx aws.Context, queue string, data [][]byte) (*s
 		}
 
 		if len(retry) > 0 {
-			return a.SendMessageBatch(ctx, queue, retry)
-		}
-	}
+			if !a.Backoff.Wait(ctx, attempt) {
+				return nil, fmt.Errorf("send_message_batch: queue %s: %d messages failed: %v", queue, len(retry), iaws.ErrMaxRetriesExceeded)
+			}
 
-	if err != nil {
-		return nil, fmt.Errorf("send_message_batch: queue %s: %v", queue, err)
+			return a.sendMessageBatch(ctx, queue, retry, attempt+1)
+		}
 	}
 
 	return resp, nil
@@ -2,12 +2,15 @@ package sqs
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	iaws "github.com/brexhq/substation/internal/aws"
 )
 
 type mockedSendMessage struct {
@@ -36,7 +39,7 @@ func TestSendMessage(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedSendMessage{Resp: test.resp},
+			Client: mockedSendMessage{Resp: test.resp},
 		}
 
 		resp, err := a.SendMessage(ctx, "", []byte(""))
@@ -86,7 +89,7 @@ func TestSendMessageBatch(t *testing.T) {
 
 	for _, test := range tests {
 		a := API{
-			mockedSendMessageBatch{Resp: test.resp},
+			Client: mockedSendMessageBatch{Resp: test.resp},
 		}
 
 		resp, err := a.SendMessageBatch(ctx, "", [][]byte{})
@@ -101,3 +104,65 @@ func TestSendMessageBatch(t *testing.T) {
 		}
 	}
 }
+
+// mockedSendMessageBatchRetry fails every message in the first failures requests.
+type mockedSendMessageBatchRetry struct {
+	sqsiface.SQSAPI
+	failures int
+	calls    int
+}
+
+func (m *mockedSendMessageBatchRetry) SendMessageBatchWithContext(ctx aws.Context, in *sqs.SendMessageBatchInput, opts ...request.Option) (*sqs.SendMessageBatchOutput, error) {
+	m.calls++
+
+	resp := &sqs.SendMessageBatchOutput{}
+	for _, e := range in.Entries {
+		if m.calls <= m.failures {
+			resp.Failed = append(resp.Failed, &sqs.BatchResultErrorEntry{Id: e.Id})
+			continue
+		}
+
+		resp.Successful = append(resp.Successful, &sqs.SendMessageBatchResultEntry{Id: e.Id})
+	}
+
+	return resp, nil
+}
+
+func TestSendMessageBatchRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		calls    int
+		err      bool
+	}{
+		{"success", 0, 1, false},
+		{"retry", 2, 3, false},
+		// MaxRetries is 2, so the items are sent 3 times before failing.
+		{"max retries", 3, 3, true},
+	}
+
+	ctx := context.TODO()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockedSendMessageBatchRetry{failures: test.failures}
+			a := API{
+				Client:  m,
+				Backoff: iaws.Backoff{MaxRetries: 2, MaxDelay: time.Millisecond},
+			}
+
+			_, err := a.SendMessageBatch(ctx, "queue", [][]byte{[]byte("a"), []byte("b")})
+			if test.err {
+				if err == nil || !strings.Contains(err.Error(), iaws.ErrMaxRetriesExceeded.Error()) {
+					t.Errorf("expected %v, got %v", iaws.ErrMaxRetriesExceeded, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if m.calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, m.calls)
+			}
+		})
+	}
+}
@@ -47,6 +47,39 @@ type Retry struct {
 	// ErrorMessages are regular expressions that match error messages and determine
 	// if the action should be retried.
 	ErrorMessages []string `json:"error_messages"`
+	// MaxDelay is the maximum amount of time to wait between retries (e.g., 10s).
+	// Retries use exponential backoff with jitter, so the delay between retries
+	// increases up to this value.
+	//
+	// This is optional and the default depends on the action.
+	MaxDelay string `json:"max_delay"`
+}
+
+type TLS struct {
+	// CACertificate is the certificate authority bundle that is used to verify
+	// the server. This can be a file path or a PEM-encoded certificate.
+	//
+	// This is optional and defaults to the system's certificate pool.
+	CACertificate string `json:"ca_certificate"`
+	// ClientCertificate is the certificate that is used for mutual TLS. This can
+	// be a file path or a PEM-encoded certificate.
+	//
+	// This is optional and must be used with ClientKey.
+	ClientCertificate string `json:"client_certificate"`
+	// ClientKey is the private key that is used for mutual TLS. This can be a
+	// file path or a PEM-encoded key.
+	//
+	// This is optional and must be used with ClientCertificate.
+	ClientKey string `json:"client_key"`
+	// ServerName overrides the hostname that is used to verify the server.
+	//
+	// This is optional and defaults to the hostname of the endpoint.
+	ServerName string `json:"server_name"`
+	// InsecureSkipVerify determines if the server's certificate is not verified.
+	// This should only be used for testing.
+	//
+	// This is optional and defaults to false (the certificate is verified).
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
 }
 
 type Batch struct {
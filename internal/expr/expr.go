@@ -0,0 +1,84 @@
+// Package expr provides functions for compiling and evaluating expressions (https://expr-lang.org) against JSON objects.
+package expr
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// builtins are the functions that can be used in expressions. Only a subset
+// of functions are enabled so that they do not shadow common key names (e.g.,
+// duration, date).
+var builtins = []string{
+	"abs", "ceil", "floor", "round", "min", "max", "sum", "mean", "median",
+	"int", "float", "string", "len", "lower", "upper", "trim",
+}
+
+// Compile compiles an expression that is evaluated against the environment
+// returned by Env. Variables that do not exist in the environment are nil.
+// Options are applied after the default options (e.g., expr.AsBool).
+func Compile(s string, opts ...expr.Option) (*vm.Program, error) {
+	o := []expr.Option{
+		expr.AllowUndefinedVariables(),
+		expr.DisableAllBuiltins(),
+	}
+
+	for _, fn := range builtins {
+		o = append(o, expr.EnableBuiltin(fn))
+	}
+
+	return expr.Compile(s, append(o, opts...)...)
+}
+
+// Run evaluates a compiled expression against the environment.
+func Run(prog *vm.Program, env map[string]interface{}) (interface{}, error) {
+	return expr.Run(prog, env)
+}
+
+// Env returns the environment that an expression is evaluated against from a
+// JSON object. Whole numbers are converted to integers so that integer
+// operators (e.g., modulo) can be used.
+func Env(b []byte) (map[string]interface{}, error) {
+	env := make(map[string]interface{})
+	if len(b) == 0 {
+		return env, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	if err := dec.Decode(&env); err != nil {
+		return nil, err
+	}
+
+	for k, v := range env {
+		env[k] = numbers(v)
+	}
+
+	return env, nil
+}
+
+func numbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = numbers(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = numbers(val)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,94 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/expr-lang/expr"
+)
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected map[string]interface{}
+	}{
+		{
+			[]byte(``),
+			map[string]interface{}{},
+		},
+		{
+			[]byte(`{"a":1,"b":1.5,"c":"d"}`),
+			map[string]interface{}{"a": 1, "b": 1.5, "c": "d"},
+		},
+		{
+			[]byte(`{"a":{"b":[1,2.5,{"c":3}]}}`),
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": []interface{}{1, 2.5, map[string]interface{}{"c": 3}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		env, err := Env(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(env, test.expected) {
+			t.Errorf("expected %v, got %v", test.expected, env)
+		}
+	}
+
+	if _, err := Env([]byte(`[1,2]`)); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		expr     string
+		data     []byte
+		expected interface{}
+	}{
+		{`a % 3`, []byte(`{"a":10}`), 1},
+		{`a.b * 2`, []byte(`{"a":{"b":1.5}}`), 3.0},
+		{`round(a) + max(b, 5)`, []byte(`{"a":1.6,"b":3}`), 7.0},
+		{`upper(trim(a))`, []byte(`{"a":" b "}`), "B"},
+		{`missing`, []byte(`{"a":1}`), nil},
+		// Builtins that are not enabled do not shadow keys.
+		{`duration`, []byte(`{"duration":5}`), 5},
+	}
+
+	for _, test := range tests {
+		prog, err := Compile(test.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		env, err := Env(test.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := Run(prog, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("%s: expected %v (%T), got %v (%T)", test.expr, test.expected, test.expected, res, res)
+		}
+	}
+}
+
+func TestCompileOptions(t *testing.T) {
+	if _, err := Compile(`1 + 1`, expr.AsBool()); err == nil {
+		t.Error("expected error")
+	}
+
+	if _, err := Compile(`a > 1`, expr.AsBool()); err != nil {
+		t.Error(err)
+	}
+}
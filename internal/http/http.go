@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 
@@ -28,6 +29,13 @@ func (h *HTTP) Setup() {
 	h.Client = retryablehttp.NewClient()
 }
 
+// SetTLSConfig sets the TLS configuration of the HTTP client. This method must be called before EnableXRay.
+func (h *HTTP) SetTLSConfig(cfg *tls.Config) {
+	if t, ok := h.Client.HTTPClient.Transport.(*http.Transport); ok {
+		t.TLSClientConfig = cfg
+	}
+}
+
 // EnableXRay replaces the standard retryable HTTP client with an AWS XRay client. This method can be used when making HTTP calls on AWS infrastructure and should be enabled by looking for the environment variable "AWS_XRAY_DAEMON_ADDRESS".
 func (h *HTTP) EnableXRay() {
 	h.Client.HTTPClient = xray.Client(h.Client.HTTPClient)
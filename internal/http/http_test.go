@@ -3,6 +3,8 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"io"
 	"net/http"
@@ -85,3 +87,49 @@ func TestGet(t *testing.T) {
 		}
 	}
 }
+
+func TestSetTLSConfig(t *testing.T) {
+	serv := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer serv.Close()
+
+	ctx := context.TODO()
+
+	// The server's certificate is not trusted by default.
+	var h HTTP
+	h.Setup()
+	h.Client.RetryMax = 0
+
+	if _, err := h.Get(ctx, serv.URL); err == nil {
+		t.Error("expected error")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(serv.Certificate())
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+
+	h.Setup()
+	h.Client.RetryMax = 0
+	h.SetTLSConfig(cfg)
+
+	tr, ok := h.Client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", h.Client.HTTPClient.Transport)
+	}
+
+	if tr.TLSClientConfig != cfg {
+		t.Error("expected TLS config to be set on the transport")
+	}
+
+	resp, err := h.Get(ctx, serv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
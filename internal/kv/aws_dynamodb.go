@@ -170,6 +170,7 @@ func (store *kvAWSDynamoDB) Setup(ctx context.Context) error {
 		RoleARN:         store.AWS.RoleARN,
 		MaxRetries:      store.Retry.Count,
 		RetryableErrors: store.Retry.ErrorMessages,
+		MaxRetryDelay:   store.Retry.MaxDelay,
 	})
 
 	return nil
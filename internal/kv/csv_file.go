@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/brexhq/substation/config"
@@ -58,8 +59,16 @@ type kvCSVFile struct {
 	// This is optional and defaults to using the first line of the CSV file as the
 	// header.
 	Header string `json:"header"`
-	mu     sync.Mutex
-	items  map[string]map[string]interface{}
+	// RefreshInterval is the interval (e.g., 5m) at which the file is reloaded.
+	// The store continues to use the previous file while it is reloaded and if
+	// the reload fails.
+	//
+	// This is optional and defaults to never reloading the file.
+	RefreshInterval string `json:"refresh_interval"`
+	mu              sync.Mutex
+	items           map[string]map[string]interface{}
+	dur             time.Duration
+	stop            chan struct{}
 }
 
 // Create a new CSV file KV store.
@@ -73,6 +82,19 @@ func newKVCSVFile(cfg config.Config) (*kvCSVFile, error) {
 		return nil, fmt.Errorf("kv: csv: options %+v: %v", &store, errors.ErrMissingRequiredOption)
 	}
 
+	if store.Delimiter == "" {
+		store.Delimiter = ","
+	}
+
+	if store.RefreshInterval != "" {
+		dur, err := time.ParseDuration(store.RefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("kv: csv: refresh_interval: %v", err)
+		}
+
+		store.dur = dur
+	}
+
 	return &store, nil
 }
 
@@ -120,17 +142,72 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	store.items = make(map[string]map[string]interface{})
+	items, err := store.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	store.items = items
+
+	if store.dur > 0 {
+		store.stop = make(chan struct{})
+		go refresh("csv_file", store.dur, store.stop, store.reload)
+	}
+
+	return nil
+}
+
+// Closes the store.
+func (store *kvCSVFile) Close() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// avoids unnecessary closing
+	if store.items == nil {
+		return nil
+	}
+
+	if store.stop != nil {
+		close(store.stop)
+		store.stop = nil
+	}
+
+	store.items = nil
+	return nil
+}
+
+// reload replaces the store's items with the current contents of the file.
+// The file is read without holding the lock, so Get is not blocked.
+func (store *kvCSVFile) reload(ctx context.Context) error {
+	items, err := store.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// The store was closed while the file was read.
+	if store.items == nil {
+		return nil
+	}
+
+	store.items = items
+	return nil
+}
+
+func (store *kvCSVFile) read(ctx context.Context) (map[string]map[string]interface{}, error) {
+	items := make(map[string]map[string]interface{})
 
 	path, err := file.Get(ctx, store.File)
 	defer os.Remove(path)
 	if err != nil {
-		return fmt.Errorf("kv: csv_file: %v", err)
+		return nil, fmt.Errorf("kv: csv_file: %v", err)
 	}
 
 	f, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("kv: csv_file: %v", err)
+		return nil, fmt.Errorf("kv: csv_file: %v", err)
 	}
 
 	defer f.Close()
@@ -140,10 +217,10 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 	if store.Header != "" {
 		buf, err := bufio.NewReader(f).ReadString('\n')
 		if err != nil {
-			return fmt.Errorf("kv: csv_file: %v", err)
+			return nil, fmt.Errorf("kv: csv_file: %v", err)
 		}
 		if _, err = f.Seek(int64(len(buf)), io.SeekStart); err != nil {
-			return fmt.Errorf("kv: csv_file: %v", err)
+			return nil, fmt.Errorf("kv: csv_file: %v", err)
 		}
 
 		h := strings.NewReader(fmt.Sprintf("%s\n", store.Header))
@@ -152,10 +229,6 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 		reader = csv.NewReader(f)
 	}
 
-	if store.Delimiter == "" {
-		store.Delimiter = ","
-	}
-
 	// CSV reader only accepts runes for the comma / delimiter
 	r, _ := utf8.DecodeRune([]byte(store.Delimiter))
 	reader.Comma = r
@@ -163,7 +236,7 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 	// any errors in the CSV file are raised here
 	rows, err := reader.ReadAll()
 	if err != nil {
-		return fmt.Errorf("kv: csv_file: %v", err)
+		return nil, fmt.Errorf("kv: csv_file: %v", err)
 	}
 
 	var header []string
@@ -184,7 +257,7 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 			}
 
 			if key == "" {
-				return fmt.Errorf("kv: csv_file: %v", errCSVFileColumnNotFound)
+				return nil, fmt.Errorf("kv: csv_file: %v", errCSVFileColumnNotFound)
 			}
 
 			// the KV store value is the row with the column's value removed
@@ -197,23 +270,9 @@ func (store *kvCSVFile) Setup(ctx context.Context) error {
 				val[header[i]] = row[i]
 			}
 
-			store.items[key] = val
+			items[key] = val
 		}
 	}
 
-	return nil
-}
-
-// Closes the store.
-func (store *kvCSVFile) Close() error {
-	store.mu.Lock()
-	defer store.mu.Unlock()
-
-	// avoids unnecessary closing
-	if store.items == nil {
-		return nil
-	}
-
-	store.items = nil
-	return nil
+	return items, nil
 }
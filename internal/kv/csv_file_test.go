@@ -0,0 +1,124 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brexhq/substation/config"
+)
+
+func kvCSVFileGet(t *testing.T, store *kvCSVFile, key string) interface{} {
+	t.Helper()
+
+	v, err := store.Get(context.TODO(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return v.(map[string]interface{})["bar"]
+}
+
+func TestKVCSVFileRefresh(t *testing.T) {
+	ctx := context.TODO()
+	path := kvTestPath(t, "kv.csv")
+	kvWriteFile(t, path, "foo,bar\na,b\n")
+
+	store, err := newKVCSVFile(config.Config{
+		Settings: map[string]interface{}{
+			"file":             path,
+			"column":           "foo",
+			"refresh_interval": "5ms",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Setup(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := kvCSVFileGet(t, store, "a"); v != "b" {
+		t.Fatalf("expected b, got %v", v)
+	}
+
+	// The store picks up changes to the file.
+	kvWriteFile(t, path, "foo,bar\na,c\n")
+	kvEventually(t, time.Second, func() bool {
+		return kvCSVFileGet(t, store, "a") == "c"
+	})
+
+	// The store keeps the previous values if the reload fails.
+	kvWriteFile(t, path, "foo,bar\na\n")
+	time.Sleep(50 * time.Millisecond)
+	if v := kvCSVFileGet(t, store, "a"); v != "c" {
+		t.Errorf("expected c, got %v", v)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Close stops the refresh, so the store is not repopulated.
+	kvWriteFile(t, path, "foo,bar\na,d\n")
+	time.Sleep(50 * time.Millisecond)
+	if store.IsEnabled() {
+		t.Error("expected store to be disabled after close")
+	}
+
+	store.mu.Lock()
+	stop := store.stop
+	store.mu.Unlock()
+	if stop != nil {
+		t.Error("expected refresh to be stopped after close")
+	}
+}
+
+func TestKVCSVFileNoRefresh(t *testing.T) {
+	ctx := context.TODO()
+	path := kvTestPath(t, "kv.csv")
+	kvWriteFile(t, path, "foo,bar\na,b\n")
+
+	store, err := newKVCSVFile(config.Config{
+		Settings: map[string]interface{}{
+			"file":   path,
+			"column": "foo",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Setup(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if store.stop != nil {
+		t.Error("expected no refresh without refresh_interval")
+	}
+
+	kvWriteFile(t, path, "foo,bar\na,c\n")
+	time.Sleep(20 * time.Millisecond)
+	if v := kvCSVFileGet(t, store, "a"); v != "b" {
+		t.Errorf("expected b, got %v", v)
+	}
+}
+
+func TestKVCSVFileInvalidRefreshInterval(t *testing.T) {
+	_, err := newKVCSVFile(config.Config{
+		Settings: map[string]interface{}{
+			"file":             "kv.csv",
+			"column":           "foo",
+			"refresh_interval": "soon",
+		},
+	})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
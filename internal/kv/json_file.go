@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/brexhq/substation/config"
 	_config "github.com/brexhq/substation/internal/config"
@@ -27,9 +28,17 @@ type kvJSONFile struct {
 	// IsLines indicates that the file is a JSON Lines file. The first non-null value
 	// is returned when a key is found.
 	IsLines bool `json:"is_lines"`
+	// RefreshInterval is the interval (e.g., 5m) at which the file is reloaded.
+	// The store continues to use the previous file while it is reloaded and if
+	// the reload fails.
+	//
+	// This is optional and defaults to never reloading the file.
+	RefreshInterval string `json:"refresh_interval"`
 
 	mu     *sync.Mutex
 	object []byte
+	dur    time.Duration
+	stop   chan struct{}
 }
 
 // Create a new JSON file KV store.
@@ -44,6 +53,15 @@ func newKVJSONFile(cfg config.Config) (*kvJSONFile, error) {
 		return nil, fmt.Errorf("kv: json: options %+v: %v", &store, errors.ErrMissingRequiredOption)
 	}
 
+	if store.RefreshInterval != "" {
+		dur, err := time.ParseDuration(store.RefreshInterval)
+		if err != nil {
+			return nil, fmt.Errorf("kv: json: refresh_interval: %v", err)
+		}
+
+		store.dur = dur
+	}
+
 	return &store, nil
 }
 
@@ -107,22 +125,18 @@ func (store *kvJSONFile) Setup(ctx context.Context) error {
 		return nil
 	}
 
-	path, err := file.Get(ctx, store.File)
-	defer os.Remove(path)
+	buf, err := store.read(ctx)
 	if err != nil {
-		return fmt.Errorf("kv: json_file: %v", err)
+		return err
 	}
 
-	buf, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("kv: json_file: %v", err)
-	}
+	store.object = buf
 
-	if !json.Valid(buf) {
-		return fmt.Errorf("kv: json_file: %v", errJSONFileInvalid)
+	if store.dur > 0 {
+		store.stop = make(chan struct{})
+		go refresh("json_file", store.dur, store.stop, store.reload)
 	}
 
-	store.object = buf
 	return nil
 }
 
@@ -136,6 +150,50 @@ func (store *kvJSONFile) Close() error {
 		return nil
 	}
 
+	if store.stop != nil {
+		close(store.stop)
+		store.stop = nil
+	}
+
 	store.object = nil
 	return nil
 }
+
+// reload replaces the store's object with the current contents of the file.
+// The file is read without holding the lock, so Get is not blocked.
+func (store *kvJSONFile) reload(ctx context.Context) error {
+	buf, err := store.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// The store was closed while the file was read.
+	if store.object == nil {
+		return nil
+	}
+
+	store.object = buf
+	return nil
+}
+
+func (store *kvJSONFile) read(ctx context.Context) ([]byte, error) {
+	path, err := file.Get(ctx, store.File)
+	defer os.Remove(path)
+	if err != nil {
+		return nil, fmt.Errorf("kv: json_file: %v", err)
+	}
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("kv: json_file: %v", err)
+	}
+
+	if !json.Valid(buf) {
+		return nil, fmt.Errorf("kv: json_file: %v", errJSONFileInvalid)
+	}
+
+	return buf, nil
+}
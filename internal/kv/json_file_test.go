@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brexhq/substation/config"
+)
+
+func kvJSONFileGet(t *testing.T, store *kvJSONFile, key string) interface{} {
+	t.Helper()
+
+	v, err := store.Get(context.TODO(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
+
+func TestKVJSONFileRefresh(t *testing.T) {
+	ctx := context.TODO()
+	path := kvTestPath(t, "kv.json")
+	kvWriteFile(t, path, `{"foo":"bar"}`)
+
+	store, err := newKVJSONFile(config.Config{
+		Settings: map[string]interface{}{
+			"file":             path,
+			"refresh_interval": "5ms",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Setup(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := kvJSONFileGet(t, store, "foo"); v != "bar" {
+		t.Fatalf("expected bar, got %v", v)
+	}
+
+	// The store picks up changes to the file.
+	kvWriteFile(t, path, `{"foo":"baz"}`)
+	kvEventually(t, time.Second, func() bool {
+		return kvJSONFileGet(t, store, "foo") == "baz"
+	})
+
+	// The store keeps the previous values if the reload fails.
+	kvWriteFile(t, path, `{"foo":`)
+	time.Sleep(50 * time.Millisecond)
+	if v := kvJSONFileGet(t, store, "foo"); v != "baz" {
+		t.Errorf("expected baz, got %v", v)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Close stops the refresh, so the store is not repopulated.
+	kvWriteFile(t, path, `{"foo":"qux"}`)
+	time.Sleep(50 * time.Millisecond)
+	if store.IsEnabled() {
+		t.Error("expected store to be disabled after close")
+	}
+
+	store.mu.Lock()
+	stop := store.stop
+	store.mu.Unlock()
+	if stop != nil {
+		t.Error("expected refresh to be stopped after close")
+	}
+}
+
+func TestKVJSONFileNoRefresh(t *testing.T) {
+	ctx := context.TODO()
+	path := kvTestPath(t, "kv.json")
+	kvWriteFile(t, path, `{"foo":"bar"}`)
+
+	store, err := newKVJSONFile(config.Config{
+		Settings: map[string]interface{}{
+			"file": path,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Setup(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	if store.stop != nil {
+		t.Error("expected no refresh without refresh_interval")
+	}
+
+	kvWriteFile(t, path, `{"foo":"baz"}`)
+	time.Sleep(20 * time.Millisecond)
+	if v := kvJSONFileGet(t, store, "foo"); v != "bar" {
+		t.Errorf("expected bar, got %v", v)
+	}
+}
+
+func TestKVJSONFileInvalidRefreshInterval(t *testing.T) {
+	_, err := newKVJSONFile(config.Config{
+		Settings: map[string]interface{}{
+			"file":             "kv.json",
+			"refresh_interval": "soon",
+		},
+	})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/brexhq/substation/config"
 	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/log"
 )
 
 var (
@@ -33,6 +35,28 @@ func toString(s Storer) string {
 	return string(b)
 }
 
+// refresh calls load on an interval until stop is closed. Each load is limited
+// by the interval so that a stalled read cannot block future reloads. If load
+// fails, then the error is logged at the debug level and the store continues
+// to use its current values.
+func refresh(name string, interval time.Duration, stop <-chan struct{}, load func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := load(ctx); err != nil {
+				log.WithField("kv_store", name).WithField("error", err).Debug("refresh failed")
+			}
+			cancel()
+		}
+	}
+}
+
 // Get returns a pointer to a Storer that is stored as a package level global variable.
 // This function and each Storer are safe for concurrent access.
 func Get(cfg config.Config) (Storer, error) {
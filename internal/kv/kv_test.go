@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// kvWriteFile atomically replaces the file so that a concurrent reload never
+// reads a partial write.
+func kvWriteFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// kvEventually polls fn until it returns true or the timeout is reached.
+func kvEventually(t *testing.T, timeout time.Duration, fn func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if fn() {
+			return
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition not met before timeout")
+}
+
+func TestRefresh(t *testing.T) {
+	var calls atomic.Int64
+	var deadline atomic.Bool
+
+	load := func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); ok {
+			deadline.Store(true)
+		}
+
+		// Errors are logged and do not stop the refresh.
+		calls.Add(1)
+		return errors.New("load failed")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		refresh("test", 5*time.Millisecond, stop, load)
+		close(done)
+	}()
+
+	kvEventually(t, time.Second, func() bool { return calls.Load() >= 2 })
+	if !deadline.Load() {
+		t.Error("expected load context to have a deadline")
+	}
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected refresh to return after stop is closed")
+	}
+
+	n := calls.Load()
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != n {
+		t.Errorf("expected no loads after stop, got %d", calls.Load()-n)
+	}
+}
+
+func TestRefreshTimeout(t *testing.T) {
+	errc := make(chan error, 1)
+	load := func(ctx context.Context) error {
+		// Simulates a stalled read that only returns when the context expires.
+		<-ctx.Done()
+		select {
+		case errc <- ctx.Err():
+		default:
+		}
+
+		return ctx.Err()
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go refresh("test", 10*time.Millisecond, stop, load)
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected stalled load to time out")
+	}
+}
+
+// kvTestPath returns a path in a temporary directory that is removed when the
+// test completes.
+func kvTestPath(t *testing.T, name string) string {
+	t.Helper()
+
+	return filepath.Join(t.TempDir(), name)
+}
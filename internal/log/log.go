@@ -5,6 +5,7 @@ package log
 
 import (
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -21,16 +22,37 @@ func Info(args ...interface{}) {
 	log.Info(args...)
 }
 
+// IsDebug returns true if debug logging is enabled. This can be used to avoid
+// the cost of collecting data that is only logged at the debug level.
+func IsDebug() bool {
+	return log.IsLevelEnabled(logrus.DebugLevel)
+}
+
 // WithField wraps logrus WithField function
 func WithField(k string, v interface{}) *logrus.Entry {
 	return log.WithField(k, v)
 }
 
+// init configures the logger from environment variables:
+//   - SUBSTATION_LOG_LEVEL sets the level (debug, info, warn, error) and defaults to info.
+//   - SUBSTATION_LOG_FORMAT sets the format (text, json) and defaults to text.
+//   - SUBSTATION_DEBUG sets the level to debug and takes precedence over SUBSTATION_LOG_LEVEL.
 func init() {
+	if f, ok := os.LookupEnv("SUBSTATION_LOG_FORMAT"); ok && strings.EqualFold(f, "json") {
+		log.SetFormatter(&logrus.JSONFormatter{})
+	}
+
 	if _, ok := os.LookupEnv("SUBSTATION_DEBUG"); ok {
 		log.SetLevel(logrus.DebugLevel)
 		return
 	}
 
 	log.SetLevel(logrus.InfoLevel)
+	if l, ok := os.LookupEnv("SUBSTATION_LOG_LEVEL"); ok {
+		// Invalid levels are ignored so that misconfigured applications
+		// still produce logs.
+		if lvl, err := logrus.ParseLevel(l); err == nil {
+			log.SetLevel(lvl)
+		}
+	}
 }
@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+
+	"github.com/brexhq/substation/config"
+	iaws "github.com/brexhq/substation/internal/aws"
+	icloudwatch "github.com/brexhq/substation/internal/aws/cloudwatch"
+	iconfig "github.com/brexhq/substation/internal/config"
+)
+
+// errAWSCloudWatchInvalidValue is returned when the metric value cannot be
+// converted to a float.
+var errAWSCloudWatchInvalidValue = fmt.Errorf("invalid metric value")
+
+type awsCloudWatchConfig struct {
+	// Namespace is the CloudWatch namespace that metrics are sent to.
+	//
+	// This is optional and defaults to Substation.
+	Namespace string `json:"namespace"`
+
+	AWS   iconfig.AWS   `json:"aws"`
+	Retry iconfig.Retry `json:"retry"`
+}
+
+// awsCloudWatch sends metrics to CloudWatch using the PutMetricData API. This
+// should be used when metrics cannot be sent using the Embedded Metrics Format
+// (e.g., outside of AWS Lambda). Attributes are sent as dimensions.
+type awsCloudWatch struct {
+	conf awsCloudWatchConfig
+
+	// client is safe for concurrent use.
+	client icloudwatch.API
+}
+
+func newAWSCloudWatch(_ context.Context, cfg config.Config) (*awsCloudWatch, error) {
+	conf := awsCloudWatchConfig{}
+	if err := iconfig.Decode(cfg.Settings, &conf); err != nil {
+		return nil, err
+	}
+
+	if conf.Namespace == "" {
+		conf.Namespace = metricsApplication
+	}
+
+	m := awsCloudWatch{
+		conf: conf,
+	}
+
+	m.client.Setup(iaws.Config{
+		Region:          conf.AWS.Region,
+		RoleARN:         conf.AWS.RoleARN,
+		MaxRetries:      conf.Retry.Count,
+		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
+	})
+
+	return &m, nil
+}
+
+func (m *awsCloudWatch) Generate(ctx context.Context, data Data) error {
+	return m.GenerateBatch(ctx, []Data{data})
+}
+
+func (m *awsCloudWatch) GenerateBatch(ctx context.Context, data []Data) error {
+	now := time.Now()
+
+	datums := make([]*cloudwatch.MetricDatum, 0, len(data))
+	for _, d := range data {
+		v, err := awsCloudWatchValue(d.Value)
+		if err != nil {
+			return fmt.Errorf("metrics aws_cloudwatch: %s: %v", d.Name, err)
+		}
+
+		datum := &cloudwatch.MetricDatum{
+			MetricName: aws.String(d.Name),
+			Timestamp:  aws.Time(now),
+			Value:      aws.Float64(v),
+		}
+
+		for key, val := range d.Attributes {
+			datum.Dimensions = append(datum.Dimensions, &cloudwatch.Dimension{
+				Name:  aws.String(key),
+				Value: aws.String(val),
+			})
+		}
+
+		datums = append(datums, datum)
+	}
+
+	if err := m.client.PutMetricData(ctx, m.conf.Namespace, datums); err != nil {
+		return fmt.Errorf("metrics aws_cloudwatch: %v", err)
+	}
+
+	return nil
+}
+
+func awsCloudWatchValue(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case time.Duration:
+		return float64(n), nil
+	default:
+		return 0, errAWSCloudWatchInvalidValue
+	}
+}
@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+
+	icloudwatch "github.com/brexhq/substation/internal/aws/cloudwatch"
+)
+
+type awsCloudWatchMockedPutMetricData struct {
+	cloudwatchiface.CloudWatchAPI
+	inputs []*cloudwatch.PutMetricDataInput
+}
+
+func (m *awsCloudWatchMockedPutMetricData) PutMetricDataWithContext(_ aws.Context, in *cloudwatch.PutMetricDataInput, _ ...request.Option) (*cloudwatch.PutMetricDataOutput, error) {
+	m.inputs = append(m.inputs, in)
+	return &cloudwatch.PutMetricDataOutput{}, nil
+}
+
+func TestAWSCloudWatchValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected float64
+		err      bool
+	}{
+		{int(1), 1, false},
+		{int64(-2), -2, false},
+		{uint32(3), 3, false},
+		{uint64(4), 4, false},
+		{float64(5.5), 5.5, false},
+		{time.Second, float64(time.Second), false},
+		{"6", 0, true},
+		{nil, 0, true},
+		{true, 0, true},
+		{float32(7), 0, true},
+	}
+
+	for _, test := range tests {
+		v, err := awsCloudWatchValue(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("%T %v: expected error", test.value, test.value)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%T %v: %v", test.value, test.value, err)
+			continue
+		}
+
+		if v != test.expected {
+			t.Errorf("%T %v: expected %v, got %v", test.value, test.value, test.expected, v)
+		}
+	}
+}
+
+func TestAWSCloudWatchGenerateBatch(t *testing.T) {
+	mock := &awsCloudWatchMockedPutMetricData{}
+	m := awsCloudWatch{
+		conf:   awsCloudWatchConfig{Namespace: "Test"},
+		client: icloudwatch.API{Client: mock},
+	}
+
+	data := []Data{
+		{Name: "A", Value: uint32(1), Attributes: map[string]string{"env": "prod"}},
+		{Name: "B", Value: 2.5},
+	}
+
+	if err := m.GenerateBatch(context.TODO(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mock.inputs) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(mock.inputs))
+	}
+
+	in := mock.inputs[0]
+	if aws.StringValue(in.Namespace) != "Test" {
+		t.Errorf("expected namespace Test, got %s", aws.StringValue(in.Namespace))
+	}
+
+	if len(in.MetricData) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(in.MetricData))
+	}
+
+	a := in.MetricData[0]
+	if aws.StringValue(a.MetricName) != "A" || aws.Float64Value(a.Value) != 1 {
+		t.Errorf("expected A 1, got %s %v", aws.StringValue(a.MetricName), aws.Float64Value(a.Value))
+	}
+
+	if len(a.Dimensions) != 1 || aws.StringValue(a.Dimensions[0].Name) != "env" || aws.StringValue(a.Dimensions[0].Value) != "prod" {
+		t.Errorf("expected dimension env=prod, got %v", a.Dimensions)
+	}
+
+	if b := in.MetricData[1]; aws.Float64Value(b.Value) != 2.5 || len(b.Dimensions) != 0 {
+		t.Errorf("expected B 2.5 without dimensions, got %v", b)
+	}
+}
+
+func TestAWSCloudWatchGenerateBatchInvalidValue(t *testing.T) {
+	mock := &awsCloudWatchMockedPutMetricData{}
+	m := awsCloudWatch{
+		client: icloudwatch.API{Client: mock},
+	}
+
+	if err := m.GenerateBatch(context.TODO(), []Data{{Name: "A", Value: "1"}}); err == nil {
+		t.Error("expected error")
+	}
+
+	if len(mock.inputs) != 0 {
+		t.Errorf("expected no requests, got %d", len(mock.inputs))
+	}
+}
@@ -39,8 +39,31 @@ type Generator interface {
 	Generate(context.Context, Data) error
 }
 
+// BatchGenerator is a Generator that can send multiple metrics in a single request.
+type BatchGenerator interface {
+	Generator
+	GenerateBatch(context.Context, []Data) error
+}
+
+// GenerateBatch sends multiple metrics. If the Generator is a BatchGenerator, then the metrics are sent in batches, otherwise they are sent one at a time.
+func GenerateBatch(ctx context.Context, gen Generator, data []Data) error {
+	if b, ok := gen.(BatchGenerator); ok {
+		return b.GenerateBatch(ctx, data)
+	}
+
+	for _, d := range data {
+		if err := gen.Generate(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func New(ctx context.Context, cfg config.Config) (Generator, error) {
 	switch cfg.Type {
+	case "aws_cloudwatch":
+		return newAWSCloudWatch(ctx, cfg)
 	case "aws_cloudwatch_embedded_metrics":
 		return newAWSCloudWatchEmbeddedMetrics(ctx, cfg)
 	default:
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testGenerator records metrics that are sent one at a time.
+type testGenerator struct {
+	data []Data
+	err  error
+}
+
+func (g *testGenerator) Generate(_ context.Context, d Data) error {
+	if g.err != nil {
+		return g.err
+	}
+
+	g.data = append(g.data, d)
+	return nil
+}
+
+// testBatchGenerator records metrics that are sent in batches.
+type testBatchGenerator struct {
+	testGenerator
+	batches [][]Data
+}
+
+func (g *testBatchGenerator) GenerateBatch(_ context.Context, data []Data) error {
+	g.batches = append(g.batches, data)
+	return nil
+}
+
+func TestGenerateBatch(t *testing.T) {
+	ctx := context.TODO()
+	data := []Data{
+		{Name: "a", Value: 1},
+		{Name: "b", Value: 2},
+	}
+
+	// Generators that do not support batches receive one metric at a time.
+	gen := &testGenerator{}
+	if err := GenerateBatch(ctx, gen, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(gen.data, data) {
+		t.Errorf("expected %v, got %v", data, gen.data)
+	}
+
+	// Batch generators receive all metrics in a single call.
+	batch := &testBatchGenerator{}
+	if err := GenerateBatch(ctx, batch, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(batch.data) != 0 {
+		t.Errorf("expected no calls to Generate, got %v", batch.data)
+	}
+
+	if !reflect.DeepEqual(batch.batches, [][]Data{data}) {
+		t.Errorf("expected %v, got %v", [][]Data{data}, batch.batches)
+	}
+}
+
+func TestGenerateBatchError(t *testing.T) {
+	gen := &testGenerator{err: fmt.Errorf("failed")}
+	if err := GenerateBatch(context.TODO(), gen, []Data{{Name: "a", Value: 1}}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -74,6 +74,7 @@ func newAWSSecretsManager(_ context.Context, cfg config.Config) (*awsSecretsMana
 		RoleARN:         conf.AWS.RoleARN,
 		MaxRetries:      conf.Retry.Count,
 		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
 	})
 
 	return c, nil
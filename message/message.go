@@ -254,6 +254,11 @@ func (v Value) IsArray() bool {
 	return v.gjson.IsArray()
 }
 
+// IsObject returns true if the value is an object.
+func (v Value) IsObject() bool {
+	return v.gjson.IsObject()
+}
+
 // Map returns the value as a map of string to Value.
 func (v Value) Map() map[string]Value {
 	values := make(map[string]Value)
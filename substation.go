@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/brexhq/substation/config"
 	"github.com/brexhq/substation/message"
@@ -44,7 +46,7 @@ func New(ctx context.Context, cfg Config, opts ...func(*Substation)) (*Substatio
 
 	// Create transforms from the configuration.
 	for _, c := range cfg.Transforms {
-		t, err := sub.factory(ctx, c)
+		t, err := sub.factory(ctx, overrideType(c))
 		if err != nil {
 			return nil, err
 		}
@@ -55,6 +57,38 @@ func New(ctx context.Context, cfg Config, opts ...func(*Substation)) (*Substatio
 	return sub, nil
 }
 
+// overrideType replaces the type of a top-level transform using environment
+// variables, which allows the same configuration to be used in multiple
+// environments. Settings are not changed, so the new type should accept
+// the settings of the original type (all send transforms do this).
+//
+// These are checked in order:
+//   - SUBSTATION_TRANSFORM_TYPE_<TYPE> overrides a specific type
+//     (e.g., SUBSTATION_TRANSFORM_TYPE_SEND_AWS_KINESIS_DATA_STREAM=send_stdout).
+//   - SUBSTATION_SINK_TYPE overrides the type of all send transforms, which
+//     are the sinks of a pipeline (e.g., SUBSTATION_SINK_TYPE=send_file).
+//   - SUBSTATION_SEND_TYPE is an alias of SUBSTATION_SINK_TYPE that matches
+//     the name of send transforms.
+func overrideType(cfg config.Config) config.Config {
+	if t, ok := os.LookupEnv("SUBSTATION_TRANSFORM_TYPE_" + strings.ToUpper(cfg.Type)); ok && t != "" {
+		cfg.Type = strings.ToLower(t)
+		return cfg
+	}
+
+	if !strings.HasPrefix(cfg.Type, "send_") {
+		return cfg
+	}
+
+	for _, env := range []string{"SUBSTATION_SINK_TYPE", "SUBSTATION_SEND_TYPE"} {
+		if t, ok := os.LookupEnv(env); ok && t != "" {
+			cfg.Type = strings.ToLower(t)
+			break
+		}
+	}
+
+	return cfg
+}
+
 // WithTransformFactory implements a custom transform factory.
 func WithTransformFactory(fac transform.Factory) func(*Substation) {
 	return func(s *Substation) {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"testing"
 
 	"github.com/brexhq/substation"
 	"github.com/brexhq/substation/config"
@@ -200,3 +202,79 @@ func (t *utilityDuplicate) Transform(ctx context.Context, msg *message.Message)
 
 	return output, nil
 }
+
+func TestOverrideType(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected []string
+	}{
+		{
+			"none",
+			nil,
+			[]string{"object_copy", "send_aws_kinesis_data_stream", "send_stdout"},
+		},
+		{
+			"sink",
+			map[string]string{"SUBSTATION_SINK_TYPE": "send_file"},
+			[]string{"object_copy", "send_file", "send_file"},
+		},
+		{
+			"send",
+			map[string]string{"SUBSTATION_SEND_TYPE": "send_null"},
+			[]string{"object_copy", "send_null", "send_null"},
+		},
+		{
+			"sink before send",
+			map[string]string{
+				"SUBSTATION_SINK_TYPE": "send_file",
+				"SUBSTATION_SEND_TYPE": "send_null",
+			},
+			[]string{"object_copy", "send_file", "send_file"},
+		},
+		{
+			"transform type",
+			map[string]string{
+				"SUBSTATION_TRANSFORM_TYPE_SEND_AWS_KINESIS_DATA_STREAM": "SEND_STDOUT",
+				"SUBSTATION_TRANSFORM_TYPE_OBJECT_COPY":                  "object_move",
+				"SUBSTATION_SINK_TYPE":                                   "send_file",
+			},
+			[]string{"object_move", "send_stdout", "send_file"},
+		},
+		{
+			"empty",
+			map[string]string{"SUBSTATION_SINK_TYPE": ""},
+			[]string{"object_copy", "send_aws_kinesis_data_stream", "send_stdout"},
+		},
+	}
+
+	cfg := substation.Config{
+		Transforms: []config.Config{
+			{Type: "object_copy"},
+			{Type: "send_aws_kinesis_data_stream"},
+			{Type: "send_stdout"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			var types []string
+			fac := func(_ context.Context, c config.Config) (transform.Transformer, error) {
+				types = append(types, c.Type)
+				return &utilityDuplicate{}, nil
+			}
+
+			if _, err := substation.New(context.TODO(), cfg, substation.WithTransformFactory(fac)); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(types, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, types)
+			}
+		})
+	}
+}
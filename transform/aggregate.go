@@ -18,6 +18,21 @@ func (c *aggregateArrayConfig) Decode(in interface{}) error {
 	return iconfig.Decode(in, c)
 }
 
+type aggregateFromArrayConfig struct {
+	// Envelope are keys in the object that are copied into each element of the
+	// array (e.g., metadata that is shared by a batch of events). This is only
+	// used when the transform is configured with a source key.
+	//
+	// This is optional and defaults to copying all keys in the object.
+	Envelope []string `json:"envelope"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *aggregateFromArrayConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
 func aggToArray(data [][]byte) []byte {
 	return slices.Concat([]byte("["), bytes.Join(data, []byte(",")), []byte("]"))
 }
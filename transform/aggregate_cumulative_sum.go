@@ -0,0 +1,206 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/aggregate"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type aggregateCumulativeSumConfig struct {
+	// SortKey retrieves a value from each message that orders the batch before
+	// sums are calculated (e.g., a timestamp). Numbers are sorted numerically
+	// and all other values are sorted as strings.
+	//
+	// This is optional and defaults to the order that messages are received.
+	SortKey string `json:"sort_key"`
+	// Window is the number of values (including the current value) that are
+	// summed. If this is 0, then the sum includes all previous values in the
+	// batch.
+	//
+	// This is optional and defaults to 0.
+	Window int `json:"window"`
+
+	Object iconfig.Object `json:"object"`
+	Batch  iconfig.Batch  `json:"batch"`
+}
+
+func (c *aggregateCumulativeSumConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *aggregateCumulativeSumConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Window < 0 {
+		return fmt.Errorf("window %d: %v", c.Window, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newAggregateCumulativeSum(_ context.Context, cfg config.Config) (*aggregateCumulativeSum, error) {
+	conf := aggregateCumulativeSumConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", err)
+	}
+
+	tf := aggregateCumulativeSum{
+		conf: conf,
+		msgs: make(map[string][]*message.Message),
+	}
+
+	agg, err := aggregate.New(aggregate.Config{
+		Count:    conf.Batch.Count,
+		Size:     conf.Batch.Size,
+		Duration: conf.Batch.Duration,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", err)
+	}
+	tf.agg = *agg
+
+	return &tf, nil
+}
+
+// aggregateCumulativeSum calculates running totals of a numeric value across
+// a batch of messages. Messages are held until the batch is complete, then
+// each message is returned with the sum of its value and the values of the
+// messages before it. If the batch is grouped by a key, then each group has
+// its own sum.
+//
+// Values that are not numbers are treated as 0.
+type aggregateCumulativeSum struct {
+	conf aggregateCumulativeSumConfig
+
+	mu   sync.Mutex
+	agg  aggregate.Aggregate
+	msgs map[string][]*message.Message
+}
+
+func (tf *aggregateCumulativeSum) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		keys := make([]string, 0, len(tf.msgs))
+		for key := range tf.msgs {
+			keys = append(keys, key)
+		}
+
+		// Groups are returned in a stable order.
+		sort.Strings(keys)
+
+		var output []*message.Message
+		for _, key := range keys {
+			msgs, err := tf.sum(key)
+			if err != nil {
+				return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", err)
+			}
+
+			output = append(output, msgs...)
+		}
+
+		tf.agg.ResetAll()
+		tf.msgs = make(map[string][]*message.Message)
+
+		output = append(output, msg)
+		return output, nil
+	}
+
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	if ok := tf.agg.Add(key, msg.Data()); ok {
+		tf.msgs[key] = append(tf.msgs[key], msg)
+		return nil, nil
+	}
+
+	output, err := tf.sum(key)
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", err)
+	}
+
+	// If data cannot be added after reset, then the batch is misconfgured.
+	tf.agg.Reset(key)
+	if ok := tf.agg.Add(key, msg.Data()); !ok {
+		return nil, fmt.Errorf("transform: aggregate_cumulative_sum: %v", errSendBatchMisconfigured)
+	}
+
+	tf.msgs[key] = []*message.Message{msg}
+	return output, nil
+}
+
+func (tf *aggregateCumulativeSum) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// sum sets the running total into each message in the batch and removes the
+// batch.
+func (tf *aggregateCumulativeSum) sum(key string) ([]*message.Message, error) {
+	msgs := tf.msgs[key]
+	delete(tf.msgs, key)
+
+	if tf.conf.SortKey != "" {
+		sort.SliceStable(msgs, func(i, j int) bool {
+			return aggCumulativeSumLess(
+				msgs[i].GetValue(tf.conf.SortKey),
+				msgs[j].GetValue(tf.conf.SortKey),
+			)
+		})
+	}
+
+	values := make([]float64, len(msgs))
+	var total float64
+	for i, msg := range msgs {
+		if v, ok := msg.GetValue(tf.conf.Object.SourceKey).Value().(float64); ok {
+			values[i] = v
+		}
+
+		total += values[i]
+		if tf.conf.Window > 0 && i >= tf.conf.Window {
+			total -= values[i-tf.conf.Window]
+		}
+
+		// Floats are normalized the same way as the number_math transforms.
+		f, err := strconv.ParseFloat(numberFloat64ToString(total), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := msg.SetValue(tf.conf.Object.TargetKey, f); err != nil {
+			return nil, err
+		}
+	}
+
+	return msgs, nil
+}
+
+// aggCumulativeSumLess returns true if a sorts before b. Numbers are compared
+// numerically and all other values are compared as strings.
+func aggCumulativeSumLess(a, b message.Value) bool {
+	af, aok := a.Value().(float64)
+	bf, bok := b.Value().(float64)
+	if aok && bok {
+		return af < bf
+	}
+
+	return a.String() < b.String()
+}
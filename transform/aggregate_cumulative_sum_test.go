@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &aggregateCumulativeSum{}
+
+var aggregateCumulativeSumTests = []struct {
+	name     string
+	cfg      config.Config
+	data     []string
+	expected []string
+}{
+	{
+		"no_limit",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]string{
+			`{"a":1}`,
+			`{"a":2.5}`,
+			`{"a":"x"}`,
+			`{"a":-1}`,
+		},
+		[]string{
+			`{"a":1,"b":1}`,
+			`{"a":2.5,"b":3.5}`,
+			`{"a":"x","b":3.5}`,
+			`{"a":-1,"b":2.5}`,
+		},
+	},
+	{
+		"with_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+					"batch_key":  "c",
+				},
+			},
+		},
+		[]string{
+			`{"a":1,"c":"x"}`,
+			`{"a":10,"c":"y"}`,
+			`{"a":2,"c":"x"}`,
+			`{"a":20,"c":"y"}`,
+		},
+		[]string{
+			`{"a":1,"c":"x","b":1}`,
+			`{"a":2,"c":"x","b":3}`,
+			`{"a":10,"c":"y","b":10}`,
+			`{"a":20,"c":"y","b":30}`,
+		},
+	},
+	{
+		"sort_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"sort_key": "t",
+			},
+		},
+		[]string{
+			`{"a":3,"t":"2024-01-03T00:00:00Z"}`,
+			`{"a":1,"t":"2024-01-01T00:00:00Z"}`,
+			`{"a":2,"t":"2024-01-02T00:00:00Z"}`,
+		},
+		[]string{
+			`{"a":1,"t":"2024-01-01T00:00:00Z","b":1}`,
+			`{"a":2,"t":"2024-01-02T00:00:00Z","b":3}`,
+			`{"a":3,"t":"2024-01-03T00:00:00Z","b":6}`,
+		},
+	},
+	{
+		"window",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"window": 2,
+			},
+		},
+		[]string{
+			`{"a":1}`,
+			`{"a":2}`,
+			`{"a":3}`,
+			`{"a":4}`,
+		},
+		[]string{
+			`{"a":1,"b":1}`,
+			`{"a":2,"b":3}`,
+			`{"a":3,"b":5}`,
+			`{"a":4,"b":7}`,
+		},
+	},
+	{
+		"max_count",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"batch": map[string]interface{}{
+					"count": 2,
+				},
+			},
+		},
+		[]string{
+			`{"a":1}`,
+			`{"a":2}`,
+			`{"a":3}`,
+		},
+		[]string{
+			`{"a":1,"b":1}`,
+			`{"a":2,"b":3}`,
+			`{"a":3,"b":3}`,
+		},
+	},
+}
+
+func TestAggregateCumulativeSum(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range aggregateCumulativeSumTests {
+		t.Run(test.name, func(t *testing.T) {
+			var messages []*message.Message
+			for _, data := range test.data {
+				msg := message.New().SetData([]byte(data))
+				messages = append(messages, msg)
+			}
+
+			// aggregateCumulativeSum relies on an interrupt message to flush the buffer,
+			// so it's always added and then removed from the output.
+			ctrl := message.New().AsControl()
+			messages = append(messages, ctrl)
+
+			tf, err := newAggregateCumulativeSum(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := Apply(ctx, []Transformer{tf}, messages...)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var arr []string
+			for _, c := range result {
+				if c.IsControl() {
+					continue
+				}
+
+				arr = append(arr, string(c.Data()))
+			}
+
+			if !slices.Equal(arr, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, arr)
+			}
+		})
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func newAggregateFromArray(_ context.Context, cfg config.Config) (*aggregateFromArray, error) {
-	conf := aggregateArrayConfig{}
+	conf := aggregateFromArrayConfig{}
 	if err := conf.Decode(cfg.Settings); err != nil {
 		return nil, fmt.Errorf("transform: aggregate_from_array: %v", err)
 	}
@@ -26,7 +26,7 @@ func newAggregateFromArray(_ context.Context, cfg config.Config) (*aggregateFrom
 }
 
 type aggregateFromArray struct {
-	conf      aggregateArrayConfig
+	conf      aggregateFromArrayConfig
 	hasObjSrc bool
 	hasObjTrg bool
 }
@@ -56,7 +56,18 @@ func (tf *aggregateFromArray) Transform(ctx context.Context, msg *message.Messag
 	for _, res := range value.Array() {
 		outMsg := message.New().SetMetadata(meta)
 
-		if tf.hasObjSrc {
+		if tf.hasObjSrc && len(tf.conf.Envelope) > 0 {
+			for _, key := range tf.conf.Envelope {
+				val := msg.GetValue(key)
+				if !val.Exists() {
+					continue
+				}
+
+				if err := outMsg.SetValue(key, val.Value()); err != nil {
+					return nil, err
+				}
+			}
+		} else if tf.hasObjSrc {
 			for key, val := range msg.GetValue("@this").Map() {
 				if err := outMsg.SetValue(key, val.Value()); err != nil {
 					return nil, err
@@ -73,7 +84,7 @@ func (tf *aggregateFromArray) Transform(ctx context.Context, msg *message.Messag
 			continue
 		}
 
-		if tf.hasObjSrc {
+		if tf.hasObjSrc && len(outMsg.Data()) != 0 {
 			tmp := fmt.Sprintf(`[%s,%s]`, outMsg.Data(), res.String())
 			join := gjson.GetBytes([]byte(tmp), "@join")
 
@@ -87,6 +87,42 @@ var aggregateFromArrayTests = []struct {
 			`{"y":"z","x":{"e":"f"}}`,
 		},
 	},
+	{
+		"object with envelope",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "events",
+				},
+				"envelope": []string{"meta", "id"},
+			},
+		},
+		[]string{
+			`{"meta":{"a":"b"},"events":[{"c":"d"},{"e":"f"}],"id":1,"y":"z"}`,
+		},
+		[]string{
+			`{"meta":{"a":"b"},"id":1,"c":"d"}`,
+			`{"meta":{"a":"b"},"id":1,"e":"f"}`,
+		},
+	},
+	{
+		"object with missing envelope",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "events",
+				},
+				"envelope": []string{"meta"},
+			},
+		},
+		[]string{
+			`{"events":[{"c":"d"},{"e":"f"}],"y":"z"}`,
+		},
+		[]string{
+			`{"c":"d"}`,
+			`{"e":"f"}`,
+		},
+	},
 }
 
 func TestAggregateFromArray(t *testing.T) {
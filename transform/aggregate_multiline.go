@@ -0,0 +1,181 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type aggregateMultilineConfig struct {
+	// Pattern is the regular expression that matches continuation lines (e.g.,
+	// "^\\s+" for indented lines or "^(\\s+at |Caused by:)" for Java stack traces).
+	// Continuation lines are joined to the previous line.
+	Pattern string `json:"pattern"`
+	// Separator is the string that is used to join lines.
+	//
+	// This is optional and defaults to "\n".
+	Separator string `json:"separator"`
+	// MaxLines is the maximum number of lines that are joined, including the
+	// first line. If this is reached, then the next line starts a new message.
+	//
+	// This is optional and defaults to 500.
+	MaxLines int `json:"max_lines"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *aggregateMultilineConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *aggregateMultilineConfig) Validate() error {
+	if c.Pattern == "" {
+		return fmt.Errorf("pattern: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey != "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newAggregateMultiline(_ context.Context, cfg config.Config) (*aggregateMultiline, error) {
+	conf := aggregateMultilineConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_multiline: %v", err)
+	}
+
+	if conf.Separator == "" {
+		conf.Separator = "\n"
+	}
+
+	if conf.MaxLines <= 0 {
+		conf.MaxLines = 500
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_multiline: %v", err)
+	}
+
+	re, err := regexp.Compile(conf.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_multiline: %v", err)
+	}
+
+	tf := aggregateMultiline{
+		conf:      conf,
+		hasObjSrc: conf.Object.SourceKey != "",
+		re:        re,
+		pending:   make(map[string]*aggregateMultilineRecord),
+	}
+
+	return &tf, nil
+}
+
+type aggregateMultilineRecord struct {
+	msg   *message.Message
+	lines []string
+}
+
+// aggregateMultiline joins consecutive messages that are lines of a single
+// event (e.g., a stack trace) into one message. If the transform is configured
+// with a source key, then lines are read from and joined into that key of the
+// first message, otherwise the data of each message is a line. Lines from
+// different sources (e.g., hosts) can be joined separately by using a batch key.
+//
+// Messages are emitted when the next event starts or when the transform is
+// flushed, so output is delayed by one message for each batch key.
+type aggregateMultiline struct {
+	conf      aggregateMultilineConfig
+	hasObjSrc bool
+	re        *regexp.Regexp
+
+	mu      sync.Mutex
+	pending map[string]*aggregateMultilineRecord
+}
+
+func (tf *aggregateMultiline) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		var output []*message.Message
+		for _, rec := range tf.pending {
+			outMsg, err := tf.join(rec)
+			if err != nil {
+				return nil, fmt.Errorf("transform: aggregate_multiline: %v", err)
+			}
+
+			output = append(output, outMsg)
+		}
+
+		tf.pending = make(map[string]*aggregateMultilineRecord)
+
+		output = append(output, msg)
+		return output, nil
+	}
+
+	var line string
+	if tf.hasObjSrc {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		line = value.String()
+	} else {
+		line = string(msg.Data())
+	}
+
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	rec, ok := tf.pending[key]
+	if ok && len(rec.lines) < tf.conf.MaxLines && tf.re.MatchString(line) {
+		rec.lines = append(rec.lines, line)
+		return nil, nil
+	}
+
+	tf.pending[key] = &aggregateMultilineRecord{
+		msg:   msg,
+		lines: []string{line},
+	}
+
+	if !ok {
+		return nil, nil
+	}
+
+	outMsg, err := tf.join(rec)
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_multiline: %v", err)
+	}
+
+	return []*message.Message{outMsg}, nil
+}
+
+func (tf *aggregateMultiline) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *aggregateMultiline) join(rec *aggregateMultilineRecord) (*message.Message, error) {
+	s := strings.Join(rec.lines, tf.conf.Separator)
+
+	if !tf.hasObjSrc {
+		rec.msg.SetData([]byte(s))
+		return rec.msg, nil
+	}
+
+	if err := rec.msg.SetValue(tf.conf.Object.SourceKey, s); err != nil {
+		return nil, err
+	}
+
+	return rec.msg, nil
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &aggregateMultiline{}
+
+var aggregateMultilineTests = []struct {
+	name     string
+	cfg      config.Config
+	data     []string
+	expected []string
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `^(\s+at |Caused by:)`,
+			},
+		},
+		[]string{
+			`Exception in thread "main" java.lang.NullPointerException`,
+			`    at com.example.App.run(App.java:10)`,
+			`    at com.example.App.main(App.java:5)`,
+			`Caused by: java.lang.IllegalStateException`,
+			`INFO started`,
+			`INFO stopped`,
+		},
+		[]string{
+			"Exception in thread \"main\" java.lang.NullPointerException\n    at com.example.App.run(App.java:10)\n    at com.example.App.main(App.java:5)\nCaused by: java.lang.IllegalStateException",
+			`INFO started`,
+			`INFO stopped`,
+		},
+	},
+	{
+		"data with separator and max_lines",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":   `^\s`,
+				"separator": " | ",
+				"max_lines": 2,
+			},
+		},
+		[]string{
+			`a`,
+			` b`,
+			` c`,
+		},
+		[]string{
+			`a |  b`,
+			` c`,
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "msg",
+					"batch_key":  "host",
+				},
+				"pattern": `^\s+`,
+			},
+		},
+		[]string{
+			`{"host":"a","msg":"Traceback (most recent call last):"}`,
+			`{"host":"b","msg":"ok"}`,
+			`{"host":"a","msg":"  File \"app.py\", line 1"}`,
+			`{"host":"a","msg":"ValueError"}`,
+		},
+		[]string{
+			`{"host":"a","msg":"Traceback (most recent call last):\n  File \"app.py\", line 1"}`,
+			`{"host":"b","msg":"ok"}`,
+			`{"host":"a","msg":"ValueError"}`,
+		},
+	},
+}
+
+func TestAggregateMultiline(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range aggregateMultilineTests {
+		t.Run(test.name, func(t *testing.T) {
+			var messages []*message.Message
+			for _, data := range test.data {
+				msg := message.New().SetData([]byte(data))
+				messages = append(messages, msg)
+			}
+
+			// aggregateMultiline relies on an interrupt message to flush the buffer,
+			// so it's always added and then removed from the output.
+			ctrl := message.New().AsControl()
+			messages = append(messages, ctrl)
+
+			tf, err := newAggregateMultiline(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := Apply(ctx, []Transformer{tf}, messages...)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var arr []string
+			for _, c := range result {
+				if c.IsControl() {
+					continue
+				}
+
+				arr = append(arr, string(c.Data()))
+			}
+
+			if len(arr) != len(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, arr)
+			}
+
+			// The order of the output is not guaranteed, so we need to
+			// check that the expected values are present anywhere in the
+			// result.
+			for _, r := range arr {
+				if !slices.Contains(test.expected, r) {
+					t.Errorf("expected %s, got %s", test.expected, r)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type aggregateToStatsConfig struct {
+	// Percentiles are the percentiles that are calculated for each batch. Each
+	// percentile is put into the output as "p" followed by the percentile, with
+	// decimals replaced by underscores (e.g., 99.9 is "p99_9").
+	//
+	// This is optional and defaults to [50, 95, 99].
+	Percentiles []float64 `json:"percentiles"`
+	// Algorithm determines how percentiles are calculated.
+	//
+	// Must be one of:
+	//	- exact: sorts all values in the batch
+	//	- tdigest: approximates percentiles using a t-digest, which uses a
+	//	fixed amount of memory for large batches
+	//
+	// This is optional and defaults to exact.
+	Algorithm string `json:"algorithm"`
+	// Compression is the compression of the t-digest. Higher values are more
+	// accurate and use more memory. This is only used when the algorithm is
+	// tdigest.
+	//
+	// This is optional and defaults to 100.
+	Compression float64 `json:"compression"`
+
+	Object iconfig.Object `json:"object"`
+	Batch  iconfig.Batch  `json:"batch"`
+}
+
+func (c *aggregateToStatsConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *aggregateToStatsConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, p := range c.Percentiles {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("percentiles %v: %v", p, errors.ErrInvalidOption)
+		}
+	}
+
+	if !slices.Contains(
+		[]string{
+			"exact",
+			"tdigest",
+		},
+		c.Algorithm) {
+		return fmt.Errorf("algorithm %q: %v", c.Algorithm, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newAggregateToStats(_ context.Context, cfg config.Config) (*aggregateToStats, error) {
+	conf := aggregateToStatsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_to_stats: %v", err)
+	}
+
+	if conf.Percentiles == nil {
+		conf.Percentiles = []float64{50, 95, 99}
+	}
+
+	if conf.Algorithm == "" {
+		conf.Algorithm = "exact"
+	}
+
+	if conf.Compression <= 0 {
+		conf.Compression = 100
+	}
+
+	if conf.Batch.Count < 1 {
+		conf.Batch.Count = 1000
+	}
+
+	if conf.Batch.Size < 1 {
+		conf.Batch.Size = 1024 * 1024
+	}
+
+	if conf.Batch.Duration == "" {
+		conf.Batch.Duration = "1m"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: aggregate_to_stats: %v", err)
+	}
+
+	dur, err := time.ParseDuration(conf.Batch.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_to_stats: duration: %v", err)
+	}
+
+	tf := aggregateToStats{
+		conf:      conf,
+		hasObjTrg: conf.Object.TargetKey != "",
+		dur:       dur,
+		groups:    make(map[string]*aggStatsGroup),
+	}
+
+	return &tf, nil
+}
+
+// aggregateToStats calculates statistics (count, sum, min, max, mean, and
+// percentiles) of a numeric value across a batch of messages. Each batch
+// produces one message that contains the statistics and, if the batch is
+// grouped by a key, the value of the key.
+//
+// Statistics are updated as messages are received, so batches do not keep
+// copies of the data. Values that are not numbers are ignored.
+type aggregateToStats struct {
+	conf      aggregateToStatsConfig
+	hasObjTrg bool
+	dur       time.Duration
+
+	// mu protects the batches, which are grouped by the batch key.
+	mu     sync.Mutex
+	groups map[string]*aggStatsGroup
+}
+
+func (tf *aggregateToStats) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		var output []*message.Message
+
+		for key, g := range tf.groups {
+			if g.count == 0 {
+				continue
+			}
+
+			outMsg, err := tf.stats(key, g)
+			if err != nil {
+				return nil, fmt.Errorf("transform: aggregate_to_stats: %v", err)
+			}
+
+			output = append(output, outMsg)
+		}
+
+		tf.groups = make(map[string]*aggStatsGroup)
+
+		output = append(output, msg)
+		return output, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return nil, nil
+	}
+
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	g, ok := tf.groups[key]
+	if !ok {
+		g = tf.newGroup()
+		tf.groups[key] = g
+	}
+
+	b := value.Bytes()
+	if tf.fits(g, len(b)) {
+		g.add(b)
+		return nil, nil
+	}
+
+	outMsg, err := tf.stats(key, g)
+	if err != nil {
+		return nil, fmt.Errorf("transform: aggregate_to_stats: %v", err)
+	}
+
+	// If data cannot be added after reset, then the batch is misconfgured.
+	g = tf.newGroup()
+	tf.groups[key] = g
+	if !tf.fits(g, len(b)) {
+		return nil, fmt.Errorf("transform: aggregate_to_stats: %v", errSendBatchMisconfigured)
+	}
+
+	g.add(b)
+	return []*message.Message{outMsg}, nil
+}
+
+func (tf *aggregateToStats) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *aggregateToStats) newGroup() *aggStatsGroup {
+	g := &aggStatsGroup{
+		start: time.Now(),
+	}
+
+	if tf.conf.Algorithm == "tdigest" {
+		g.td = newAggStatsTDigest(tf.conf.Compression)
+	}
+
+	return g
+}
+
+// fits returns true if a value of the size can be added to the batch. The
+// duration is measured from the first value in the batch.
+func (tf *aggregateToStats) fits(g *aggStatsGroup, size int) bool {
+	if g.count+1 > tf.conf.Batch.Count || g.size+size > tf.conf.Batch.Size {
+		return false
+	}
+
+	return g.count == 0 || time.Since(g.start) <= tf.dur
+}
+
+func (tf *aggregateToStats) stats(key string, g *aggStatsGroup) (*message.Message, error) {
+	stats := map[string]interface{}{
+		"count": g.n,
+	}
+
+	if g.n > 0 {
+		stats["sum"] = g.sum
+		stats["min"] = g.min
+		stats["max"] = g.max
+		stats["mean"] = g.sum / float64(g.n)
+
+		var quantile func(float64) float64
+		if g.td != nil {
+			quantile = g.td.quantile
+		} else {
+			sort.Float64s(g.values)
+			quantile = func(q float64) float64 {
+				return aggStatsQuantile(g.values, q)
+			}
+		}
+
+		for _, p := range tf.conf.Percentiles {
+			stats[aggStatsPercentileKey(p)] = quantile(p / 100)
+		}
+	}
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := message.New()
+	if tf.hasObjTrg {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, err
+		}
+	} else {
+		msg.SetData(b)
+	}
+
+	if tf.conf.Object.BatchKey != "" {
+		if err := msg.SetValue(tf.conf.Object.BatchKey, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, nil
+}
+
+// aggStatsGroup contains the running statistics of a batch. Values are
+// kept only for exact percentiles; t-digest percentiles use the digest.
+type aggStatsGroup struct {
+	// count and size include values that are not numbers, which are used
+	// to limit the size of the batch.
+	count int
+	size  int
+	start time.Time
+
+	n             int
+	sum, min, max float64
+	values        []float64
+	td            *aggStatsTDigest
+}
+
+func (g *aggStatsGroup) add(b []byte) {
+	g.count++
+	g.size += len(b)
+
+	f, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return
+	}
+
+	if g.n == 0 {
+		g.min, g.max = f, f
+	}
+
+	g.n++
+	g.sum += f
+	g.min = math.Min(g.min, f)
+	g.max = math.Max(g.max, f)
+
+	if g.td != nil {
+		g.td.add(f)
+		return
+	}
+
+	g.values = append(g.values, f)
+}
+
+func aggStatsPercentileKey(p float64) string {
+	return "p" + strings.ReplaceAll(strconv.FormatFloat(p, 'f', -1, 64), ".", "_")
+}
+
+// aggStatsQuantile returns the quantile of sorted values using linear
+// interpolation between the closest ranks.
+func aggStatsQuantile(sorted []float64, q float64) float64 {
+	rank := q * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+type aggStatsCentroid struct {
+	mean   float64
+	weight float64
+}
+
+// aggStatsTDigest is a merging t-digest (https://arxiv.org/abs/1902.04023).
+// Values are buffered and periodically merged into centroids, which limits
+// the cost of adding values to large batches.
+type aggStatsTDigest struct {
+	compression float64
+	centroids   []aggStatsCentroid
+	buffer      []aggStatsCentroid
+}
+
+func newAggStatsTDigest(compression float64) *aggStatsTDigest {
+	return &aggStatsTDigest{
+		compression: compression,
+		buffer:      make([]aggStatsCentroid, 0, int(compression)*5),
+	}
+}
+
+func (td *aggStatsTDigest) add(v float64) {
+	td.buffer = append(td.buffer, aggStatsCentroid{mean: v, weight: 1})
+	if len(td.buffer) == cap(td.buffer) {
+		td.merge()
+	}
+}
+
+func (td *aggStatsTDigest) merge() {
+	if len(td.buffer) == 0 {
+		return
+	}
+
+	all := append(td.centroids, td.buffer...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	var total float64
+	for _, c := range all {
+		total += c.weight
+	}
+
+	merged := make([]aggStatsCentroid, 0, len(td.centroids)+1)
+	cur := all[0]
+
+	var prior float64
+	limit := total * td.qLimit(0)
+	for _, c := range all[1:] {
+		if prior+cur.weight+c.weight <= limit {
+			cur.mean += (c.mean - cur.mean) * c.weight / (cur.weight + c.weight)
+			cur.weight += c.weight
+
+			continue
+		}
+
+		prior += cur.weight
+		limit = total * td.qLimit(prior/total)
+		merged = append(merged, cur)
+		cur = c
+	}
+
+	td.centroids = append(merged, cur)
+	td.buffer = td.buffer[:0]
+}
+
+// qLimit returns the maximum quantile of a centroid that starts at q, which
+// is calculated using the k1 scale function.
+func (td *aggStatsTDigest) qLimit(q float64) float64 {
+	k := td.compression / (2 * math.Pi) * math.Asin(2*q-1)
+	return (math.Sin(math.Min((k+1)*2*math.Pi/td.compression, math.Pi/2)) + 1) / 2
+}
+
+func (td *aggStatsTDigest) quantile(q float64) float64 {
+	td.merge()
+
+	if len(td.centroids) == 1 {
+		return td.centroids[0].mean
+	}
+
+	var total float64
+	for _, c := range td.centroids {
+		total += c.weight
+	}
+
+	// Each centroid is centered on its cumulative weight, and values between
+	// centroids are interpolated.
+	target := q * total
+	var cumulative float64
+	for i, c := range td.centroids {
+		mid := cumulative + c.weight/2
+		if target < mid {
+			if i == 0 {
+				return c.mean
+			}
+
+			prev := td.centroids[i-1]
+			prevMid := cumulative - prev.weight/2
+
+			return prev.mean + (c.mean-prev.mean)*(target-prevMid)/(mid-prevMid)
+		}
+
+		cumulative += c.weight
+	}
+
+	return td.centroids[len(td.centroids)-1].mean
+}
@@ -0,0 +1,304 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &aggregateToStats{}
+
+var aggregateToStatsTests = []struct {
+	name     string
+	cfg      config.Config
+	data     []string
+	expected []string
+}{
+	{
+		"no_limit",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"percentiles": []float64{50, 90},
+			},
+		},
+		[]string{
+			`{"a":1}`,
+			`{"a":2}`,
+			`{"a":3}`,
+			`{"a":4}`,
+			`{"b":5}`,
+		},
+		[]string{
+			`{"count":4,"max":4,"mean":2.5,"min":1,"p50":2.5,"p90":3.7,"sum":10}`,
+		},
+	},
+	{
+		"with_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "x",
+					"batch_key":  "b",
+				},
+				"percentiles": []float64{99.9},
+			},
+		},
+		[]string{
+			`{"a":1,"b":"c"}`,
+			`{"a":3,"b":"c"}`,
+			`{"a":10,"b":"d"}`,
+		},
+		[]string{
+			`{"x":{"count":2,"max":3,"mean":2,"min":1,"p99_9":2.998,"sum":4},"b":"c"}`,
+			`{"x":{"count":1,"max":10,"mean":10,"min":10,"p99_9":10,"sum":10},"b":"d"}`,
+		},
+	},
+	{
+		"max_count",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"percentiles": []float64{},
+				"batch": map[string]interface{}{
+					"count": 2,
+				},
+			},
+		},
+		[]string{
+			`{"a":1}`,
+			`{"a":"2"}`,
+			`{"a":3}`,
+		},
+		[]string{
+			`{"count":2,"max":2,"mean":1.5,"min":1,"sum":3}`,
+			`{"count":1,"max":3,"mean":3,"min":3,"sum":3}`,
+		},
+	},
+	{
+		"tdigest",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+				},
+				"algorithm": "tdigest",
+			},
+		},
+		[]string{
+			`{"a":5}`,
+		},
+		[]string{
+			`{"count":1,"max":5,"mean":5,"min":5,"p50":5,"p95":5,"p99":5,"sum":5}`,
+		},
+	},
+}
+
+func TestAggregateToStats(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range aggregateToStatsTests {
+		t.Run(test.name, func(t *testing.T) {
+			var messages []*message.Message
+			for _, data := range test.data {
+				msg := message.New().SetData([]byte(data))
+				messages = append(messages, msg)
+			}
+
+			// aggregateToStats relies on an interrupt message to flush the buffer,
+			// so it's always added and then removed from the output.
+			ctrl := message.New().AsControl()
+			messages = append(messages, ctrl)
+
+			tf, err := newAggregateToStats(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := Apply(ctx, []Transformer{tf}, messages...)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var arr []string
+			for _, c := range result {
+				if c.IsControl() {
+					continue
+				}
+
+				arr = append(arr, string(c.Data()))
+			}
+
+			if len(arr) != len(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, arr)
+			}
+
+			// The order of the output is not guaranteed, so we need to
+			// check that the expected values are present anywhere in the
+			// result.
+			for _, r := range arr {
+				if !slices.Contains(test.expected, r) {
+					t.Errorf("expected %s, got %s", test.expected, r)
+				}
+			}
+		})
+	}
+}
+
+func TestAggStatsTDigest(t *testing.T) {
+	td := newAggStatsTDigest(100)
+	for i := 1; i <= 100000; i++ {
+		td.add(float64(i))
+	}
+
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		expected := q * 100000
+		if result := td.quantile(q); math.Abs(result-expected)/expected > 0.01 {
+			t.Errorf("q%v: expected %v, got %v", q, expected, result)
+		}
+	}
+}
+
+func TestAggregateToStatsTDigestStreaming(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newAggregateToStats(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"object": map[string]interface{}{
+				"source_key": "a",
+			},
+			"algorithm": "tdigest",
+			"batch": map[string]interface{}{
+				"count": 100000,
+				"size":  1024 * 1024 * 10,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 10000; i++ {
+		msg := message.New().SetData([]byte(fmt.Sprintf(`{"a":%d}`, i)))
+		if _, err := tf.Transform(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Values are added to the digest as they are received, so the batch
+	// does not keep them.
+	if g := tf.groups[""]; g == nil || g.values != nil || g.td == nil {
+		t.Fatalf("expected values to be added to the digest, got %+v", g)
+	}
+
+	result, err := tf.Transform(ctx, message.New().AsControl())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(result))
+	}
+
+	msg := result[0]
+	if c := msg.GetValue("count").Int(); c != 10000 {
+		t.Errorf("expected count 10000, got %d", c)
+	}
+
+	for _, p := range []string{"p50", "p95", "p99"} {
+		expected := map[string]float64{"p50": 5000, "p95": 9500, "p99": 9900}[p]
+		if v := msg.GetValue(p).Float(); math.Abs(v-expected)/expected > 0.01 {
+			t.Errorf("%s: expected %v, got %v", p, expected, v)
+		}
+	}
+}
+
+func TestAggregateToStatsLimits(t *testing.T) {
+	ctx := context.TODO()
+
+	// The size of the batch is the size of the values.
+	tf, err := newAggregateToStats(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"object": map[string]interface{}{
+				"source_key": "a",
+			},
+			"percentiles": []float64{},
+			"batch": map[string]interface{}{
+				"size": 3,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output []string
+	for _, d := range []string{`{"a":10}`, `{"a":2}`, `{"a":30}`} {
+		result, err := tf.Transform(ctx, message.New().SetData([]byte(d)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range result {
+			output = append(output, string(r.Data()))
+		}
+	}
+
+	expected := []string{`{"count":2,"max":10,"mean":6,"min":2,"sum":12}`}
+	if !slices.Equal(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+
+	// Values that are larger than the batch cannot be added.
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"a":1000}`))); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestAggregateToStatsDuration(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newAggregateToStats(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"object": map[string]interface{}{
+				"source_key": "a",
+			},
+			"percentiles": []float64{},
+			"batch": map[string]interface{}{
+				"duration": "50ms",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output []string
+	for _, d := range []string{`{"a":1}`, `{"a":2}`, `{"a":3}`} {
+		result, err := tf.Transform(ctx, message.New().SetData([]byte(d)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range result {
+			output = append(output, string(r.Data()))
+		}
+
+		// The duration is measured from the first value in the batch.
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	expected := []string{`{"count":2,"max":2,"mean":1.5,"min":1,"sum":3}`}
+	if !slices.Equal(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
@@ -0,0 +1,7 @@
+package transform
+
+import "fmt"
+
+// errArrayNotArray is returned when a transform that requires an
+// array receives a value that is not an array.
+var errArrayNotArray = fmt.Errorf("input must be array")
@@ -0,0 +1,113 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type arrayIndexConfig struct {
+	// Index is the position of the element that is retrieved from the array.
+	// Negative values count from the end of the array (e.g., -1 is the last
+	// element).
+	//
+	// This is optional and defaults to 0 (the first element).
+	Index int `json:"index"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *arrayIndexConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *arrayIndexConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newArrayIndex(_ context.Context, cfg config.Config) (*arrayIndex, error) {
+	conf := arrayIndexConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: array_index: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: array_index: %v", err)
+	}
+
+	tf := arrayIndex{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type arrayIndex struct {
+	conf     arrayIndexConfig
+	isObject bool
+}
+
+func (tf *arrayIndex) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !value.IsArray() {
+		return nil, fmt.Errorf("transform: array_index: %v", errArrayNotArray)
+	}
+
+	arr := value.Array()
+	i := tf.conf.Index
+	if i < 0 {
+		i += len(arr)
+	}
+
+	// Out of range indices do not produce an error, which is consistent
+	// with retrieving a key that does not exist.
+	if i < 0 || i >= len(arr) {
+		return []*message.Message{msg}, nil
+	}
+
+	v := arr[i]
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: array_index: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(v.Bytes())
+	return []*message.Message{msg}, nil
+}
+
+func (tf *arrayIndex) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &arrayIndex{}
+
+var arrayIndexTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`["a","b","c"]`),
+		[][]byte{
+			[]byte(`a`),
+		},
+	},
+	{
+		"data last",
+		config.Config{
+			Settings: map[string]interface{}{
+				"index": -1,
+			},
+		},
+		[]byte(`[{"a":1},{"a":2}]`),
+		[][]byte{
+			[]byte(`{"a":2}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"index": 1,
+			},
+		},
+		[]byte(`{"a":[1,2,3]}`),
+		[][]byte{
+			[]byte(`{"a":[1,2,3],"b":2}`),
+		},
+	},
+	{
+		"object out of range",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"index": 5,
+			},
+		},
+		[]byte(`{"a":[1,2,3]}`),
+		[][]byte{
+			[]byte(`{"a":[1,2,3]}`),
+		},
+	},
+}
+
+func TestArrayIndex(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range arrayIndexTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newArrayIndex(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkArrayIndex(b *testing.B, tf *arrayIndex, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkArrayIndex(b *testing.B) {
+	for _, test := range arrayIndexTests {
+		tf, err := newArrayIndex(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkArrayIndex(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type arraySetConfig struct {
+	// Operation is the set operation that is applied to the arrays.
+	//
+	// Must be one of:
+	//	- union: values in either array
+	//	- intersection: values in both arrays
+	//	- difference: values in the source array that are not in the other array
+	Operation string `json:"operation"`
+	// OtherKey retrieves the second array from a JSON object. If the key does
+	// not exist, then the second array is empty.
+	OtherKey string `json:"other_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *arraySetConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *arraySetConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.OtherKey == "" {
+		return fmt.Errorf("other_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"union",
+			"intersection",
+			"difference",
+		},
+		c.Operation) {
+		return fmt.Errorf("operation %q: %v", c.Operation, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newArraySet(_ context.Context, cfg config.Config) (*arraySet, error) {
+	conf := arraySetConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: array_set: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: array_set: %v", err)
+	}
+
+	tf := arraySet{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// arraySet applies a set operation to two arrays. The arrays are treated as
+// sets, so the output never contains duplicate values. Values are ordered by
+// when they were first seen in the source array and then the other array.
+// Values are compared the same way as arrayUnique.
+type arraySet struct {
+	conf arraySetConfig
+}
+
+func (tf *arraySet) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	left := msg.GetValue(tf.conf.Object.SourceKey)
+	if !left.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	right := msg.GetValue(tf.conf.OtherKey)
+	if !left.IsArray() || (right.Exists() && !right.IsArray()) {
+		return nil, fmt.Errorf("transform: array_set: %v", errArrayNotArray)
+	}
+
+	v, err := tf.apply(left.Array(), right.Array())
+	if err != nil {
+		return nil, fmt.Errorf("transform: array_set: %v", err)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+		return nil, fmt.Errorf("transform: array_set: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *arraySet) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *arraySet) apply(left, right []message.Value) ([]interface{}, error) {
+	inRight := make(map[string]struct{})
+	for _, r := range right {
+		k, err := json.Marshal(r.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		inRight[string(k)] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	out := []interface{}{}
+
+	add := func(v message.Value, include func(string) bool) error {
+		k, err := json.Marshal(v.Value())
+		if err != nil {
+			return err
+		}
+
+		if _, ok := seen[string(k)]; ok || !include(string(k)) {
+			return nil
+		}
+
+		seen[string(k)] = struct{}{}
+		out = append(out, v.Value())
+
+		return nil
+	}
+
+	var include func(string) bool
+	switch tf.conf.Operation {
+	case "union":
+		include = func(string) bool { return true }
+	case "intersection":
+		include = func(k string) bool {
+			_, ok := inRight[k]
+			return ok
+		}
+	case "difference":
+		include = func(k string) bool {
+			_, ok := inRight[k]
+			return !ok
+		}
+	}
+
+	for _, l := range left {
+		if err := add(l, include); err != nil {
+			return nil, err
+		}
+	}
+
+	if tf.conf.Operation == "union" {
+		for _, r := range right {
+			if err := add(r, include); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
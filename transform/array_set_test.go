@@ -0,0 +1,155 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &arraySet{}
+
+var arraySetTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"union",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "union",
+				"other_key": "b",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":["x","y","x"],"b":["z","y"]}`),
+		[][]byte{
+			[]byte(`{"a":["x","y","x"],"b":["z","y"],"c":["x","y","z"]}`),
+		},
+	},
+	{
+		"intersection",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "intersection",
+				"other_key": "b",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":["x","y",{"k":1,"l":2}],"b":[{"l":2,"k":1},"y"]}`),
+		[][]byte{
+			[]byte(`{"a":["x","y",{"k":1,"l":2}],"b":[{"l":2,"k":1},"y"],"c":["y",{"k":1,"l":2}]}`),
+		},
+	},
+	{
+		"difference",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "difference",
+				"other_key": "b",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":["read","write","admin"],"b":["read"]}`),
+		[][]byte{
+			[]byte(`{"a":["read","write","admin"],"b":["read"],"c":["write","admin"]}`),
+		},
+	},
+	{
+		"difference missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "difference",
+				"other_key": "b",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":[1,"1",1]}`),
+		[][]byte{
+			[]byte(`{"a":[1,"1",1],"c":[1,"1"]}`),
+		},
+	},
+	{
+		"intersection empty",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "intersection",
+				"other_key": "b",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":[1],"b":[2]}`),
+		[][]byte{
+			[]byte(`{"a":[1],"b":[2],"c":[]}`),
+		},
+	},
+}
+
+func TestArraySet(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range arraySetTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newArraySet(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkArraySet(b *testing.B, tf *arraySet, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkArraySet(b *testing.B) {
+	for _, test := range arraySetTests {
+		tf, err := newArraySet(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkArraySet(b, tf, test.test)
+			},
+		)
+	}
+}
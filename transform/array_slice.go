@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type arraySliceConfig struct {
+	// Start is the index of the first element in the slice. Negative values
+	// count from the end of the array (e.g., -1 is the last element).
+	//
+	// This is optional and defaults to 0.
+	Start int `json:"start"`
+	// End is the index of the element after the last element in the slice.
+	// Negative values count from the end of the array.
+	//
+	// This is optional and defaults to the end of the array.
+	End int `json:"end"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *arraySliceConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *arraySliceConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newArraySlice(_ context.Context, cfg config.Config) (*arraySlice, error) {
+	conf := arraySliceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: array_slice: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: array_slice: %v", err)
+	}
+
+	tf := arraySlice{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type arraySlice struct {
+	conf     arraySliceConfig
+	isObject bool
+}
+
+func (tf *arraySlice) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !value.IsArray() {
+		return nil, fmt.Errorf("transform: array_slice: %v", errArrayNotArray)
+	}
+
+	arr := value.Array()
+	start := resolveIndex(tf.conf.Start, len(arr))
+	end := len(arr)
+	if tf.conf.End != 0 {
+		end = resolveIndex(tf.conf.End, len(arr))
+	}
+
+	v := []interface{}{}
+	for i := start; i < end; i++ {
+		v = append(v, arr[i].Value())
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: array_slice: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(anyToBytes(v))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *arraySlice) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
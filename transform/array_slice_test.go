@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &arraySlice{}
+
+var arraySliceTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 1,
+				"end":   3,
+			},
+		},
+		[]byte(`[1,2,3,4]`),
+		[][]byte{
+			[]byte(`[2,3]`),
+		},
+	},
+	{
+		"data negative",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": -2,
+			},
+		},
+		[]byte(`["a","b","c"]`),
+		[][]byte{
+			[]byte(`["b","c"]`),
+		},
+	},
+	{
+		"data out of range",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 2,
+				"end":   10,
+			},
+		},
+		[]byte(`[1,2,3]`),
+		[][]byte{
+			[]byte(`[3]`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"end": -1,
+			},
+		},
+		[]byte(`{"a":[{"c":1},{"c":2},{"c":3}]}`),
+		[][]byte{
+			[]byte(`{"a":[{"c":1},{"c":2},{"c":3}],"b":[{"c":1},{"c":2}]}`),
+		},
+	},
+	{
+		"object empty",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"start": 5,
+			},
+		},
+		[]byte(`{"a":[1,2]}`),
+		[][]byte{
+			[]byte(`{"a":[1,2],"b":[]}`),
+		},
+	},
+}
+
+func TestArraySlice(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range arraySliceTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newArraySlice(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkArraySlice(b *testing.B, tf *arraySlice, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkArraySlice(b *testing.B) {
+	for _, test := range arraySliceTests {
+		tf, err := newArraySlice(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkArraySlice(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,163 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type arraySortConfig struct {
+	// By is the key used to sort arrays of objects. If the key does not exist
+	// in an object, then the object is sorted as if the value is empty.
+	//
+	// This is optional and has no default (elements are sorted by their value).
+	By string `json:"by"`
+	// Order is the order that the array is sorted in.
+	//
+	// Must be one of:
+	//	- asc: ascending order
+	//	- desc: descending order
+	//
+	// This is optional and defaults to asc.
+	Order string `json:"order"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *arraySortConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *arraySortConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Order != "" && !slices.Contains(
+		[]string{
+			"asc",
+			"desc",
+		},
+		c.Order) {
+		return fmt.Errorf("order %q: %v", c.Order, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newArraySort(_ context.Context, cfg config.Config) (*arraySort, error) {
+	conf := arraySortConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: array_sort: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: array_sort: %v", err)
+	}
+
+	tf := arraySort{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type arraySort struct {
+	conf     arraySortConfig
+	isObject bool
+}
+
+func (tf *arraySort) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !value.IsArray() {
+		return nil, fmt.Errorf("transform: array_sort: %v", errArrayNotArray)
+	}
+
+	v := tf.sort(value.Array())
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: array_sort: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(anyToBytes(v))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *arraySort) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// sort returns the values of the array in sorted order. If all sort keys are
+// numbers, then they are sorted numerically, otherwise they are sorted
+// lexically. The sort is stable, so elements with equal keys keep their
+// original order.
+func (tf *arraySort) sort(arr []message.Value) []interface{} {
+	keys := make([]message.Value, len(arr))
+	isNumeric := true
+	for i, a := range arr {
+		keys[i] = a
+		if tf.conf.By != "" {
+			keys[i] = message.New().SetData(a.Bytes()).GetValue(tf.conf.By)
+		}
+
+		if _, ok := keys[i].Value().(float64); !ok {
+			isNumeric = false
+		}
+	}
+
+	idx := make([]int, len(arr))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := keys[idx[i]], keys[idx[j]]
+		if tf.conf.Order == "desc" {
+			a, b = b, a
+		}
+
+		if isNumeric {
+			return a.Float() < b.Float()
+		}
+
+		return a.String() < b.String()
+	})
+
+	out := make([]interface{}, len(arr))
+	for i, j := range idx {
+		out[i] = arr[j].Value()
+	}
+
+	return out
+}
@@ -0,0 +1,131 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &arraySort{}
+
+var arraySortTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`["c","a","b"]`),
+		[][]byte{
+			[]byte(`["a","b","c"]`),
+		},
+	},
+	{
+		"data numeric",
+		config.Config{},
+		[]byte(`[10,9,100]`),
+		[][]byte{
+			[]byte(`[9,10,100]`),
+		},
+	},
+	{
+		"data desc",
+		config.Config{
+			Settings: map[string]interface{}{
+				"order": "desc",
+			},
+		},
+		[]byte(`[1,3,2]`),
+		[][]byte{
+			[]byte(`[3,2,1]`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":["foo","bar","baz"]}`),
+		[][]byte{
+			[]byte(`{"a":["bar","baz","foo"]}`),
+		},
+	},
+	{
+		"object by",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"by": "ts",
+			},
+		},
+		[]byte(`{"a":[{"ts":3,"id":"x"},{"ts":1,"id":"y"},{"ts":3,"id":"z"}]}`),
+		[][]byte{
+			[]byte(`{"a":[{"ts":3,"id":"x"},{"ts":1,"id":"y"},{"ts":3,"id":"z"}],"b":[{"id":"y","ts":1},{"id":"x","ts":3},{"id":"z","ts":3}]}`),
+		},
+	},
+}
+
+func TestArraySort(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range arraySortTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newArraySort(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkArraySort(b *testing.B, tf *arraySort, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkArraySort(b *testing.B) {
+	for _, test := range arraySortTests {
+		tf, err := newArraySort(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkArraySort(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type arrayUniqueConfig struct {
+	// By is the key used to determine uniqueness in arrays of objects. If the
+	// key does not exist in an object, then the object is compared as if the
+	// value is null.
+	//
+	// This is optional and has no default (elements are compared by their value).
+	By string `json:"by"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *arrayUniqueConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *arrayUniqueConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newArrayUnique(_ context.Context, cfg config.Config) (*arrayUnique, error) {
+	conf := arrayUniqueConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: array_unique: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: array_unique: %v", err)
+	}
+
+	tf := arrayUnique{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type arrayUnique struct {
+	conf     arrayUniqueConfig
+	isObject bool
+}
+
+func (tf *arrayUnique) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !value.IsArray() {
+		return nil, fmt.Errorf("transform: array_unique: %v", errArrayNotArray)
+	}
+
+	v, err := tf.unique(value.Array())
+	if err != nil {
+		return nil, fmt.Errorf("transform: array_unique: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: array_unique: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(anyToBytes(v))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *arrayUnique) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// unique returns the values of the array with duplicates removed, preserving
+// the order in which values were first seen. Values are compared by type and
+// value, so mixed-type arrays keep values that look the same but have
+// different types (e.g., 1 and "1"). Objects are equal if they contain the
+// same keys and values, regardless of key order.
+func (tf *arrayUnique) unique(arr []message.Value) ([]interface{}, error) {
+	seen := make(map[string]struct{})
+	out := []interface{}{}
+
+	for _, a := range arr {
+		k := a
+		if tf.conf.By != "" {
+			k = message.New().SetData(a.Bytes()).GetValue(tf.conf.By)
+		}
+
+		b, err := json.Marshal(k.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[string(b)]; ok {
+			continue
+		}
+
+		seen[string(b)] = struct{}{}
+		out = append(out, a.Value())
+	}
+
+	return out, nil
+}
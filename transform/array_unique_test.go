@@ -0,0 +1,127 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &arrayUnique{}
+
+var arrayUniqueTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`["b","a","b","c","a"]`),
+		[][]byte{
+			[]byte(`["b","a","c"]`),
+		},
+	},
+	{
+		"data mixed",
+		config.Config{},
+		[]byte(`[1,"1",1,true,"true",null,null]`),
+		[][]byte{
+			[]byte(`[1,"1",true,"true",null]`),
+		},
+	},
+	{
+		"data objects",
+		config.Config{},
+		[]byte(`[{"a":1,"b":2},{"b":2,"a":1},{"a":2}]`),
+		[][]byte{
+			[]byte(`[{"a":1,"b":2},{"a":2}]`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":["x","x","y"]}`),
+		[][]byte{
+			[]byte(`{"a":["x","y"]}`),
+		},
+	},
+	{
+		"object by",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"by": "id",
+			},
+		},
+		[]byte(`{"a":[{"id":1,"v":"x"},{"id":2,"v":"y"},{"id":1,"v":"z"}]}`),
+		[][]byte{
+			[]byte(`{"a":[{"id":1,"v":"x"},{"id":2,"v":"y"},{"id":1,"v":"z"}],"b":[{"id":1,"v":"x"},{"id":2,"v":"y"}]}`),
+		},
+	},
+}
+
+func TestArrayUnique(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range arrayUniqueTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newArrayUnique(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkArrayUnique(b *testing.B, tf *arrayUnique, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkArrayUnique(b *testing.B) {
+	for _, test := range arrayUniqueTests {
+		tf, err := newArrayUnique(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkArrayUnique(b, tf, test.test)
+			},
+		)
+	}
+}
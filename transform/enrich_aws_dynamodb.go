@@ -88,6 +88,7 @@ func newEnrichAWSDynamoDB(_ context.Context, cfg config.Config) (*enrichAWSDynam
 		RoleARN:         conf.AWS.RoleARN,
 		MaxRetries:      conf.Retry.Count,
 		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
 	})
 
 	return &tf, nil
@@ -0,0 +1,238 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/aws"
+	"github.com/brexhq/substation/internal/aws/dynamodb"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/kv"
+	"github.com/brexhq/substation/message"
+)
+
+type enrichAWSDynamoDBGetItemConfig struct {
+	// TableName is the DynamoDB table that is read from.
+	TableName string `json:"table_name"`
+	// Key maps the attributes of the table's primary key to keys in the
+	// message (e.g., {"PK":"user.id"}). The partition key is required and
+	// the sort key is required if the table has one.
+	Key map[string]string `json:"key"`
+	// Attributes are the attributes of the item that are put into the
+	// message.
+	//
+	// This is optional and defaults to all attributes.
+	Attributes []string `json:"attributes"`
+	// ConsistentRead determines if strongly consistent reads are used.
+	//
+	// This is optional and defaults to false (eventually consistent reads
+	// are used).
+	ConsistentRead bool `json:"consistent_read"`
+	// CacheTTL is the amount of time that items (and items that do not
+	// exist) are stored in memory before they are read from the table
+	// again. The cache is disabled if this is "0s".
+	//
+	// This is optional and defaults to 5m.
+	CacheTTL string `json:"cache_ttl"`
+	// CacheCapacity is the maximum number of items stored in memory.
+	//
+	// This is optional and defaults to 1024.
+	CacheCapacity int `json:"cache_capacity"`
+
+	Object iconfig.Object `json:"object"`
+	AWS    iconfig.AWS    `json:"aws"`
+	Retry  iconfig.Retry  `json:"retry"`
+}
+
+func (c *enrichAWSDynamoDBGetItemConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *enrichAWSDynamoDBGetItemConfig) Validate() error {
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.TableName == "" {
+		return fmt.Errorf("table_name: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if len(c.Key) == 0 {
+		return fmt.Errorf("key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newEnrichAWSDynamoDBGetItem(_ context.Context, cfg config.Config) (*enrichAWSDynamoDBGetItem, error) {
+	conf := enrichAWSDynamoDBGetItemConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+	}
+
+	if conf.CacheTTL == "" {
+		conf.CacheTTL = "5m"
+	}
+
+	if conf.CacheCapacity == 0 {
+		conf.CacheCapacity = 1024
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+	}
+
+	ttl, err := time.ParseDuration(conf.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+	}
+
+	tf := enrichAWSDynamoDBGetItem{
+		conf: conf,
+		ttl:  ttl,
+	}
+
+	// The cache is not shared with other transforms.
+	if ttl > 0 {
+		cache, err := kv.New(config.Config{
+			Type: "memory",
+			Settings: map[string]interface{}{
+				"capacity": conf.CacheCapacity,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+		}
+
+		if err := cache.Setup(context.Background()); err != nil {
+			return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+		}
+
+		tf.cache = cache
+	}
+
+	// Setup the AWS client.
+	tf.client.Setup(aws.Config{
+		Region:          conf.AWS.Region,
+		RoleARN:         conf.AWS.RoleARN,
+		MaxRetries:      conf.Retry.Count,
+		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
+	})
+
+	return &tf, nil
+}
+
+// enrichAWSDynamoDBGetItem retrieves a single item from a DynamoDB table using
+// values from the message as the item's primary key. Items that do not exist
+// do not change the message.
+type enrichAWSDynamoDBGetItem struct {
+	conf enrichAWSDynamoDBGetItemConfig
+	ttl  time.Duration
+
+	// client and cache are safe for concurrent access.
+	client dynamodb.API
+	cache  kv.Storer
+}
+
+func (tf *enrichAWSDynamoDBGetItem) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	key := make(map[string]interface{}, len(tf.conf.Key))
+	for attr, k := range tf.conf.Key {
+		value := msg.GetValue(k)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		key[attr] = value.Value()
+	}
+
+	item, err := tf.getItem(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+	}
+
+	// No match.
+	if len(item) == 0 {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, item); err != nil {
+		return nil, fmt.Errorf("transform: enrich_aws_dynamodb_get_item: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *enrichAWSDynamoDBGetItem) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// getItem returns the item as a JSON object, or an empty slice if the
+// item does not exist.
+func (tf *enrichAWSDynamoDBGetItem) getItem(ctx context.Context, key map[string]interface{}) ([]byte, error) {
+	// Maps are marshaled in sorted order, so the same key always has
+	// the same cache key.
+	ck, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf.cache != nil {
+		v, err := tf.cache.Get(ctx, string(ck))
+		if err != nil {
+			return nil, err
+		}
+
+		if b, ok := v.([]byte); ok {
+			return b, nil
+		}
+	}
+
+	resp, err := tf.client.GetItem(ctx, tf.conf.TableName, key, tf.conf.ConsistentRead)
+	if err != nil {
+		return nil, err
+	}
+
+	b := []byte{}
+	if len(resp.Item) != 0 {
+		var item map[string]interface{}
+		if err := dynamodbattribute.UnmarshalMap(resp.Item, &item); err != nil {
+			return nil, err
+		}
+
+		if len(tf.conf.Attributes) != 0 {
+			projected := make(map[string]interface{}, len(tf.conf.Attributes))
+			for _, a := range tf.conf.Attributes {
+				if v, ok := item[a]; ok {
+					projected[a] = v
+				}
+			}
+
+			item = projected
+		}
+
+		if b, err = json.Marshal(item); err != nil {
+			return nil, err
+		}
+	}
+
+	if tf.cache != nil {
+		exp := time.Now().Add(tf.ttl).Unix()
+		if err := tf.cache.SetWithTTL(ctx, string(ck), b, exp); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,252 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/brexhq/substation/config"
+	ddb "github.com/brexhq/substation/internal/aws/dynamodb"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &enrichAWSDynamoDBGetItem{}
+
+type enrichAWSDynamoDBMockedGetItem struct {
+	dynamodbiface.DynamoDBAPI
+	Resp  dynamodb.GetItemOutput
+	calls *int
+}
+
+func (m enrichAWSDynamoDBMockedGetItem) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput, opts ...request.Option) (*dynamodb.GetItemOutput, error) {
+	if m.calls != nil {
+		*m.calls++
+	}
+
+	return &m.Resp, nil
+}
+
+var enrichAWSDynamoDBGetItemTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+	api      ddb.API
+}{
+	{
+		"success",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+				"table_name": "tab",
+				"key": map[string]interface{}{
+					"PK": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b","x":{"PK":"b","c":"d"}}`),
+		},
+		ddb.API{
+			Client: enrichAWSDynamoDBMockedGetItem{
+				Resp: dynamodb.GetItemOutput{
+					Item: map[string]*dynamodb.AttributeValue{
+						"PK": {
+							S: aws.String("b"),
+						},
+						"c": {
+							S: aws.String("d"),
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"attributes",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+				"table_name": "tab",
+				"key": map[string]interface{}{
+					"PK": "a",
+					"SK": "b",
+				},
+				"attributes": []string{"c"},
+			},
+		},
+		[]byte(`{"a":"b","b":1}`),
+		[][]byte{
+			[]byte(`{"a":"b","b":1,"x":{"c":"d"}}`),
+		},
+		ddb.API{
+			Client: enrichAWSDynamoDBMockedGetItem{
+				Resp: dynamodb.GetItemOutput{
+					Item: map[string]*dynamodb.AttributeValue{
+						"PK": {
+							S: aws.String("b"),
+						},
+						"SK": {
+							N: aws.String("1"),
+						},
+						"c": {
+							S: aws.String("d"),
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		"no match",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+				"table_name": "tab",
+				"key": map[string]interface{}{
+					"PK": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+		ddb.API{
+			Client: enrichAWSDynamoDBMockedGetItem{},
+		},
+	},
+	{
+		"missing key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+				"table_name": "tab",
+				"key": map[string]interface{}{
+					"PK": "c",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+		ddb.API{
+			Client: enrichAWSDynamoDBMockedGetItem{},
+		},
+	},
+}
+
+func TestEnrichAWSDynamoDBGetItem(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range enrichAWSDynamoDBGetItemTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newEnrichAWSDynamoDBGetItem(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tf.client = test.api
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var data [][]byte
+			for _, c := range result {
+				data = append(data, c.Data())
+			}
+
+			if !reflect.DeepEqual(data, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, data)
+			}
+		})
+	}
+}
+
+func TestEnrichAWSDynamoDBGetItemCache(t *testing.T) {
+	ctx := context.TODO()
+	tests := []struct {
+		name     string
+		ttl      string
+		expected int
+	}{
+		{"enabled", "1m", 1},
+		{"disabled", "0s", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newEnrichAWSDynamoDBGetItem(ctx, config.Config{
+				Settings: map[string]interface{}{
+					"object": map[string]interface{}{
+						"target_key": "x",
+					},
+					"table_name": "tab",
+					"key": map[string]interface{}{
+						"PK": "a",
+					},
+					"cache_ttl": test.ttl,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var calls int
+			tf.client = ddb.API{
+				Client: enrichAWSDynamoDBMockedGetItem{calls: &calls},
+			}
+
+			for i := 0; i < 3; i++ {
+				msg := message.New().SetData([]byte(`{"a":"b"}`))
+				if _, err := tf.Transform(ctx, msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if calls != test.expected {
+				t.Errorf("expected %d calls, got %d", test.expected, calls)
+			}
+		})
+	}
+}
+
+func benchmarkEnrichAWSDynamoDBGetItem(b *testing.B, tf *enrichAWSDynamoDBGetItem, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkEnrichAWSDynamoDBGetItem(b *testing.B) {
+	ctx := context.TODO()
+	for _, test := range enrichAWSDynamoDBGetItemTests {
+		b.Run(test.name,
+			func(b *testing.B) {
+				tf, err := newEnrichAWSDynamoDBGetItem(ctx, test.cfg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				tf.client = test.api
+
+				benchmarkEnrichAWSDynamoDBGetItem(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -63,6 +63,7 @@ func newEnrichAWSLambda(_ context.Context, cfg config.Config) (*enrichAWSLambda,
 		RoleARN:         conf.AWS.RoleARN,
 		MaxRetries:      conf.Retry.Count,
 		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
 	})
 
 	return &tf, nil
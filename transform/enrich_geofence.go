@@ -0,0 +1,224 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type enrichGeofencePoint struct {
+	// Latitude is the latitude of the point in decimal degrees.
+	Latitude float64 `json:"latitude"`
+	// Longitude is the longitude of the point in decimal degrees.
+	Longitude float64 `json:"longitude"`
+}
+
+type enrichGeofenceBox struct {
+	// MinLatitude is the southern edge of the box in decimal degrees.
+	MinLatitude float64 `json:"min_latitude"`
+	// MinLongitude is the western edge of the box in decimal degrees.
+	MinLongitude float64 `json:"min_longitude"`
+	// MaxLatitude is the northern edge of the box in decimal degrees.
+	MaxLatitude float64 `json:"max_latitude"`
+	// MaxLongitude is the eastern edge of the box in decimal degrees. If this is
+	// less than MinLongitude, then the box crosses the antimeridian.
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+type enrichGeofenceRegion struct {
+	// Name is put into the message when the coordinate is inside of the region.
+	Name string `json:"name"`
+	// Box is the bounding box of the region.
+	//
+	// This is optional and has no default. Either Box or Polygon must be set,
+	// but not both.
+	Box *enrichGeofenceBox `json:"box"`
+	// Polygon is the list of vertices that are the boundary of the region. The
+	// polygon is closed automatically and cannot cross the antimeridian.
+	//
+	// This is optional and has no default.
+	Polygon []enrichGeofencePoint `json:"polygon"`
+}
+
+type enrichGeofenceConfig struct {
+	// LatitudeKey retrieves the latitude of the coordinate from a JSON object.
+	LatitudeKey string `json:"latitude_key"`
+	// LongitudeKey retrieves the longitude of the coordinate from a JSON object.
+	LongitudeKey string `json:"longitude_key"`
+	// Regions are the named regions that the coordinate is compared to.
+	Regions []enrichGeofenceRegion `json:"regions"`
+
+	// Object.TargetKey is where the names of matching regions are put.
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *enrichGeofenceConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *enrichGeofenceConfig) Validate() error {
+	if c.LatitudeKey == "" {
+		return fmt.Errorf("latitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.LongitudeKey == "" {
+		return fmt.Errorf("longitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if len(c.Regions) == 0 {
+		return fmt.Errorf("regions: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, r := range c.Regions {
+		if r.Name == "" {
+			return fmt.Errorf("regions.name: %v", errors.ErrMissingRequiredOption)
+		}
+
+		if (r.Box == nil) == (r.Polygon == nil) {
+			return fmt.Errorf("regions %q: box or polygon: %v", r.Name, errors.ErrInvalidOption)
+		}
+
+		if r.Box != nil && r.Box.MinLatitude > r.Box.MaxLatitude {
+			return fmt.Errorf("regions %q: box: %v", r.Name, errors.ErrInvalidOption)
+		}
+
+		if r.Polygon != nil && len(r.Polygon) < 3 {
+			return fmt.Errorf("regions %q: polygon: %v", r.Name, errors.ErrInvalidOption)
+		}
+	}
+
+	return nil
+}
+
+func newEnrichGeofence(_ context.Context, cfg config.Config) (*enrichGeofence, error) {
+	conf := enrichGeofenceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: enrich_geofence: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: enrich_geofence: %v", err)
+	}
+
+	tf := enrichGeofence{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// enrichGeofence puts the names of all regions that contain a coordinate into
+// the message. Names are in the same order as the configured regions, and
+// the list is empty if the coordinate is not inside of any region.
+type enrichGeofence struct {
+	conf enrichGeofenceConfig
+}
+
+func (tf *enrichGeofence) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Messages without a valid coordinate are not changed.
+	lat, ok := enrichGeofenceCoordinate(msg.GetValue(tf.conf.LatitudeKey))
+	if !ok {
+		return []*message.Message{msg}, nil
+	}
+
+	lon, ok := enrichGeofenceCoordinate(msg.GetValue(tf.conf.LongitudeKey))
+	if !ok {
+		return []*message.Message{msg}, nil
+	}
+
+	p := enrichGeofencePoint{
+		Latitude:  lat,
+		Longitude: lon,
+	}
+
+	names := []string{}
+	// Invalid coordinates are never inside of a region.
+	if math.Abs(p.Latitude) <= 90 && math.Abs(p.Longitude) <= 180 {
+		for _, r := range tf.conf.Regions {
+			if r.Box != nil && enrichGeofenceInBox(*r.Box, p) {
+				names = append(names, r.Name)
+			}
+
+			if r.Polygon != nil && enrichGeofenceInPolygon(r.Polygon, p) {
+				names = append(names, r.Name)
+			}
+		}
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, names); err != nil {
+		return nil, fmt.Errorf("transform: enrich_geofence: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *enrichGeofence) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// enrichGeofenceCoordinate returns the value as a number. Only numbers and
+// strings that contain a number are valid coordinates.
+func enrichGeofenceCoordinate(v message.Value) (float64, bool) {
+	switch c := v.Value().(type) {
+	case float64:
+		return c, true
+	case string:
+		f, err := strconv.ParseFloat(c, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, false
+		}
+
+		return f, true
+	}
+
+	return 0, false
+}
+
+func enrichGeofenceInBox(box enrichGeofenceBox, p enrichGeofencePoint) bool {
+	if p.Latitude < box.MinLatitude || p.Latitude > box.MaxLatitude {
+		return false
+	}
+
+	if box.MinLongitude <= box.MaxLongitude {
+		return p.Longitude >= box.MinLongitude && p.Longitude <= box.MaxLongitude
+	}
+
+	// The box crosses the antimeridian.
+	return p.Longitude >= box.MinLongitude || p.Longitude <= box.MaxLongitude
+}
+
+// enrichGeofenceInPolygon returns true if the point is inside of the polygon.
+// This uses the ray casting algorithm, which treats longitude and latitude as
+// planar coordinates.
+func enrichGeofenceInPolygon(poly []enrichGeofencePoint, p enrichGeofencePoint) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Latitude > p.Latitude) == (b.Latitude > p.Latitude) {
+			continue
+		}
+
+		x := a.Longitude + (p.Latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if p.Longitude < x {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
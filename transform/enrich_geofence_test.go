@@ -0,0 +1,610 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &enrichGeofence{}
+
+var enrichGeofenceTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":37.77,"lon":-122.42}`),
+		[][]byte{
+			[]byte(`{"lat":37.77,"lon":-122.42,"regions":["us_west","bay_area"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":34.05,"lon":-118.24}`),
+		[][]byte{
+			[]byte(`{"lat":34.05,"lon":-118.24,"regions":["us_west"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":37.1,"lon":-122.9}`),
+		[][]byte{
+			[]byte(`{"lat":37.1,"lon":-122.9,"regions":["us_west"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":0,"lon":179.5}`),
+		[][]byte{
+			[]byte(`{"lat":0,"lon":179.5,"regions":["pacific"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":40.71,"lon":-74.01}`),
+		[][]byte{
+			[]byte(`{"lat":40.71,"lon":-74.01,"regions":[]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":100,"lon":-122.42}`),
+		[][]byte{
+			[]byte(`{"lat":100,"lon":-122.42,"regions":[]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lon":-122.42}`),
+		[][]byte{
+			[]byte(`{"lon":-122.42}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":"0.5","lon":"-0.5"}`),
+		[][]byte{
+			[]byte(`{"lat":"0.5","lon":"-0.5","regions":["gulf"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":"unknown","lon":""}`),
+		[][]byte{
+			[]byte(`{"lat":"unknown","lon":""}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"regions": []map[string]interface{}{
+					{
+						"name": "us_west",
+						"box": map[string]interface{}{
+							"min_latitude":  32.0,
+							"min_longitude": -125.0,
+							"max_latitude":  49.0,
+							"max_longitude": -114.0,
+						},
+					},
+					{
+						"name": "bay_area",
+						"polygon": []map[string]interface{}{
+							{"latitude": 38.0, "longitude": -123.0},
+							{"latitude": 38.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -121.5},
+							{"latitude": 37.0, "longitude": -122.5},
+						},
+					},
+					{
+						"name": "gulf",
+						"box": map[string]interface{}{
+							"min_latitude":  -1.0,
+							"min_longitude": -1.0,
+							"max_latitude":  1.0,
+							"max_longitude": 1.0,
+						},
+					},
+					{
+						"name": "pacific",
+						"box": map[string]interface{}{
+							"min_latitude":  -10.0,
+							"min_longitude": 170.0,
+							"max_latitude":  10.0,
+							"max_longitude": -170.0,
+						},
+					},
+				},
+				"object": map[string]interface{}{
+					"target_key": "regions",
+				},
+			},
+		},
+		[]byte(`{"lat":null,"lon":false}`),
+		[][]byte{
+			[]byte(`{"lat":null,"lon":false}`),
+		},
+	},
+}
+
+func TestEnrichGeofence(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range enrichGeofenceTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newEnrichGeofence(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkEnrichGeofence(b *testing.B, tf *enrichGeofence, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkEnrichGeofence(b *testing.B) {
+	for _, test := range enrichGeofenceTests {
+		tf, err := newEnrichGeofence(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkEnrichGeofence(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -3,11 +3,13 @@ package transform
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 
 	iconfig "github.com/brexhq/substation/internal/config"
 	"github.com/brexhq/substation/internal/errors"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 type formatBase64Config struct {
@@ -30,6 +32,84 @@ func (c *formatBase64Config) Validate() error {
 	return nil
 }
 
+// errFormatInvalidJSON is returned when a JSON transform receives data
+// that is not valid JSON.
+var errFormatInvalidJSON = fmt.Errorf("invalid JSON")
+
+type formatJSONConfig struct {
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatJSONConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatJSONConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+type formatQueryStringConfig struct {
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatQueryStringConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatQueryStringConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+type formatURLConfig struct {
+	// PlusAsSpace determines if spaces are encoded as plus signs (and plus
+	// signs are decoded as spaces). This is the encoding used by URL query
+	// strings and HTML forms.
+	//
+	// This is optional and defaults to false (spaces are encoded as %20,
+	// which is the encoding used by URL paths).
+	PlusAsSpace bool `json:"plus_as_space"`
+	// IgnoreInvalid determines if values that contain invalid escape sequences
+	// are passed through without being decoded. If false, then an error is
+	// returned. This is ignored when encoding.
+	//
+	// This is optional and defaults to false.
+	IgnoreInvalid bool `json:"ignore_invalid"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatURLConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatURLConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
 type formatGzipConfig struct{}
 
 func (c *formatGzipConfig) Decode(in interface{}) error {
@@ -63,3 +143,117 @@ func fmtFromGzip(data []byte) ([]byte, error) {
 
 	return output, nil
 }
+
+type formatMsgPackConfig struct{}
+
+func (c *formatMsgPackConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func fmtToMsgPack(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Numbers are decoded as json.Number so that integers are not
+	// encoded as floats.
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	// Sorting keys makes the output deterministic and compact integers
+	// keep the output small.
+	enc.SetSortMapKeys(true)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(fmtMsgPackNumbers(v)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func fmtFromMsgPack(data []byte) ([]byte, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetMapDecoder(fmtMsgPackMap)
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// fmtMsgPackMap decodes a map with keys of any type into a map with string
+// keys, which is required by JSON. Keys that are not strings are converted
+// using their default format (e.g., 1 is "1").
+func fmtMsgPackMap(dec *msgpack.Decoder) (interface{}, error) {
+	n, err := dec.DecodeMapLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if n == -1 {
+		return nil, nil
+	}
+
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := dec.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := dec.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+
+		// Binary keys are treated as strings, which is how strings are
+		// encoded by some older MessagePack implementations.
+		if b, ok := k.([]byte); ok {
+			k = string(b)
+		}
+
+		m[fmt.Sprint(k)] = v
+	}
+
+	return m, nil
+}
+
+// fmtMsgPackNumbers recursively converts json.Number values into
+// int64 or float64 values, preferring integers when possible.
+func fmtMsgPackNumbers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := val.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range val {
+			val[k] = fmtMsgPackNumbers(e)
+		}
+
+		return val
+	case []interface{}:
+		for i, e := range val {
+			val[i] = fmtMsgPackNumbers(e)
+		}
+
+		return val
+	default:
+		return v
+	}
+}
+
+// errFormatInvalidGeohash is returned when a geohash contains characters that
+// are not in the geohash alphabet.
+var errFormatInvalidGeohash = fmt.Errorf("invalid geohash")
+
+// fmtGeohashAlphabet is the base32 alphabet used by geohashes.
+const fmtGeohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
@@ -0,0 +1,237 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+// errFormatFromCLFInvalid is returned when the transform receives data that
+// does not match the log format.
+var errFormatFromCLFInvalid = fmt.Errorf("invalid access log")
+
+var (
+	// formatFromCLFCommon matches `host ident user [time] "request" status bytes`.
+	formatFromCLFCommon = regexp.MustCompile(`^(?P<remote_host>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<timestamp>[^\]]+)\] "(?P<request>(?:[^"\\]|\\.)*)" (?P<status>\d{3}|-) (?P<bytes>\d+|-)$`)
+	// formatFromCLFCombined matches the common format followed by
+	// `"referer" "user_agent"`.
+	formatFromCLFCombined = regexp.MustCompile(`^(?P<remote_host>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<timestamp>[^\]]+)\] "(?P<request>(?:[^"\\]|\\.)*)" (?P<status>\d{3}|-) (?P<bytes>\d+|-) "(?P<referer>(?:[^"\\]|\\.)*)" "(?P<user_agent>(?:[^"\\]|\\.)*)"`)
+)
+
+type formatFromCLFConfig struct {
+	// Format is the format of the access log.
+	//
+	// Must be one of:
+	//	- common: Common Log Format (e.g., 127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326)
+	//	- combined: Combined Log Format, which is the common format followed by the referer and user agent
+	//	- custom: the format is defined by Pattern
+	//
+	// This is optional and defaults to detecting common or combined format from
+	// each message.
+	Format string `json:"format"`
+	// Pattern is a regular expression with named groups that is used when the
+	// format is custom (e.g., `^(?P<remote_host>\S+) (?P<status>\d+)$`). Each
+	// named group is put into the output with the name of the group.
+	//
+	// This is optional and has no default.
+	Pattern string `json:"pattern"`
+	// ErrorKey is the key where an error is put if the data does not match the
+	// format. If this is set, then data that does not match the format is not
+	// changed and an error is not returned.
+	//
+	// This is optional and has no default (an error is returned).
+	ErrorKey string `json:"error_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatFromCLFConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatFromCLFConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Format != "" && !slices.Contains(
+		[]string{
+			"common",
+			"combined",
+			"custom",
+		},
+		c.Format) {
+		return fmt.Errorf("format %q: %v", c.Format, errors.ErrInvalidOption)
+	}
+
+	if c.Format == "custom" && c.Pattern == "" {
+		return fmt.Errorf("pattern: %v", errors.ErrMissingRequiredOption)
+	}
+
+	// Errors can only be put into objects.
+	if c.ErrorKey != "" && c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newFormatFromCLF(_ context.Context, cfg config.Config) (*formatFromCLF, error) {
+	conf := formatFromCLFConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+	}
+
+	tf := formatFromCLF{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	switch conf.Format {
+	case "common":
+		tf.patterns = []*regexp.Regexp{formatFromCLFCommon}
+	case "combined":
+		tf.patterns = []*regexp.Regexp{formatFromCLFCombined}
+	case "custom":
+		re, err := regexp.Compile(conf.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("transform: format_from_clf: pattern: %v", err)
+		}
+
+		tf.patterns = []*regexp.Regexp{re}
+	default:
+		tf.patterns = []*regexp.Regexp{formatFromCLFCombined, formatFromCLFCommon}
+	}
+
+	return &tf, nil
+}
+
+// formatFromCLF parses web server access logs (e.g., Apache and Nginx) into an
+// object. In common and combined format, the request is split into its method,
+// path, and protocol, the status and bytes are converted to numbers, and fields
+// that are "-" are omitted.
+type formatFromCLF struct {
+	conf     formatFromCLFConfig
+	isObject bool
+
+	patterns []*regexp.Regexp
+}
+
+func (tf *formatFromCLF) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	log, err := tf.parse(strings.TrimRight(value.String(), "\r\n"))
+	if err != nil && tf.conf.ErrorKey != "" {
+		if err := msg.SetValue(tf.conf.ErrorKey, err.Error()); err != nil {
+			return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+	}
+
+	b, err := json.Marshal(log)
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_clf: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromCLF) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *formatFromCLF) parse(s string) (map[string]interface{}, error) {
+	for _, re := range tf.patterns {
+		match := re.FindStringSubmatch(s)
+		if match == nil {
+			continue
+		}
+
+		log := make(map[string]interface{})
+		for i, name := range re.SubexpNames() {
+			if i == 0 || name == "" {
+				continue
+			}
+
+			if tf.conf.Format == "custom" {
+				log[name] = match[i]
+				continue
+			}
+
+			if match[i] == "-" || match[i] == "" {
+				continue
+			}
+
+			switch name {
+			case "status", "bytes":
+				n, err := strconv.ParseInt(match[i], 10, 64)
+				if err != nil {
+					return nil, err
+				}
+
+				log[name] = n
+			case "request":
+				log[name] = match[i]
+
+				// Requests that are not "METHOD PATH PROTOCOL" (e.g., from
+				// scanners) are only put into the request field.
+				if parts := strings.Fields(match[i]); len(parts) == 3 {
+					log["method"] = parts[0]
+					log["path"] = parts[1]
+					log["protocol"] = parts[2]
+				}
+			default:
+				log[name] = match[i]
+			}
+		}
+
+		return log, nil
+	}
+
+	return nil, errFormatFromCLFInvalid
+}
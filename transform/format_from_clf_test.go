@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromCLF{}
+
+var formatFromCLFTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data common",
+		config.Config{},
+		[]byte(`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`),
+		[][]byte{
+			[]byte(`{"bytes":2326,"method":"GET","path":"/apache_pb.gif","protocol":"HTTP/1.0","remote_host":"127.0.0.1","request":"GET /apache_pb.gif HTTP/1.0","status":200,"timestamp":"10/Oct/2000:13:55:36 -0700","user":"frank"}`),
+		},
+	},
+	{
+		"data combined",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "combined",
+			},
+		},
+		[]byte(`10.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "POST /login HTTP/1.1" 302 - "https://example.com/" "Mozilla/5.0 (X11)"`),
+		[][]byte{
+			[]byte(`{"method":"POST","path":"/login","protocol":"HTTP/1.1","referer":"https://example.com/","remote_host":"10.0.0.1","request":"POST /login HTTP/1.1","status":302,"timestamp":"10/Oct/2000:13:55:36 -0700","user_agent":"Mozilla/5.0 (X11)"}`),
+		},
+	},
+	{
+		"data custom",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format":  "custom",
+				"pattern": `^(?P<remote_host>\S+) (?P<status>\d+)$`,
+			},
+		},
+		[]byte(`10.0.0.1 404`),
+		[][]byte{
+			[]byte(`{"remote_host":"10.0.0.1","status":"404"}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"::1 - - [10/Oct/2000:13:55:36 -0700] \"\\x16\\x03\" 400 0"}`),
+		[][]byte{
+			[]byte(`{"a":"::1 - - [10/Oct/2000:13:55:36 -0700] \"\\x16\\x03\" 400 0","b":{"bytes":0,"remote_host":"::1","request":"\\x16\\x03","status":400,"timestamp":"10/Oct/2000:13:55:36 -0700"}}`),
+		},
+	},
+	{
+		"object error_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"error_key": "error",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"not a log"}`),
+		[][]byte{
+			[]byte(`{"a":"not a log","error":"invalid access log"}`),
+		},
+	},
+}
+
+func TestFormatFromCLF(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromCLFTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromCLF(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromCLF(b *testing.B, tf *formatFromCLF, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromCLF(b *testing.B) {
+	for _, test := range formatFromCLFTests {
+		tf, err := newFormatFromCLF(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromCLF(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,176 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type formatFromFixedWidthColumn struct {
+	// Name is the key where the column is put in the output object.
+	Name string `json:"name"`
+	// Start is the zero-based position where the column starts.
+	Start int `json:"start"`
+	// Length is the width of the column. If the length is 0, then the
+	// column continues to the end of the record.
+	Length int `json:"length"`
+}
+
+type formatFromFixedWidthConfig struct {
+	// Columns are the column definitions that are extracted from the record.
+	Columns []formatFromFixedWidthColumn `json:"columns"`
+	// Measurement controls how column positions are measured.
+	//
+	// Must be one of:
+	//	- byte: positions are byte offsets
+	//	- char: positions are character offsets, which is safe for multi-byte
+	//	encodings
+	//
+	// This is optional and defaults to char.
+	Measurement string `json:"measurement"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatFromFixedWidthConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatFromFixedWidthConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if len(c.Columns) == 0 {
+		return fmt.Errorf("columns: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, col := range c.Columns {
+		if col.Name == "" {
+			return fmt.Errorf("columns: name: %v", errors.ErrMissingRequiredOption)
+		}
+
+		if col.Start < 0 || col.Length < 0 {
+			return fmt.Errorf("columns %q: %v", col.Name, errors.ErrInvalidOption)
+		}
+	}
+
+	if !slices.Contains(
+		[]string{
+			"byte",
+			"char",
+		},
+		c.Measurement) {
+		return fmt.Errorf("measurement %q: %v", c.Measurement, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newFormatFromFixedWidth(_ context.Context, cfg config.Config) (*formatFromFixedWidth, error) {
+	conf := formatFromFixedWidthConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_fixed_width: %v", err)
+	}
+
+	if conf.Measurement == "" {
+		conf.Measurement = "char"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_fixed_width: %v", err)
+	}
+
+	tf := formatFromFixedWidth{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// formatFromFixedWidth converts a fixed-width record into an object. Padding
+// is trimmed from each column and columns that start after the end of the
+// record are omitted.
+type formatFromFixedWidth struct {
+	conf     formatFromFixedWidthConfig
+	isObject bool
+}
+
+func (tf *formatFromFixedWidth) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var s string
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		s = value.String()
+	} else {
+		s = string(msg.Data())
+	}
+
+	s = strings.TrimRight(s, "\r\n")
+
+	// Runes are only needed if positions are character offsets.
+	var r []rune
+	n := len(s)
+	if tf.conf.Measurement == "char" {
+		r = []rune(s)
+		n = len(r)
+	}
+
+	out := message.New()
+	for _, col := range tf.conf.Columns {
+		if col.Start >= n {
+			continue
+		}
+
+		end := n
+		if col.Length > 0 && col.Start+col.Length < n {
+			end = col.Start + col.Length
+		}
+
+		var v string
+		if r != nil {
+			v = string(r[col.Start:end])
+		} else {
+			v = s[col.Start:end]
+		}
+
+		if err := out.SetValue(col.Name, strings.TrimSpace(v)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_fixed_width: %v", err)
+		}
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out.Data())); err != nil {
+			return nil, fmt.Errorf("transform: format_from_fixed_width: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out.Data())
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromFixedWidth) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
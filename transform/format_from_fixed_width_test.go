@@ -0,0 +1,172 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromFixedWidth{}
+
+var formatFromFixedWidthTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"name": "id", "start": 0, "length": 5},
+					{"name": "name", "start": 5, "length": 10},
+					{"name": "amount", "start": 15, "length": 8},
+				},
+			},
+		},
+		[]byte(`00042JOHN DOE  00012.50`),
+		[][]byte{
+			[]byte(`{"id":"00042","name":"JOHN DOE","amount":"00012.50"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"name": "id", "start": 0, "length": 5},
+					{"name": "name", "start": 5, "length": 10},
+					{"name": "amount", "start": 15, "length": 8},
+				},
+			},
+		},
+		[]byte(`00042JOSÉ      00012.50`),
+		[][]byte{
+			[]byte(`{"id":"00042","name":"JOSÉ","amount":"00012.50"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"measurement": "byte",
+				"columns": []map[string]interface{}{
+					{"name": "id", "start": 0, "length": 5},
+					{"name": "name", "start": 5, "length": 10},
+					{"name": "amount", "start": 15, "length": 8},
+				},
+			},
+		},
+		[]byte(`00042JOSÉ     00012.50`),
+		[][]byte{
+			[]byte(`{"id":"00042","name":"JOSÉ","amount":"00012.50"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"name": "id", "start": 0, "length": 5},
+					{"name": "name", "start": 5, "length": 10},
+					{"name": "amount", "start": 15, "length": 8},
+				},
+			},
+		},
+		[]byte(`00042JANE`),
+		[][]byte{
+			[]byte(`{"id":"00042","name":"JANE"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"name": "a", "start": 0, "length": 2},
+					{"name": "b", "start": 2},
+				},
+			},
+		},
+		[]byte(`AB rest of line  `),
+		[][]byte{
+			[]byte(`{"a":"AB","b":"rest of line"}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"columns": []map[string]interface{}{
+					{"name": "id", "start": 0, "length": 5},
+					{"name": "name", "start": 5, "length": 10},
+					{"name": "amount", "start": 15, "length": 8},
+				},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"00042JOHN DOE  00012.50"}`),
+		[][]byte{
+			[]byte(`{"a":"00042JOHN DOE  00012.50","b":{"id":"00042","name":"JOHN DOE","amount":"00012.50"}}`),
+		},
+	},
+}
+
+func TestFormatFromFixedWidth(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromFixedWidthTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromFixedWidth(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromFixedWidth(b *testing.B, tf *formatFromFixedWidth, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromFixedWidth(b *testing.B) {
+	for _, test := range formatFromFixedWidthTests {
+		tf, err := newFormatFromFixedWidth(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromFixedWidth(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatFromGeohash(_ context.Context, cfg config.Config) (*formatFromGeohash, error) {
+	conf := formatBase64Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_geohash: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_geohash: %v", err)
+	}
+
+	tf := formatFromGeohash{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// formatFromGeohash decodes a geohash into the coordinate at the center of its
+// cell (e.g., {"latitude":57.64911,"longitude":10.40744}).
+type formatFromGeohash struct {
+	conf     formatBase64Config
+	isObject bool
+}
+
+func (tf *formatFromGeohash) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	lat, lon, err := fmtFromGeohash(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_geohash: %v", err)
+	}
+
+	b, err := json.Marshal(map[string]float64{
+		"latitude":  lat,
+		"longitude": lon,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_geohash: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_geohash: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromGeohash) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func fmtFromGeohash(s string) (float64, float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, 0, errFormatInvalidGeohash
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	isLon := true
+
+	for _, c := range s {
+		ch := strings.IndexRune(fmtGeohashAlphabet, c)
+		if ch == -1 {
+			return 0, 0, errFormatInvalidGeohash
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			r := &latRange
+			if isLon {
+				r = &lonRange
+			}
+
+			mid := (r[0] + r[1]) / 2
+			if ch&(1<<bit) != 0 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+
+			isLon = !isLon
+		}
+	}
+
+	return (latRange[0] + latRange[1]) / 2, (lonRange[0] + lonRange[1]) / 2, nil
+}
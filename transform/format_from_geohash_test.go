@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromGeohash{}
+
+var formatFromGeohashTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`ezs42`),
+		[][]byte{
+			[]byte(`{"latitude":42.60498046875,"longitude":-5.60302734375}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"u4pru"}`),
+		[][]byte{
+			[]byte(`{"a":"u4pru","b":{"latitude":57.63427734375,"longitude":10.39306640625}}`),
+		},
+	},
+}
+
+func TestFormatFromGeohash(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromGeohashTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromGeohash(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromGeohash(b *testing.B, tf *formatFromGeohash, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromGeohash(b *testing.B) {
+	for _, test := range formatFromGeohashTests {
+		tf, err := newFormatFromGeohash(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromGeohash(b, tf, test.test)
+			},
+		)
+	}
+}
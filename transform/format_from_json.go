@@ -0,0 +1,79 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatFromJSON(_ context.Context, cfg config.Config) (*formatFromJSON, error) {
+	conf := formatJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_json: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_json: %v", err)
+	}
+
+	tf := formatFromJSON{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatFromJSON struct {
+	conf     formatJSONConfig
+	isObject bool
+}
+
+// Transform parses a JSON string into JSON. In data mode, the data must be a
+// JSON string (e.g., "{\"a\":1}"). Invalid JSON causes an error, which can be
+// ignored by using meta_err.
+func (tf *formatFromJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		var s string
+		if err := json.Unmarshal(msg.Data(), &s); err != nil {
+			return nil, fmt.Errorf("transform: format_from_json: %v", err)
+		}
+
+		if !json.Valid([]byte(s)) {
+			return nil, fmt.Errorf("transform: format_from_json: %v", errFormatInvalidJSON)
+		}
+
+		msg.SetData([]byte(s))
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	b := value.Bytes()
+	if !json.Valid(b) {
+		return nil, fmt.Errorf("transform: format_from_json: %v", errFormatInvalidJSON)
+	}
+
+	// RawMessage is used so that all JSON types (including strings and
+	// numbers) are written as JSON.
+	if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+		return nil, fmt.Errorf("transform: format_from_json: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromJSON{}
+
+var formatFromJSONTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`"{\"a\":1}"`),
+		[][]byte{
+			[]byte(`{"a":1}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "payload",
+					"target_key": "payload",
+				},
+			},
+		},
+		[]byte(`{"payload":"{\"a\":1,\"b\":[2,3]}"}`),
+		[][]byte{
+			[]byte(`{"payload":{"a":1,"b":[2,3]}}`),
+		},
+	},
+	{
+		"object array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"[1,2]"}`),
+		[][]byte{
+			[]byte(`{"a":"[1,2]","b":[1,2]}`),
+		},
+	},
+}
+
+func TestFormatFromJSON(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromJSONTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromJSON(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromJSON(b *testing.B, tf *formatFromJSON, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromJSON(b *testing.B) {
+	for _, test := range formatFromJSONTests {
+		tf, err := newFormatFromJSON(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromJSON(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,308 @@
+package transform
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/secrets"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	// errFormatFromJWTInvalidToken is returned when the JWT transform receives
+	// data that is not a JSON Web Token.
+	errFormatFromJWTInvalidToken = fmt.Errorf("invalid token")
+	// errFormatFromJWTInvalidSignature is returned when the JWT transform is
+	// configured to verify tokens and the signature does not match.
+	errFormatFromJWTInvalidSignature = fmt.Errorf("invalid signature")
+	// errFormatFromJWTUnsupportedAlgorithm is returned when the JWT transform is
+	// configured to verify tokens and the token's algorithm is not supported.
+	errFormatFromJWTUnsupportedAlgorithm = fmt.Errorf("unsupported algorithm")
+	// errFormatFromJWTInvalidKey is returned when the verification key cannot be
+	// used with the token's algorithm.
+	errFormatFromJWTInvalidKey = fmt.Errorf("invalid key")
+	// errFormatFromJWTAlgorithmMismatch is returned when the JWT transform is
+	// configured to verify tokens and the token's algorithm does not match the
+	// configured algorithm or the type of the verification key.
+	errFormatFromJWTAlgorithmMismatch = fmt.Errorf("algorithm mismatch")
+)
+
+type formatFromJWTConfig struct {
+	// VerifyKey is the key used to verify the signature of each token. For HMAC
+	// algorithms (HS256, HS384, HS512) this is the shared secret and for RSA
+	// algorithms (RS256, RS384, RS512) this is the PEM encoded public key. This
+	// supports secrets interpolation.
+	//
+	// This is optional and has no default (signatures are not verified).
+	VerifyKey string `json:"verify_key"`
+	// Algorithm is the algorithm that tokens must be signed with. Tokens that
+	// use any other algorithm (as declared in the token header) fail
+	// verification.
+	//
+	// Must be one of:
+	//	- HS256
+	//	- HS384
+	//	- HS512
+	//	- RS256
+	//	- RS384
+	//	- RS512
+	//
+	// This is optional. If not set, then the family of algorithms is derived
+	// from the verification key: PEM encoded keys only verify RSA algorithms
+	// and all other keys only verify HMAC algorithms.
+	Algorithm string `json:"algorithm"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatFromJWTConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatFromJWTConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Algorithm != "" && !slices.Contains(
+		[]string{
+			"HS256",
+			"HS384",
+			"HS512",
+			"RS256",
+			"RS384",
+			"RS512",
+		},
+		c.Algorithm) {
+		return fmt.Errorf("algorithm %q: %v", c.Algorithm, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newFormatFromJWT(_ context.Context, cfg config.Config) (*formatFromJWT, error) {
+	conf := formatFromJWTConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_jwt: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_jwt: %v", err)
+	}
+
+	tf := formatFromJWT{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatFromJWT struct {
+	conf     formatFromJWTConfig
+	isObject bool
+}
+
+// formatFromJWTToken is the decoded token. Expired is true if the token
+// contains an expiration time (exp) that is in the past.
+type formatFromJWTToken struct {
+	Header  json.RawMessage `json:"header"`
+	Claims  json.RawMessage `json:"claims"`
+	Expired bool            `json:"expired"`
+}
+
+func (tf *formatFromJWT) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	tok, err := tf.decode(ctx, value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_jwt: %v", err)
+	}
+
+	b, err := json.Marshal(tok)
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_jwt: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_jwt: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromJWT) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *formatFromJWT) decode(ctx context.Context, token string) (formatFromJWTToken, error) {
+	var tok formatFromJWTToken
+
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return tok, errFormatFromJWTInvalidToken
+	}
+
+	header, err := formatFromJWTDecodeSegment(parts[0])
+	if err != nil {
+		return tok, err
+	}
+
+	claims, err := formatFromJWTDecodeSegment(parts[1])
+	if err != nil {
+		return tok, err
+	}
+
+	tok.Header = header
+	tok.Claims = claims
+
+	if tf.conf.VerifyKey != "" {
+		var h struct {
+			Alg string `json:"alg"`
+		}
+
+		if err := json.Unmarshal(header, &h); err != nil {
+			return tok, errFormatFromJWTInvalidToken
+		}
+
+		key, err := secrets.Interpolate(ctx, tf.conf.VerifyKey)
+		if err != nil {
+			return tok, err
+		}
+
+		// The algorithm in the header is not trusted. Otherwise, a token could
+		// be signed with HMAC using a public RSA key as the secret.
+		if tf.conf.Algorithm != "" && h.Alg != tf.conf.Algorithm {
+			return tok, fmt.Errorf("%v: %q", errFormatFromJWTAlgorithmMismatch, h.Alg)
+		}
+
+		block, _ := pem.Decode([]byte(key))
+		if (block != nil) != strings.HasPrefix(h.Alg, "RS") {
+			return tok, fmt.Errorf("%v: %q", errFormatFromJWTAlgorithmMismatch, h.Alg)
+		}
+
+		if err := formatFromJWTVerify(h.Alg, key, parts[0]+"."+parts[1], parts[2]); err != nil {
+			return tok, err
+		}
+	}
+
+	var c struct {
+		Exp *float64 `json:"exp"`
+	}
+
+	// Non-numeric expiration times are ignored.
+	_ = json.Unmarshal(claims, &c)
+	if c.Exp != nil {
+		tok.Expired = time.Now().Unix() >= int64(*c.Exp)
+	}
+
+	return tok, nil
+}
+
+// formatFromJWTDecodeSegment decodes a base64url encoded segment of a token
+// and checks that it is a JSON object.
+func formatFromJWTDecodeSegment(seg string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(seg, "="))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", errFormatFromJWTInvalidToken, err)
+	}
+
+	if !json.Valid(b) || !strings.HasPrefix(strings.TrimSpace(string(b)), "{") {
+		return nil, errFormatFromJWTInvalidToken
+	}
+
+	return b, nil
+}
+
+func formatFromJWTVerify(alg, key, signed, signature string) error {
+	sig, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(signature, "="))
+	if err != nil {
+		return errFormatFromJWTInvalidSignature
+	}
+
+	var newHash func() hash.Hash
+	var cryptoHash crypto.Hash
+	switch alg {
+	case "HS256", "RS256":
+		newHash, cryptoHash = sha256.New, crypto.SHA256
+	case "HS384", "RS384":
+		newHash, cryptoHash = sha512.New384, crypto.SHA384
+	case "HS512", "RS512":
+		newHash, cryptoHash = sha512.New, crypto.SHA512
+	default:
+		return fmt.Errorf("%v: %q", errFormatFromJWTUnsupportedAlgorithm, alg)
+	}
+
+	if strings.HasPrefix(alg, "HS") {
+		mac := hmac.New(newHash, []byte(key))
+		mac.Write([]byte(signed))
+
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return errFormatFromJWTInvalidSignature
+		}
+
+		return nil
+	}
+
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return errFormatFromJWTInvalidKey
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%v: %v", errFormatFromJWTInvalidKey, err)
+	}
+
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return errFormatFromJWTInvalidKey
+	}
+
+	h := newHash()
+	h.Write([]byte(signed))
+	if err := rsa.VerifyPKCS1v15(rsaPub, cryptoHash, h.Sum(nil), sig); err != nil {
+		return errFormatFromJWTInvalidSignature
+	}
+
+	return nil
+}
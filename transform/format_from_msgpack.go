@@ -0,0 +1,46 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatFromMsgPack(_ context.Context, cfg config.Config) (*formatFromMsgPack, error) {
+	conf := formatMsgPackConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_msgpack: %v", err)
+	}
+
+	tf := formatFromMsgPack{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+type formatFromMsgPack struct {
+	conf formatMsgPackConfig
+}
+
+func (tf *formatFromMsgPack) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := fmtFromMsgPack(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_msgpack: %v", err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromMsgPack) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
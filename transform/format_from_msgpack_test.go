@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromMsgPack{}
+
+var formatFromMsgPackTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{},
+		[]byte{0x83, 0xa1, 0x61, 0x01, 0xa1, 0x62, 0x92, 0xcb, 0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xa1, 0x63, 0xa1, 0x64, 0x81, 0xa1, 0x65, 0xfe},
+		[][]byte{
+			[]byte(`{"a":1,"b":[1.5,"c"],"d":{"e":-2}}`),
+		},
+	},
+	{
+		"data non-string keys",
+		config.Config{},
+		// {1: "a", true: "b", bin("x"): "c", "d": [{2: "e"}]}
+		[]byte{0x84, 0x01, 0xa1, 0x61, 0xc3, 0xa1, 0x62, 0xc4, 0x01, 0x78, 0xa1, 0x63, 0xa1, 0x64, 0x91, 0x81, 0x02, 0xa1, 0x65},
+		[][]byte{
+			[]byte(`{"1":"a","d":[{"2":"e"}],"true":"b","x":"c"}`),
+		},
+	},
+}
+
+func TestFormatFromMsgPack(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromMsgPackTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromMsgPack(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromMsgPack(b *testing.B, tf *formatFromMsgPack, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromMsgPack(b *testing.B) {
+	for _, test := range formatFromMsgPackTests {
+		tf, err := newFormatFromMsgPack(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromMsgPack(b, tf, test.test)
+			},
+		)
+	}
+}
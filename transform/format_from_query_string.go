@@ -0,0 +1,181 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+// errFormatFromQueryStringMixedKey is returned when a key is used as both an
+// object and a scalar or array.
+var errFormatFromQueryStringMixedKey = fmt.Errorf("key is both an object and a value")
+
+func newFormatFromQueryString(_ context.Context, cfg config.Config) (*formatFromQueryString, error) {
+	conf := formatQueryStringConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_query_string: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_query_string: %v", err)
+	}
+
+	tf := formatFromQueryString{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// formatFromQueryString parses a URL query string (e.g., a=1&b=2&b=3) into an
+// object. Keys and values are percent-decoded and repeated keys are converted
+// to arrays (e.g., {"a":"1","b":["2","3"]}). Keys that use bracket notation are
+// converted to nested objects (e.g., a[b]=1 is {"a":{"b":"1"}}) and keys that end
+// with empty brackets are always arrays (e.g., a[]=1 is {"a":["1"]}). Repeated
+// keys append to the array (e.g., a=1&a[]=2 is {"a":["1","2"]}), and a key
+// that is both an object and a value (e.g., a=1&a[b]=2) is an error.
+//
+// This also decodes HTML form bodies (application/x-www-form-urlencoded), which
+// use the same encoding as query strings.
+type formatFromQueryString struct {
+	conf     formatQueryStringConfig
+	isObject bool
+}
+
+func (tf *formatFromQueryString) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	obj, err := fmtFromQueryString(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_query_string: %v", err)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_query_string: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_query_string: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromQueryString) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func fmtFromQueryString(s string) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "?")
+	for _, pair := range strings.Split(s, "&") {
+		if pair == "" {
+			continue
+		}
+
+		k, v, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := url.QueryUnescape(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := fmtQueryStringInsert(obj, fmtQueryStringKeys(key), val); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj, nil
+}
+
+// fmtQueryStringKeys splits a key that uses bracket notation into its parts
+// (e.g., a[b][] is ["a", "b", ""]).
+func fmtQueryStringKeys(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i <= 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	keys := []string{key[:i]}
+	for _, k := range strings.Split(key[i+1:len(key)-1], "][") {
+		// Brackets that are not nested are part of the key (e.g., a[b]c]).
+		if strings.ContainsAny(k, "[]") {
+			return []string{key}
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// fmtQueryStringInsert puts the value into the object at the nested keys.
+// Scalars and arrays are combined into an array, but a key cannot be both an
+// object and a scalar or array (e.g., a=1&a[b]=2).
+func fmtQueryStringInsert(obj map[string]interface{}, keys []string, val string) error {
+	k := keys[0]
+
+	switch {
+	case len(keys) == 1 || (len(keys) == 2 && keys[1] == ""):
+		switch v := obj[k].(type) {
+		case nil:
+			if len(keys) == 2 {
+				obj[k] = []interface{}{val}
+			} else {
+				obj[k] = val
+			}
+		case []interface{}:
+			obj[k] = append(v, val)
+		case string:
+			obj[k] = []interface{}{v, val}
+		default:
+			return fmt.Errorf("%s: %v", k, errFormatFromQueryStringMixedKey)
+		}
+	default:
+		var child map[string]interface{}
+		switch v := obj[k].(type) {
+		case nil:
+			child = make(map[string]interface{})
+			obj[k] = child
+		case map[string]interface{}:
+			child = v
+		default:
+			return fmt.Errorf("%s: %v", k, errFormatFromQueryStringMixedKey)
+		}
+
+		return fmtQueryStringInsert(child, keys[1:], val)
+	}
+
+	return nil
+}
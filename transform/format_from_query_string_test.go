@@ -0,0 +1,151 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromQueryString{}
+
+var formatFromQueryStringTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`a=1&b=2&b=3`),
+		[][]byte{
+			[]byte(`{"a":"1","b":["2","3"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`?q=hello+world%21&empty=&flag`),
+		[][]byte{
+			[]byte(`{"empty":"","flag":"","q":"hello world!"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`user[name]=a&user[roles][]=x&user[roles][]=y&ids[]=1`),
+		[][]byte{
+			[]byte(`{"ids":["1"],"user":{"name":"a","roles":["x","y"]}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte("token=abc&team_domain=example&text=hello%20world&payload=%7B%22a%22%3A1%7D\r\n"),
+		[][]byte{
+			[]byte(`{"payload":"{\"a\":1}","team_domain":"example","text":"hello world","token":"abc"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`a=1&a[]=2&b[]=3&b=4`),
+		[][]byte{
+			[]byte(`{"a":["1","2"],"b":["3","4"]}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b=c&d=e%2Ff"}`),
+		[][]byte{
+			[]byte(`{"a":{"b":"c","d":"e/f"}}`),
+		},
+	},
+}
+
+func TestFormatFromQueryString(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromQueryStringTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromQueryString(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func TestFormatFromQueryStringMixedKey(t *testing.T) {
+	tests := []string{
+		`a=1&a[b]=2`,
+		`a[b]=1&a=2`,
+		`a[]=1&a[b]=2`,
+		`a[b]=1&a[]=2`,
+	}
+
+	ctx := context.TODO()
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			tf, err := newFormatFromQueryString(ctx, config.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData([]byte(test))
+			if _, err := tf.Transform(ctx, msg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromQueryString(b *testing.B, tf *formatFromQueryString, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromQueryString(b *testing.B) {
+	for _, test := range formatFromQueryStringTests {
+		tf, err := newFormatFromQueryString(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromQueryString(b, tf, test.test)
+			},
+		)
+	}
+}
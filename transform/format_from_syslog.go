@@ -0,0 +1,302 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+// errFormatFromSyslogInvalid is returned when the syslog transform receives
+// data that is not a valid syslog message.
+var errFormatFromSyslogInvalid = fmt.Errorf("invalid syslog message")
+
+var (
+	// formatFromSyslogRFC3164 matches "Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG".
+	// The tag, PID, and message are optional.
+	formatFromSyslogRFC3164 = regexp.MustCompile(`^([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (\S+)(?: ([^:\[\s]+)(?:\[([^\]]*)\])?:)? ?(.*)$`)
+	// formatFromSyslogSDParam matches a single PARAM-NAME="PARAM-VALUE" pair in
+	// RFC 5424 structured data. Values can contain escaped characters.
+	formatFromSyslogSDParam = regexp.MustCompile(`([^\s=\]"]+)="((?:[^"\\]|\\.)*)"`)
+	formatFromSyslogEscaper = strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\]`, `]`)
+)
+
+type formatFromSyslogConfig struct {
+	// Format is the syslog format of the message.
+	//
+	// Must be one of:
+	//	- rfc3164: BSD syslog (e.g., <34>Oct 11 22:14:15 host su[123]: message)
+	//	- rfc5424: IETF syslog (e.g., <34>1 2003-10-11T22:14:15.003Z host su 123 ID47 - message)
+	//
+	// This is optional and defaults to detecting the format from each message.
+	Format string `json:"format"`
+	// ErrorKey is the key where an error is put if the data is not a valid
+	// syslog message. If this is set, then data that is not valid is not
+	// changed and an error is not returned.
+	//
+	// This is optional and has no default (an error is returned).
+	ErrorKey string `json:"error_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatFromSyslogConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatFromSyslogConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Format != "" && !slices.Contains(
+		[]string{
+			"rfc3164",
+			"rfc5424",
+		},
+		c.Format) {
+		return fmt.Errorf("format %q: %v", c.Format, errors.ErrInvalidOption)
+	}
+
+	// Errors can only be put into objects.
+	if c.ErrorKey != "" && c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newFormatFromSyslog(_ context.Context, cfg config.Config) (*formatFromSyslog, error) {
+	conf := formatFromSyslogConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+	}
+
+	tf := formatFromSyslog{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatFromSyslog struct {
+	conf     formatFromSyslogConfig
+	isObject bool
+}
+
+// formatFromSyslogMessage is the parsed syslog message. Fields that are not
+// present in the message (or are the NILVALUE "-" in RFC 5424) are omitted.
+type formatFromSyslogMessage struct {
+	Priority       int                          `json:"priority"`
+	Facility       int                          `json:"facility"`
+	Severity       int                          `json:"severity"`
+	Version        int                          `json:"version,omitempty"`
+	Timestamp      string                       `json:"timestamp,omitempty"`
+	Hostname       string                       `json:"hostname,omitempty"`
+	AppName        string                       `json:"app_name,omitempty"`
+	ProcID         string                       `json:"proc_id,omitempty"`
+	MsgID          string                       `json:"msg_id,omitempty"`
+	StructuredData map[string]map[string]string `json:"structured_data,omitempty"`
+	Message        string                       `json:"message,omitempty"`
+}
+
+func (tf *formatFromSyslog) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	sys, err := tf.parse(strings.TrimRight(value.String(), "\r\n"))
+	if err != nil && tf.conf.ErrorKey != "" {
+		if err := msg.SetValue(tf.conf.ErrorKey, err.Error()); err != nil {
+			return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+	}
+
+	b, err := json.Marshal(sys)
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: format_from_syslog: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromSyslog) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *formatFromSyslog) parse(s string) (formatFromSyslogMessage, error) {
+	var sys formatFromSyslogMessage
+
+	// Both formats start with the priority (e.g., <34>).
+	end := strings.IndexByte(s, '>')
+	if !strings.HasPrefix(s, "<") || end < 2 || end > 4 {
+		return sys, errFormatFromSyslogInvalid
+	}
+
+	pri, err := strconv.Atoi(s[1:end])
+	if err != nil || pri > 191 {
+		return sys, errFormatFromSyslogInvalid
+	}
+
+	sys.Priority = pri
+	sys.Facility = pri / 8
+	sys.Severity = pri % 8
+	s = s[end+1:]
+
+	format := tf.conf.Format
+	if format == "" {
+		// RFC 5424 messages always have a version (currently "1") after
+		// the priority, which is never a valid RFC 3164 timestamp.
+		format = "rfc3164"
+		if len(s) > 1 && s[0] >= '1' && s[0] <= '9' {
+			format = "rfc5424"
+		}
+	}
+
+	if format == "rfc5424" {
+		err = formatFromSyslogParseRFC5424(&sys, s)
+	} else {
+		err = formatFromSyslogParseRFC3164(&sys, s)
+	}
+
+	return sys, err
+}
+
+func formatFromSyslogParseRFC3164(sys *formatFromSyslogMessage, s string) error {
+	m := formatFromSyslogRFC3164.FindStringSubmatch(s)
+	if m == nil {
+		return errFormatFromSyslogInvalid
+	}
+
+	sys.Timestamp = m[1]
+	sys.Hostname = m[2]
+	sys.AppName = m[3]
+	sys.ProcID = m[4]
+	sys.Message = m[5]
+
+	return nil
+}
+
+func formatFromSyslogParseRFC5424(sys *formatFromSyslogMessage, s string) error {
+	// VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+	fields := strings.SplitN(s, " ", 7)
+	if len(fields) != 7 {
+		return errFormatFromSyslogInvalid
+	}
+
+	version, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return errFormatFromSyslogInvalid
+	}
+
+	sys.Version = version
+	sys.Timestamp = formatFromSyslogNilValue(fields[1])
+	sys.Hostname = formatFromSyslogNilValue(fields[2])
+	sys.AppName = formatFromSyslogNilValue(fields[3])
+	sys.ProcID = formatFromSyslogNilValue(fields[4])
+	sys.MsgID = formatFromSyslogNilValue(fields[5])
+
+	rest := fields[6]
+	if strings.HasPrefix(rest, "-") {
+		sys.Message = strings.TrimPrefix(strings.TrimPrefix(rest, "-"), " ")
+		return nil
+	}
+
+	// Structured data is one or more elements (e.g., [id a="b"][id2 c="d"]).
+	sys.StructuredData = make(map[string]map[string]string)
+	for strings.HasPrefix(rest, "[") {
+		end := formatFromSyslogElementEnd(rest)
+		if end < 0 {
+			return errFormatFromSyslogInvalid
+		}
+
+		elem := rest[1:end]
+		id, params, _ := strings.Cut(elem, " ")
+
+		p := make(map[string]string)
+		for _, m := range formatFromSyslogSDParam.FindAllStringSubmatch(params, -1) {
+			p[m[1]] = formatFromSyslogEscaper.Replace(m[2])
+		}
+
+		sys.StructuredData[id] = p
+		rest = rest[end+1:]
+	}
+
+	// The message may start with a UTF-8 byte order mark.
+	sys.Message = strings.TrimPrefix(strings.TrimPrefix(rest, " "), "\ufeff")
+
+	return nil
+}
+
+// formatFromSyslogElementEnd returns the index of the closing bracket of the
+// structured data element at the start of the string, which skips escaped
+// characters in parameter values.
+func formatFromSyslogElementEnd(s string) int {
+	var quoted bool
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			quoted = !quoted
+		case ']':
+			if !quoted {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func formatFromSyslogNilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+
+	return s
+}
@@ -0,0 +1,195 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromSyslog{}
+
+var formatFromSyslogTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8`),
+		[][]byte{
+			[]byte(`{"priority":34,"facility":4,"severity":2,"timestamp":"Oct 11 22:14:15","hostname":"mymachine","app_name":"su","proc_id":"123","message":"'su root' failed for lonvick on /dev/pts/8"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`<13>Feb  5 17:32:18 10.0.0.99 Use the BFG!`),
+		[][]byte{
+			[]byte(`{"priority":13,"facility":1,"severity":5,"timestamp":"Feb  5 17:32:18","hostname":"10.0.0.99","message":"Use the BFG!"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - BOM'su root' failed`),
+		[][]byte{
+			[]byte(`{"priority":34,"facility":4,"severity":2,"version":1,"timestamp":"2003-10-11T22:14:15.003Z","hostname":"mymachine.example.com","app_name":"su","msg_id":"ID47","message":"BOM'su root' failed"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "rfc5424",
+			},
+		},
+		[]byte(`<165>1 2003-10-11T22:14:15.003Z host evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication"][examplePriority@32473 class="high"] An application event`),
+		[][]byte{
+			[]byte(`{"priority":165,"facility":20,"severity":5,"version":1,"timestamp":"2003-10-11T22:14:15.003Z","hostname":"host","app_name":"evntslog","msg_id":"ID47","structured_data":{"examplePriority@32473":{"class":"high"},"exampleSDID@32473":{"eventSource":"App\"lication","iut":"3"}},"message":"An application event"}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"<14>Oct 11 22:14:15 host app: hello"}`),
+		[][]byte{
+			[]byte(`{"a":{"priority":14,"facility":1,"severity":6,"timestamp":"Oct 11 22:14:15","hostname":"host","app_name":"app","message":"hello"}}`),
+		},
+	},
+	{
+		"object error_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"error_key": "error",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"not a syslog message"}`),
+		[][]byte{
+			[]byte(`{"a":"not a syslog message","error":"invalid syslog message"}`),
+		},
+	},
+	{
+		"object error_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format":    "rfc5424",
+				"error_key": "error",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"<14>Oct 11 22:14:15 host app: hello"}`),
+		[][]byte{
+			[]byte(`{"a":"<14>Oct 11 22:14:15 host app: hello","error":"invalid syslog message"}`),
+		},
+	},
+	{
+		"object error_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"error_key": "error",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"<14>Oct 11 22:14:15 host app: hello"}`),
+		[][]byte{
+			[]byte(`{"a":"<14>Oct 11 22:14:15 host app: hello","b":{"priority":14,"facility":1,"severity":6,"timestamp":"Oct 11 22:14:15","hostname":"host","app_name":"app","message":"hello"}}`),
+		},
+	},
+}
+
+func TestFormatFromSyslogErrors(t *testing.T) {
+	ctx := context.TODO()
+
+	// Errors are returned if error_key is not set.
+	tf, err := newFormatFromSyslog(ctx, config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte("not a syslog message"))); err == nil {
+		t.Error("expected error")
+	}
+
+	// Errors can only be put into objects.
+	if _, err := newFormatFromSyslog(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"error_key": "error",
+		},
+	}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFormatFromSyslog(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromSyslogTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromSyslog(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromSyslog(b *testing.B, tf *formatFromSyslog, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromSyslog(b *testing.B) {
+	for _, test := range formatFromSyslogTests {
+		tf, err := newFormatFromSyslog(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromSyslog(b, tf, test.test)
+			},
+		)
+	}
+}
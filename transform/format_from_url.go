@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatFromURL(_ context.Context, cfg config.Config) (*formatFromURL, error) {
+	conf := formatURLConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_from_url: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_from_url: %v", err)
+	}
+
+	tf := formatFromURL{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatFromURL struct {
+	conf     formatURLConfig
+	isObject bool
+}
+
+func (tf *formatFromURL) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		s, err := tf.decode(string(msg.Data()))
+		if err != nil {
+			return nil, fmt.Errorf("transform: format_from_url: %v", err)
+		}
+
+		msg.SetData([]byte(s))
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s, err := tf.decode(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_from_url: %v", err)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+		return nil, fmt.Errorf("transform: format_from_url: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatFromURL) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *formatFromURL) decode(s string) (string, error) {
+	var decoded string
+	var err error
+	if tf.conf.PlusAsSpace {
+		decoded, err = url.QueryUnescape(s)
+	} else {
+		decoded, err = url.PathUnescape(s)
+	}
+
+	if err != nil && tf.conf.IgnoreInvalid {
+		return s, nil
+	}
+
+	return decoded, err
+}
@@ -0,0 +1,133 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatFromURL{}
+
+var formatFromURLTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`a%20b+c%2Fd`),
+		[][]byte{
+			[]byte(`a b+c/d`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"plus_as_space": true,
+			},
+		},
+		[]byte(`a%20b+c%2Fd`),
+		[][]byte{
+			[]byte(`a b c/d`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"ignore_invalid": true,
+			},
+		},
+		[]byte(`100%`),
+		[][]byte{
+			[]byte(`100%`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+				"plus_as_space": true,
+			},
+		},
+		[]byte(`{"a":"q=hello+world%21"}`),
+		[][]byte{
+			[]byte(`{"a":"q=hello world!"}`),
+		},
+	},
+}
+
+func TestFormatFromURL(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatFromURLTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatFromURL(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatFromURL(b *testing.B, tf *formatFromURL, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatFromURL(b *testing.B) {
+	for _, test := range formatFromURLTests {
+		tf, err := newFormatFromURL(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatFromURL(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestFormatFromURLInvalid(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newFormatFromURL(ctx, config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`100%`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
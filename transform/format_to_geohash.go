@@ -0,0 +1,146 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type formatToGeohashConfig struct {
+	// LatitudeKey retrieves the latitude of the coordinate from a JSON object.
+	LatitudeKey string `json:"latitude_key"`
+	// LongitudeKey retrieves the longitude of the coordinate from a JSON object.
+	LongitudeKey string `json:"longitude_key"`
+	// Precision is the number of characters in the geohash, which must be
+	// between 1 and 12. Each character reduces the size of the cell (e.g., 5 is
+	// about 5 km and 9 is about 5 m).
+	//
+	// This is optional and defaults to 12.
+	Precision int `json:"precision"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *formatToGeohashConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *formatToGeohashConfig) Validate() error {
+	if c.LatitudeKey == "" {
+		return fmt.Errorf("latitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.LongitudeKey == "" {
+		return fmt.Errorf("longitude_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Precision < 1 || c.Precision > 12 {
+		return fmt.Errorf("precision %d: %v", c.Precision, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newFormatToGeohash(_ context.Context, cfg config.Config) (*formatToGeohash, error) {
+	conf := formatToGeohashConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_to_geohash: %v", err)
+	}
+
+	if conf.Precision == 0 {
+		conf.Precision = 12
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_to_geohash: %v", err)
+	}
+
+	tf := formatToGeohash{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// formatToGeohash encodes a coordinate as a geohash, which can be used to
+// group nearby coordinates. Coordinates that are outside of the valid range
+// are not encoded.
+type formatToGeohash struct {
+	conf formatToGeohashConfig
+}
+
+func (tf *formatToGeohash) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	lat := msg.GetValue(tf.conf.LatitudeKey)
+	lon := msg.GetValue(tf.conf.LongitudeKey)
+	if !lat.Exists() || !lon.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	la, lo := lat.Float(), lon.Float()
+	if la < -90 || la > 90 || lo < -180 || lo > 180 {
+		return []*message.Message{msg}, nil
+	}
+
+	hash := fmtToGeohash(la, lo, tf.conf.Precision)
+	if err := msg.SetValue(tf.conf.Object.TargetKey, hash); err != nil {
+		return nil, fmt.Errorf("transform: format_to_geohash: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatToGeohash) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// fmtToGeohash interleaves the bits of the longitude and latitude, starting
+// with the longitude, and encodes every 5 bits as a character.
+func fmtToGeohash(lat, lon float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+
+	var sb strings.Builder
+	var ch, bit int
+	isLon := true
+
+	for sb.Len() < precision {
+		r, v := &latRange, lat
+		if isLon {
+			r, v = &lonRange, lon
+		}
+
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+
+		isLon = !isLon
+		bit++
+
+		if bit == 5 {
+			sb.WriteByte(fmtGeohashAlphabet[ch])
+			ch, bit = 0, 0
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,119 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatToGeohash{}
+
+var formatToGeohashTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"object": map[string]interface{}{
+					"target_key": "geohash",
+				},
+			},
+		},
+		[]byte(`{"lat":57.64911,"lon":10.40744}`),
+		[][]byte{
+			[]byte(`{"lat":57.64911,"lon":10.40744,"geohash":"u4pruydqqvj8"}`),
+		},
+	},
+	{
+		"object precision",
+		config.Config{
+			Settings: map[string]interface{}{
+				"precision":     5,
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"object": map[string]interface{}{
+					"target_key": "geohash",
+				},
+			},
+		},
+		[]byte(`{"lat":-25.382708,"lon":-49.265506}`),
+		[][]byte{
+			[]byte(`{"lat":-25.382708,"lon":-49.265506,"geohash":"6gkzw"}`),
+		},
+	},
+	{
+		"object out of range",
+		config.Config{
+			Settings: map[string]interface{}{
+				"latitude_key":  "lat",
+				"longitude_key": "lon",
+				"object": map[string]interface{}{
+					"target_key": "geohash",
+				},
+			},
+		},
+		[]byte(`{"lat":91,"lon":0}`),
+		[][]byte{
+			[]byte(`{"lat":91,"lon":0}`),
+		},
+	},
+}
+
+func TestFormatToGeohash(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatToGeohashTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatToGeohash(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatToGeohash(b *testing.B, tf *formatToGeohash, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatToGeohash(b *testing.B) {
+	for _, test := range formatToGeohashTests {
+		tf, err := newFormatToGeohash(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatToGeohash(b, tf, test.test)
+			},
+		)
+	}
+}
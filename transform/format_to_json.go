@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatToJSON(_ context.Context, cfg config.Config) (*formatToJSON, error) {
+	conf := formatJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_to_json: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_to_json: %v", err)
+	}
+
+	tf := formatToJSON{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatToJSON struct {
+	conf     formatJSONConfig
+	isObject bool
+}
+
+// Transform converts JSON into a JSON string (e.g., {"a":1} becomes
+// "{\"a\":1}").
+func (tf *formatToJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		b, err := json.Marshal(string(msg.Data()))
+		if err != nil {
+			return nil, fmt.Errorf("transform: format_to_json: %v", err)
+		}
+
+		msg.SetData(b)
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := json.Marshal(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_to_json: %v", err)
+	}
+
+	// RawMessage is used so that the encoded string is not written as JSON.
+	if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+		return nil, fmt.Errorf("transform: format_to_json: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatToJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatToJSON{}
+
+var formatToJSONTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"a":1}`),
+		[][]byte{
+			[]byte(`"{\"a\":1}"`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "payload",
+					"target_key": "payload",
+				},
+			},
+		},
+		[]byte(`{"payload":{"a":1}}`),
+		[][]byte{
+			[]byte(`{"payload":"{\"a\":1}"}`),
+		},
+	},
+	{
+		"object string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"c"}`),
+		[][]byte{
+			[]byte(`{"a":"c","b":"c"}`),
+		},
+	},
+}
+
+func TestFormatToJSON(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatToJSONTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatToJSON(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatToJSON(b *testing.B, tf *formatToJSON, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatToJSON(b *testing.B) {
+	for _, test := range formatToJSONTests {
+		tf, err := newFormatToJSON(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatToJSON(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,46 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatToMsgPack(_ context.Context, cfg config.Config) (*formatToMsgPack, error) {
+	conf := formatMsgPackConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_to_msgpack: %v", err)
+	}
+
+	tf := formatToMsgPack{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+type formatToMsgPack struct {
+	conf formatMsgPackConfig
+}
+
+func (tf *formatToMsgPack) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := fmtToMsgPack(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform: format_to_msgpack: %v", err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatToMsgPack) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
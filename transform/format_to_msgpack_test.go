@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatToMsgPack{}
+
+var formatToMsgPackTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"a":1,"b":[1.5,"c"],"d":{"e":-2}}`),
+		[][]byte{
+			{0x83, 0xa1, 0x61, 0x01, 0xa1, 0x62, 0x92, 0xcb, 0x3f, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xa1, 0x63, 0xa1, 0x64, 0x81, 0xa1, 0x65, 0xfe},
+		},
+	},
+}
+
+func TestFormatToMsgPack(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatToMsgPackTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatToMsgPack(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatToMsgPack(b *testing.B, tf *formatToMsgPack, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatToMsgPack(b *testing.B) {
+	for _, test := range formatToMsgPackTests {
+		tf, err := newFormatToMsgPack(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatToMsgPack(b, tf, test.test)
+			},
+		)
+	}
+}
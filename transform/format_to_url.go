@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newFormatToURL(_ context.Context, cfg config.Config) (*formatToURL, error) {
+	conf := formatURLConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: format_to_url: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: format_to_url: %v", err)
+	}
+
+	tf := formatToURL{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type formatToURL struct {
+	conf     formatURLConfig
+	isObject bool
+}
+
+func (tf *formatToURL) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		s := tf.encode(string(msg.Data()))
+		msg.SetData([]byte(s))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s := tf.encode(value.String())
+	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+		return nil, fmt.Errorf("transform: format_to_url: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *formatToURL) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *formatToURL) encode(s string) string {
+	encoded := url.QueryEscape(s)
+	if tf.conf.PlusAsSpace {
+		return encoded
+	}
+
+	// Plus signs in the input are already escaped, so any remaining
+	// plus signs are spaces.
+	return strings.ReplaceAll(encoded, "+", "%20")
+}
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &formatToURL{}
+
+var formatToURLTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`a b+c/d`),
+		[][]byte{
+			[]byte(`a%20b%2Bc%2Fd`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"plus_as_space": true,
+			},
+		},
+		[]byte(`a b+c/d`),
+		[][]byte{
+			[]byte(`a+b%2Bc%2Fd`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"hello world!"}`),
+		[][]byte{
+			[]byte(`{"a":"hello%20world%21"}`),
+		},
+	},
+}
+
+func TestFormatToURL(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range formatToURLTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newFormatToURL(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkFormatToURL(b *testing.B, tf *formatToURL, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkFormatToURL(b *testing.B) {
+	for _, test := range formatToURLTests {
+		tf, err := newFormatToURL(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkFormatToURL(b, tf, test.test)
+			},
+		)
+	}
+}
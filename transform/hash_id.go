@@ -0,0 +1,204 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type hashIDConfig struct {
+	// Keys are the keys of the values that are used to create the ID. Keys that
+	// do not exist are not used, so an ID is the same whether a key is missing
+	// or was never selected.
+	//
+	// This is optional and defaults to using the entire object.
+	Keys []string `json:"keys"`
+	// Encoding is the encoding of the ID.
+	//
+	// Must be one of:
+	//	- hex
+	//	- base64
+	//	- base64url: URL-safe base64 without padding
+	//
+	// This is optional and defaults to hex.
+	Encoding string `json:"encoding"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *hashIDConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *hashIDConfig) Validate() error {
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"hex",
+			"base64",
+			"base64url",
+		},
+		c.Encoding) {
+		return fmt.Errorf("encoding %q: %v", c.Encoding, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newHashID(_ context.Context, cfg config.Config) (*hashID, error) {
+	conf := hashIDConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: hash_id: %v", err)
+	}
+
+	if conf.Encoding == "" {
+		conf.Encoding = "hex"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: hash_id: %v", err)
+	}
+
+	tf := hashID{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// hashID creates a deterministic ID from the SHA-256 hash of values in an
+// object. Values are canonicalized before they are hashed, so the ID does not
+// change if the order of keys or the formatting of the object changes (e.g.,
+// {"a":1,"b":2} and { "b": 2.0, "a": 1 } have the same ID).
+type hashID struct {
+	conf hashIDConfig
+}
+
+func (tf *hashID) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var obj interface{}
+	if len(tf.conf.Keys) == 0 {
+		v, err := hashIDDecode(msg.Data())
+		if err != nil {
+			return nil, fmt.Errorf("transform: hash_id: %v", err)
+		}
+
+		obj = v
+	} else {
+		m := make(map[string]interface{})
+		for _, key := range tf.conf.Keys {
+			value := msg.GetValue(key)
+			if !value.Exists() {
+				continue
+			}
+
+			// Strings and nulls are used as-is so that they are not confused
+			// with other types (e.g., "123" and 123 have different IDs).
+			switch v := value.Value().(type) {
+			case string, nil:
+				m[key] = v
+			default:
+				v, err := hashIDDecode(value.Bytes())
+				if err != nil {
+					return nil, fmt.Errorf("transform: hash_id: %v", err)
+				}
+
+				m[key] = v
+			}
+		}
+
+		obj = m
+	}
+
+	// Maps are marshaled with sorted keys, which canonicalizes the object.
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("transform: hash_id: %v", err)
+	}
+
+	sum := sha256.Sum256(b)
+
+	var id string
+	switch tf.conf.Encoding {
+	case "hex":
+		id = hex.EncodeToString(sum[:])
+	case "base64":
+		id = base64.StdEncoding.EncodeToString(sum[:])
+	case "base64url":
+		id = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, id); err != nil {
+		return nil, fmt.Errorf("transform: hash_id: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *hashID) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// hashIDDecode decodes JSON into values that have one canonical encoding.
+// Numbers are decoded as integers when possible to avoid losing precision and
+// as floats otherwise (e.g., 1.0 and 1e0 are both 1).
+func hashIDDecode(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return hashIDCanonical(v), nil
+}
+
+func hashIDCanonical(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return i
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+
+		if f == float64(int64(f)) {
+			return int64(f)
+		}
+
+		return f
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = hashIDCanonical(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = hashIDCanonical(val)
+		}
+	}
+
+	return v
+}
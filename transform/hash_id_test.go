@@ -0,0 +1,174 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &hashID{}
+
+var hashIDTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"a":1,"b":{"c":"d","e":[1,2]}}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":{"c":"d","e":[1,2]},"id":"32e5ec4ea260f6563569b33db5c3c114183705bac130822d5a2ae133ff84707d"}`),
+		},
+	},
+	{
+		"object reordered",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{ "b": { "e": [1.0, 2], "c": "d" }, "a": 1e0 }`),
+		[][]byte{
+			[]byte(`{ "b": { "e": [1.0, 2], "c": "d" }, "a": 1e0 ,"id":"32e5ec4ea260f6563569b33db5c3c114183705bac130822d5a2ae133ff84707d"}`),
+		},
+	},
+	{
+		"object keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":     []string{"b", "a"},
+				"encoding": "base64url",
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"a":1,"b":2,"c":3}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":2,"c":3,"id":"QyWM_3g_5wNtikMDP4MK38YOwDc4JHNUisdCuIgpJ3c"}`),
+		},
+	},
+	{
+		"keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"user"},
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"user":"alice"}`),
+		[][]byte{
+			[]byte(`{"user":"alice","id":"a5cd97f8496e61268797de605913bd8a29ac3af68ec6af1bea67fdb50c2c0ebf"}`),
+		},
+	},
+	{
+		"keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"user"},
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"user":"123"}`),
+		[][]byte{
+			[]byte(`{"user":"123","id":"b0381fb90931199dc2624d0055ddedcfccc32c20c10e496e93b0d3bce5b6c7ce"}`),
+		},
+	},
+	{
+		"keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"user"},
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"user":123}`),
+		[][]byte{
+			[]byte(`{"user":123,"id":"7878463bc9ac0cdb2982b766f01a0d8f99bb7df69fd3cfc09838acb1fc070abd"}`),
+		},
+	},
+	{
+		"keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"user", "a"},
+				"object": map[string]interface{}{
+					"target_key": "id",
+				},
+			},
+		},
+		[]byte(`{"a":null,"user":"alice"}`),
+		[][]byte{
+			[]byte(`{"a":null,"user":"alice","id":"ef46115656931893c7fbbdc115f98c693e5f075cd2cdebfe6396859083c3a58a"}`),
+		},
+	},
+}
+
+func TestHashID(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range hashIDTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newHashID(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkHashID(b *testing.B, tf *hashID, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkHashID(b *testing.B) {
+	for _, test := range hashIDTests {
+		tf, err := newHashID(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkHashID(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type metaConcurrencyConfig struct {
+	// Transform that is applied with limited concurrency.
+	Transform config.Config `json:"transform"`
+	// Limit is the maximum number of messages that the transform is applied to
+	// at the same time.
+	Limit int `json:"limit"`
+}
+
+func (c *metaConcurrencyConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *metaConcurrencyConfig) Validate() error {
+	if c.Transform.Type == "" {
+		return fmt.Errorf("transform: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Limit <= 0 {
+		return fmt.Errorf("limit: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newMetaConcurrency(ctx context.Context, cfg config.Config) (*metaConcurrency, error) {
+	conf := metaConcurrencyConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: meta_concurrency: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: meta_concurrency: %v", err)
+	}
+
+	tf, err := New(ctx, conf.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("transform: meta_concurrency: %v", err)
+	}
+
+	meta := metaConcurrency{
+		conf: conf,
+		tf:   tf,
+		sem:  make(chan struct{}, conf.Limit),
+	}
+
+	return &meta, nil
+}
+
+// metaConcurrency limits the number of goroutines that can apply a transform at
+// the same time, independent of the concurrency of the application. This can
+// be used to protect external services (e.g., DNS resolvers or APIs) that are
+// rate limited while other transforms run with full concurrency. Goroutines
+// that exceed the limit block until the transform is available or the context
+// is cancelled.
+type metaConcurrency struct {
+	conf metaConcurrencyConfig
+
+	tf  Transformer
+	sem chan struct{}
+}
+
+func (tf *metaConcurrency) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	select {
+	case tf.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("transform: meta_concurrency: %v", ctx.Err())
+	}
+	defer func() { <-tf.sem }()
+
+	msgs, err := tf.tf.Transform(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("transform: meta_concurrency: %v", err)
+	}
+
+	return msgs, nil
+}
+
+func (tf *metaConcurrency) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &metaConcurrency{}
+
+var metaConcurrencyTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object_copy",
+		config.Config{
+			Settings: map[string]interface{}{
+				"limit": 1,
+				"transform": config.Config{
+					Settings: map[string]interface{}{
+						"object": map[string]interface{}{
+							"source_key": "a",
+							"target_key": "c",
+						},
+					},
+					Type: "object_copy",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b","c":"b"}`),
+		},
+	},
+}
+
+func TestMetaConcurrency(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range metaConcurrencyTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newMetaConcurrency(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var data [][]byte
+			for _, c := range result {
+				data = append(data, c.Data())
+			}
+
+			if !reflect.DeepEqual(data, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, data)
+			}
+		})
+	}
+}
+
+type metaConcurrencyCounter struct {
+	active, max int32
+}
+
+func (c *metaConcurrencyCounter) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	n := atomic.AddInt32(&c.active, 1)
+	defer atomic.AddInt32(&c.active, -1)
+
+	for {
+		m := atomic.LoadInt32(&c.max)
+		if n <= m || atomic.CompareAndSwapInt32(&c.max, m, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	return []*message.Message{msg}, nil
+}
+
+func TestMetaConcurrencyLimit(t *testing.T) {
+	ctx := context.TODO()
+	counter := &metaConcurrencyCounter{}
+	tf := &metaConcurrency{
+		tf:  counter,
+		sem: make(chan struct{}, 2),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tf.Transform(ctx, message.New()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if counter.max > 2 {
+		t.Errorf("expected at most 2 concurrent transforms, got %d", counter.max)
+	}
+}
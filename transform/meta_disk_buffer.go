@@ -0,0 +1,484 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+const (
+	// metaDiskBufferActive is the file that messages are written to.
+	metaDiskBufferActive = "buffer.active"
+	// metaDiskBufferSegment is the extension of files that are ready to be
+	// read. Segments are named by creation time, so they sort in order.
+	metaDiskBufferSegment = ".segment"
+)
+
+type metaDiskBufferConfig struct {
+	// Transform is the transform (usually a send transform) that receives
+	// messages from the buffer.
+	Transform config.Config `json:"transform"`
+	// Directory is the local directory where messages are written when the
+	// memory buffer is full. Messages in the directory are sent when the
+	// transform is created, so messages are not lost if the application
+	// restarts.
+	Directory string `json:"directory"`
+	// Size is the number of messages that are buffered in memory before they
+	// are written to disk.
+	//
+	// This is optional and defaults to 1000.
+	Size int `json:"size"`
+}
+
+func (c *metaDiskBufferConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *metaDiskBufferConfig) Validate() error {
+	if c.Transform.Type == "" {
+		return fmt.Errorf("transform: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Directory == "" {
+		return fmt.Errorf("directory: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Size < 0 {
+		return fmt.Errorf("size: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newMetaDiskBuffer(ctx context.Context, cfg config.Config) (*metaDiskBuffer, error) {
+	conf := metaDiskBufferConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	if conf.Size == 0 {
+		conf.Size = 1000
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	tf, err := New(ctx, conf.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	meta := metaDiskBuffer{
+		conf: conf,
+		tf:   tf,
+	}
+
+	if err := meta.setup(); err != nil {
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	return &meta, nil
+}
+
+// metaDiskBufferRecord is a message that is stored on disk.
+type metaDiskBufferRecord struct {
+	Data     []byte `json:"data"`
+	Metadata []byte `json:"metadata,omitempty"`
+}
+
+// metaDiskBuffer decouples a slow transform from the rest of the pipeline.
+// Messages are returned immediately and sent to the transform by a background
+// goroutine. When the memory buffer is full, messages are written to disk
+// instead of blocking. Messages are not guaranteed to be sent in order.
+// Messages that the transform fails to send are written to disk and retried.
+//
+// Control messages block until all buffered messages (including messages on
+// disk) are sent and are then passed to the transform, so buffers are always
+// flushed when the application shuts down cleanly. Errors from the transform
+// are returned by the next call to Transform.
+type metaDiskBuffer struct {
+	conf metaDiskBufferConfig
+	tf   Transformer
+
+	ch   chan *message.Message
+	wake chan struct{}
+
+	// mu protects the fields below it.
+	mu      sync.Mutex
+	cond    *sync.Cond
+	file    *os.File
+	pending int
+	err     error
+}
+
+// setup recovers messages from a previous run and starts sending messages to
+// the transform.
+func (tf *metaDiskBuffer) setup() error {
+	tf.ch = make(chan *message.Message, tf.conf.Size)
+	tf.wake = make(chan struct{}, 1)
+	tf.cond = sync.NewCond(&tf.mu)
+
+	if err := os.MkdirAll(tf.conf.Directory, 0o700); err != nil {
+		return err
+	}
+
+	if err := tf.rotate(); err != nil {
+		return err
+	}
+
+	segments, err := tf.segments()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range segments {
+		n, err := metaDiskBufferCount(s)
+		if err != nil {
+			return err
+		}
+
+		tf.pending += n
+	}
+
+	go tf.run()
+
+	return nil
+}
+
+func (tf *metaDiskBuffer) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		tf.mu.Lock()
+		for tf.pending > 0 && tf.err == nil {
+			tf.cond.Wait()
+		}
+
+		err := tf.err
+		tf.err = nil
+		tf.mu.Unlock()
+
+		if err != nil {
+			return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+		}
+
+		msgs, err := tf.tf.Transform(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+		}
+
+		return msgs, nil
+	}
+
+	tf.mu.Lock()
+	if err := tf.err; err != nil {
+		tf.err = nil
+		tf.mu.Unlock()
+
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	tf.pending++
+	tf.mu.Unlock()
+
+	// The message is copied so that it can be modified by other transforms
+	// while it is in the buffer.
+	cp := message.New().SetData(bytes.Clone(msg.Data())).SetMetadata(bytes.Clone(msg.Metadata()))
+
+	select {
+	case tf.ch <- cp:
+		return []*message.Message{msg}, nil
+	default:
+	}
+
+	if err := tf.spill(msg); err != nil {
+		tf.done(nil)
+		return nil, fmt.Errorf("transform: meta_disk_buffer: %v", err)
+	}
+
+	// Wakes the goroutine in case it is waiting for messages in memory.
+	select {
+	case tf.wake <- struct{}{}:
+	default:
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *metaDiskBuffer) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// run sends messages to the transform. Messages in memory are sent before
+// messages on disk.
+func (tf *metaDiskBuffer) run() {
+	ctx := context.Background()
+
+	for {
+		select {
+		case msg := <-tf.ch:
+			tf.send(ctx, msg)
+
+			continue
+		default:
+		}
+
+		n, err := tf.drain(ctx)
+		if err != nil {
+			// Errors are returned by Transform, so this only prevents
+			// retrying in a tight loop.
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if n > 0 {
+			continue
+		}
+
+		select {
+		case msg := <-tf.ch:
+			tf.send(ctx, msg)
+		case <-tf.wake:
+		}
+	}
+}
+
+// send sends a message from memory to the transform. If the transform fails,
+// then the message is written to disk and retried.
+func (tf *metaDiskBuffer) send(ctx context.Context, msg *message.Message) {
+	_, err := tf.tf.Transform(ctx, msg)
+	if err == nil {
+		tf.done(nil)
+		return
+	}
+
+	if err := tf.spill(msg); err != nil {
+		tf.done(err)
+		return
+	}
+
+	// The error is returned by Transform.
+	_ = tf.fail(err)
+}
+
+// done marks a buffered message as sent.
+func (tf *metaDiskBuffer) done(err error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if err != nil && tf.err == nil {
+		tf.err = err
+	}
+
+	tf.pending--
+	tf.cond.Broadcast()
+}
+
+// spill writes the message to the active file.
+func (tf *metaDiskBuffer) spill(msg *message.Message) error {
+	b, err := json.Marshal(metaDiskBufferRecord{
+		Data:     msg.Data(),
+		Metadata: msg.Metadata(),
+	})
+	if err != nil {
+		return err
+	}
+
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if tf.file == nil {
+		f, err := os.OpenFile(filepath.Join(tf.conf.Directory, metaDiskBufferActive), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+
+		tf.file = f
+	}
+
+	_, err = tf.file.Write(append(b, '\n'))
+	return err
+}
+
+// drain sends every message that is on disk to the transform and returns the
+// number of messages that were sent. If the transform fails, then the failed
+// message and all messages after it remain in the segment.
+func (tf *metaDiskBuffer) drain(ctx context.Context) (int, error) {
+	tf.mu.Lock()
+	err := tf.rotate()
+	tf.mu.Unlock()
+
+	if err != nil {
+		return 0, tf.fail(err)
+	}
+
+	segments, err := tf.segments()
+	if err != nil {
+		return 0, tf.fail(err)
+	}
+
+	var count int
+	for _, s := range segments {
+		f, err := os.Open(s)
+		if err != nil {
+			return count, tf.fail(err)
+		}
+
+		r := bufio.NewReader(f)
+		for {
+			line, err := r.ReadBytes('\n')
+			if err == io.EOF {
+				break
+			}
+
+			if err != nil {
+				f.Close()
+				return count, tf.fail(err)
+			}
+
+			var rec metaDiskBufferRecord
+			if err := json.Unmarshal(line, &rec); err != nil {
+				tf.done(err)
+				continue
+			}
+
+			msg := message.New().SetData(rec.Data).SetMetadata(rec.Metadata)
+			if _, err := tf.tf.Transform(ctx, msg); err != nil {
+				rerr := metaDiskBufferRewrite(s, line, r)
+				f.Close()
+
+				if rerr != nil {
+					return count, tf.fail(rerr)
+				}
+
+				return count, tf.fail(err)
+			}
+
+			tf.done(nil)
+			count++
+		}
+
+		f.Close()
+		if err := os.Remove(s); err != nil {
+			return count, tf.fail(err)
+		}
+	}
+
+	return count, nil
+}
+
+// fail stores an error that is not caused by a message.
+func (tf *metaDiskBuffer) fail(err error) error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if tf.err == nil {
+		tf.err = err
+	}
+
+	tf.cond.Broadcast()
+
+	return err
+}
+
+// rotate converts the active file into a segment. The caller must hold the
+// lock, except during setup.
+func (tf *metaDiskBuffer) rotate() error {
+	if tf.file != nil {
+		if err := tf.file.Sync(); err != nil {
+			return err
+		}
+
+		if err := tf.file.Close(); err != nil {
+			return err
+		}
+
+		tf.file = nil
+	}
+
+	active := filepath.Join(tf.conf.Directory, metaDiskBufferActive)
+	if _, err := os.Stat(active); os.IsNotExist(err) {
+		return nil
+	}
+
+	name := fmt.Sprintf("%020d%s", time.Now().UnixNano(), metaDiskBufferSegment)
+	return os.Rename(active, filepath.Join(tf.conf.Directory, name))
+}
+
+func (tf *metaDiskBuffer) segments() ([]string, error) {
+	segments, err := filepath.Glob(filepath.Join(tf.conf.Directory, "*"+metaDiskBufferSegment))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// metaDiskBufferRewrite replaces a segment with the line that failed and
+// the unread lines in r.
+func metaDiskBufferRewrite(path string, line []byte, r io.Reader) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// metaDiskBufferCount returns the number of messages in a segment.
+func metaDiskBufferCount(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var n int
+	r := bufio.NewReader(f)
+	for {
+		_, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			return n, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		n++
+	}
+}
@@ -0,0 +1,248 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &metaDiskBuffer{}
+
+type metaDiskBufferCounter struct {
+	count int32
+	block chan struct{}
+	fail  atomic.Bool
+}
+
+func (c *metaDiskBufferCounter) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if c.block != nil {
+		<-c.block
+	}
+
+	if c.fail.Load() {
+		return nil, fmt.Errorf("failed")
+	}
+
+	atomic.AddInt32(&c.count, 1)
+	return []*message.Message{msg}, nil
+}
+
+func TestMetaDiskBuffer(t *testing.T) {
+	ctx := context.TODO()
+	counter := &metaDiskBufferCounter{}
+	tf := &metaDiskBuffer{
+		conf: metaDiskBufferConfig{
+			Directory: t.TempDir(),
+			Size:      2,
+		},
+		tf: counter,
+	}
+
+	if err := tf.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		msg := message.New().SetData([]byte(`{"a":"b"}`))
+		if _, err := tf.Transform(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctrl := message.New().AsControl()
+	result, err := tf.Transform(ctx, ctrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 1 || !result[0].IsControl() {
+		t.Errorf("expected control message, got %v", result)
+	}
+
+	if counter.count != 100 {
+		t.Errorf("expected 100 messages, got %d", counter.count)
+	}
+}
+
+func TestMetaDiskBufferRestart(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+
+	// The transform never completes, so all messages that do not fit in
+	// memory are written to disk.
+	blocked := &metaDiskBufferCounter{block: make(chan struct{})}
+	defer close(blocked.block)
+
+	tf := &metaDiskBuffer{
+		conf: metaDiskBufferConfig{
+			Directory: dir,
+			Size:      1,
+		},
+		tf: blocked,
+	}
+
+	if err := tf.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		msg := message.New().SetData([]byte(`{"a":"b"}`)).SetMetadata([]byte(`{"c":"d"}`))
+		if _, err := tf.Transform(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spilled, err := metaDiskBufferCount(filepath.Join(dir, metaDiskBufferActive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spilled == 0 {
+		t.Fatal("expected messages on disk")
+	}
+
+	counter := &metaDiskBufferCounter{}
+	restart := &metaDiskBuffer{
+		conf: metaDiskBufferConfig{
+			Directory: dir,
+			Size:      1,
+		},
+		tf: counter,
+	}
+
+	if err := restart.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := restart.Transform(ctx, message.New().AsControl()); err != nil {
+		t.Fatal(err)
+	}
+
+	if int(counter.count) != spilled {
+		t.Errorf("expected %d messages, got %d", spilled, counter.count)
+	}
+}
+
+func TestMetaDiskBufferRetry(t *testing.T) {
+	ctx := context.TODO()
+	counter := &metaDiskBufferCounter{}
+	counter.fail.Store(true)
+
+	tf := &metaDiskBuffer{
+		conf: metaDiskBufferConfig{
+			Directory: t.TempDir(),
+			Size:      2,
+		},
+		tf: counter,
+	}
+
+	if err := tf.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Errors from the transform are returned by later calls, and those
+	// messages are not buffered.
+	var sent int32
+	for i := 0; i < 10; i++ {
+		msg := message.New().SetData([]byte(`{"a":"b"}`))
+		if _, err := tf.Transform(ctx, msg); err == nil {
+			sent++
+		}
+	}
+
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Failed messages are retried after the transform recovers. Errors
+	// from earlier attempts may be returned before all messages are sent.
+	counter.fail.Store(false)
+
+	var err error
+	for i := 0; i < 5; i++ {
+		if _, err = tf.Transform(ctx, message.New().AsControl()); err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if counter.count != sent {
+		t.Errorf("expected %d messages, got %d", sent, counter.count)
+	}
+}
+
+type metaDiskBufferRecorder struct {
+	mu   sync.Mutex
+	data []string
+}
+
+func (r *metaDiskBufferRecorder) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data = append(r.data, string(msg.Data()))
+	return []*message.Message{msg}, nil
+}
+
+func TestMetaDiskBufferCopy(t *testing.T) {
+	ctx := context.TODO()
+	recorder := &metaDiskBufferRecorder{}
+
+	tf := &metaDiskBuffer{
+		conf: metaDiskBufferConfig{
+			Directory: t.TempDir(),
+			Size:      1,
+		},
+		tf: recorder,
+	}
+
+	if err := tf.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":"b"}`))
+	if _, err := tf.Transform(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Modifying the returned message does not modify the buffered message.
+	if err := msg.SetValue("a", "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorder.data) != 1 || recorder.data[0] != `{"a":"b"}` {
+		t.Errorf("expected [%s], got %v", `{"a":"b"}`, recorder.data)
+	}
+}
+
+func TestMetaDiskBufferInvalid(t *testing.T) {
+	ctx := context.TODO()
+	if _, err := newMetaDiskBuffer(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"transform": config.Config{Type: "utility_drop"},
+		},
+	}); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -16,9 +16,14 @@ type metaSwitchConfig struct {
 	// Cases are the transforms that are conditionally applied. If
 	// no condition is configured, then the transform is always
 	// applied.
+	//
+	// Each case can use either a single transform (Transform) or a
+	// series of transforms (Transforms) that are applied in order,
+	// similar to the meta_pipeline transform.
 	Cases []struct {
-		Condition condition.Config `json:"condition"`
-		Transform config.Config    `json:"transform"`
+		Condition  condition.Config `json:"condition"`
+		Transform  config.Config    `json:"transform"`
+		Transforms []config.Config  `json:"transforms"`
 	} `json:"cases"`
 }
 
@@ -31,6 +36,16 @@ func (c *metaSwitchConfig) Validate() error {
 		return fmt.Errorf("cases: %v", errors.ErrMissingRequiredOption)
 	}
 
+	for _, s := range c.Cases {
+		if s.Transform.Type == "" && len(s.Transforms) == 0 {
+			return fmt.Errorf("transform: %v", errors.ErrMissingRequiredOption)
+		}
+
+		if s.Transform.Type != "" && len(s.Transforms) > 0 {
+			return fmt.Errorf("transforms: %v", errors.ErrInvalidOption)
+		}
+	}
+
 	return nil
 }
 
@@ -46,7 +61,7 @@ func newMetaSwitch(ctx context.Context, cfg config.Config) (*metaSwitch, error)
 
 	var conditional []struct {
 		op condition.Operator
-		tf Transformer
+		tf []Transformer
 	}
 	for _, s := range conf.Cases {
 		op, err := condition.New(ctx, s.Condition)
@@ -54,17 +69,27 @@ func newMetaSwitch(ctx context.Context, cfg config.Config) (*metaSwitch, error)
 			return nil, fmt.Errorf("transform: meta_switch: %v", err)
 		}
 
-		tf, err := New(ctx, s.Transform)
-		if err != nil {
-			return nil, fmt.Errorf("transform: meta_switch: %v", err)
+		cfgs := s.Transforms
+		if s.Transform.Type != "" {
+			cfgs = []config.Config{s.Transform}
+		}
+
+		var tforms []Transformer
+		for _, c := range cfgs {
+			tf, err := New(ctx, c)
+			if err != nil {
+				return nil, fmt.Errorf("transform: meta_switch: %v", err)
+			}
+
+			tforms = append(tforms, tf)
 		}
 
 		conditional = append(conditional, struct {
 			op condition.Operator
-			tf Transformer
+			tf []Transformer
 		}{
 			op: op,
-			tf: tf,
+			tf: tforms,
 		})
 	}
 
@@ -76,12 +101,20 @@ func newMetaSwitch(ctx context.Context, cfg config.Config) (*metaSwitch, error)
 	return &tf, nil
 }
 
+// metaSwitch applies the transforms of the first case whose condition
+// matches the message, which replaces a series of transforms that each have
+// their own condition. A case without a condition always matches, so it can be
+// used as the last case to provide a fallback (similar to default in a Go
+// switch statement). If no cases match, then the message is not changed.
+//
+// Control messages are sent to every case, so transforms that buffer data
+// are always flushed.
 type metaSwitch struct {
 	conf metaSwitchConfig
 
 	conditional []struct {
 		op condition.Operator
-		tf Transformer
+		tf []Transformer
 	}
 }
 
@@ -89,9 +122,9 @@ func (tf *metaSwitch) Transform(ctx context.Context, msg *message.Message) ([]*m
 	if msg.IsControl() {
 		var messages []*message.Message
 		for _, c := range tf.conditional {
-			res, err := c.tf.Transform(ctx, msg)
+			res, err := Apply(ctx, c.tf, msg)
 			if err != nil {
-				return nil, fmt.Errorf("transform: meta_pipeline: %v", err)
+				return nil, fmt.Errorf("transform: meta_switch: %v", err)
 			}
 
 			messages = append(messages, res...)
@@ -122,7 +155,7 @@ func (tf *metaSwitch) Transform(ctx context.Context, msg *message.Message) ([]*m
 			continue
 		}
 
-		msgs, err := c.tf.Transform(ctx, msg)
+		msgs, err := Apply(ctx, c.tf, msg)
 		if err != nil {
 			return nil, fmt.Errorf("transform: meta_switch: %v", err)
 		}
@@ -184,6 +184,60 @@ var metaSwitchTests = []struct {
 			[]byte(`{"a":"b"}`),
 		},
 	},
+	{
+		// This test simulates routing to a pipeline by applying multiple
+		// transforms in the matching case.
+		"transforms",
+		config.Config{
+			Settings: map[string]interface{}{
+				"cases": []struct {
+					Condition  condition.Config `json:"condition"`
+					Transforms []config.Config  `json:"transforms"`
+				}{
+					{
+						Condition: condition.Config{
+							Operator: "any",
+							Inspectors: []config.Config{
+								{
+									Type: "string_contains",
+									Settings: map[string]interface{}{
+										"object": map[string]interface{}{
+											"source_key": "a",
+										},
+										"value": "b",
+									},
+								},
+							},
+						},
+						Transforms: []config.Config{
+							{
+								Type: "object_copy",
+								Settings: map[string]interface{}{
+									"object": map[string]interface{}{
+										"source_key": "a",
+										"target_key": "c",
+									},
+								},
+							},
+							{
+								Type: "string_to_upper",
+								Settings: map[string]interface{}{
+									"object": map[string]interface{}{
+										"source_key": "c",
+										"target_key": "c",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b","c":"B"}`),
+		},
+	},
 }
 
 func TestMetaSwitch(t *testing.T) {
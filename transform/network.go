@@ -2,6 +2,9 @@ package transform
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	iconfig "github.com/brexhq/substation/internal/config"
 	"github.com/brexhq/substation/internal/errors"
@@ -26,3 +29,24 @@ func (c *networkDomainConfig) Validate() error {
 
 	return nil
 }
+
+// netDomainHost returns the hostname from a hostname or URL (e.g.,
+// "https://Www.Example.com:443/path" is "www.example.com"). Ports and the
+// trailing dot of fully qualified domain names are removed.
+func netDomainHost(s string) string {
+	s = strings.TrimSpace(s)
+
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil {
+			s = u.Hostname()
+		}
+	} else if i := strings.IndexAny(s, "/?#"); i != -1 {
+		s = s[:i]
+	}
+
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+
+	return strings.ToLower(strings.TrimSuffix(s, "."))
+}
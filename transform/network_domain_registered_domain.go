@@ -41,7 +41,7 @@ func (tf *networkDomainRegisteredDomain) Transform(ctx context.Context, msg *mes
 
 	if !tf.isObj {
 		str := string(msg.Data())
-		domain, err := publicsuffix.EffectiveTLDPlusOne(str)
+		domain, err := publicsuffix.EffectiveTLDPlusOne(netDomainHost(str))
 		if err != nil {
 			return nil, fmt.Errorf("transform: network_domain_registered_domain: %v", err)
 		}
@@ -55,7 +55,7 @@ func (tf *networkDomainRegisteredDomain) Transform(ctx context.Context, msg *mes
 		return []*message.Message{msg}, nil
 	}
 
-	domain, err := publicsuffix.EffectiveTLDPlusOne(value.String())
+	domain, err := publicsuffix.EffectiveTLDPlusOne(netDomainHost(value.String()))
 	if err != nil {
 		return nil, fmt.Errorf("transform: network_domain_registered_domain: %v", err)
 	}
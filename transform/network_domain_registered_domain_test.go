@@ -26,6 +26,14 @@ var networkDomainRegisteredDomainTests = []struct {
 			[]byte(`b.com`),
 		},
 	},
+	{
+		"data url",
+		config.Config{},
+		[]byte(`https://C.b.com:8443/d?e=f`),
+		[][]byte{
+			[]byte(`b.com`),
+		},
+	},
 	// object tests
 	{
 		"object",
@@ -46,7 +46,7 @@ func (tf *networkDomainSubdomain) Transform(ctx context.Context, msg *message.Me
 
 	if !tf.isObj {
 		str := string(msg.Data())
-		domain, err := fmtParseSubdomain(str)
+		domain, err := fmtParseSubdomain(netDomainHost(str))
 		if err != nil {
 			return nil, fmt.Errorf("transform: network_domain_subdomain: %v", err)
 		}
@@ -60,7 +60,7 @@ func (tf *networkDomainSubdomain) Transform(ctx context.Context, msg *message.Me
 		return []*message.Message{msg}, nil
 	}
 
-	domain, err := fmtParseSubdomain(value.String())
+	domain, err := fmtParseSubdomain(netDomainHost(value.String()))
 	if err != nil {
 		return nil, fmt.Errorf("transform: network_domain_subdomain: %v", err)
 	}
@@ -26,6 +26,14 @@ var networkDomainSubdomainTests = []struct {
 			[]byte(`c`),
 		},
 	},
+	{
+		"data url",
+		config.Config{},
+		[]byte(`https://C.b.com:8443/d?e=f`),
+		[][]byte{
+			[]byte(`c`),
+		},
+	},
 	// object tests
 	{
 		"object",
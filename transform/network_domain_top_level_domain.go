@@ -41,7 +41,7 @@ func (tf *networkDomainTopLevelDomain) Transform(ctx context.Context, msg *messa
 
 	if !tf.isObj {
 		str := string(msg.Data())
-		domain, _ := publicsuffix.PublicSuffix(str)
+		domain, _ := publicsuffix.PublicSuffix(netDomainHost(str))
 
 		msg.SetData([]byte(domain))
 		return []*message.Message{msg}, nil
@@ -52,7 +52,7 @@ func (tf *networkDomainTopLevelDomain) Transform(ctx context.Context, msg *messa
 		return []*message.Message{msg}, nil
 	}
 
-	domain, _ := publicsuffix.PublicSuffix(value.String())
+	domain, _ := publicsuffix.PublicSuffix(netDomainHost(value.String()))
 
 	if err := msg.SetValue(tf.conf.Object.TargetKey, domain); err != nil {
 		return nil, fmt.Errorf("transform: network_domain_top_level_domain: %v", err)
@@ -26,6 +26,14 @@ var networkDomainTopLevelDomainTests = []struct {
 			[]byte(`com`),
 		},
 	},
+	{
+		"data url",
+		config.Config{},
+		[]byte(`https://C.b.com:8443/d?e=f`),
+		[][]byte{
+			[]byte(`com`),
+		},
+	},
 	// object tests
 	{
 		"object",
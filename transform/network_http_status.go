@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type networkHTTPStatusConfig struct {
+	// ReasonKey is the key where the standard reason phrase of the status code
+	// (e.g., "Not Found" for 404) is put. If the status code does not have a
+	// reason phrase, then an empty string is put into the object.
+	//
+	// This is optional and has no default (reason phrases are not put into the
+	// object).
+	ReasonKey string `json:"reason_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *networkHTTPStatusConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *networkHTTPStatusConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey == "" && c.ReasonKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newNetworkHTTPStatus(_ context.Context, cfg config.Config) (*networkHTTPStatus, error) {
+	conf := networkHTTPStatusConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: network_http_status: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: network_http_status: %v", err)
+	}
+
+	tf := networkHTTPStatus{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// networkHTTPStatus converts an HTTP status code into its class:
+//   - informational (1xx)
+//   - success (2xx)
+//   - redirect (3xx)
+//   - client_error (4xx)
+//   - server_error (5xx)
+//
+// Values that are not status codes are converted to "unknown".
+type networkHTTPStatus struct {
+	conf     networkHTTPStatusConfig
+	isObject bool
+}
+
+func (tf *networkHTTPStatus) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		code := netHTTPStatusCode(string(msg.Data()))
+		msg.SetData([]byte(netHTTPStatusClass(code)))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	code := netHTTPStatusCode(value.String())
+	if err := msg.SetValue(tf.conf.Object.TargetKey, netHTTPStatusClass(code)); err != nil {
+		return nil, fmt.Errorf("transform: network_http_status: %v", err)
+	}
+
+	if tf.conf.ReasonKey != "" {
+		if err := msg.SetValue(tf.conf.ReasonKey, http.StatusText(code)); err != nil {
+			return nil, fmt.Errorf("transform: network_http_status: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *networkHTTPStatus) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// netHTTPStatusCode returns the status code, or 0 if the value is not an
+// integer.
+func netHTTPStatusCode(s string) int {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0
+	}
+
+	return code
+}
+
+func netHTTPStatusClass(code int) string {
+	switch {
+	case code >= 100 && code < 200:
+		return "informational"
+	case code >= 200 && code < 300:
+		return "success"
+	case code >= 300 && code < 400:
+		return "redirect"
+	case code >= 400 && code < 500:
+		return "client_error"
+	case code >= 500 && code < 600:
+		return "server_error"
+	default:
+		return "unknown"
+	}
+}
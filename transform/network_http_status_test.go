@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &networkHTTPStatus{}
+
+var networkHTTPStatusTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`204`),
+		[][]byte{
+			[]byte(`success`),
+		},
+	},
+	{
+		"data unknown",
+		config.Config{},
+		[]byte(`abc`),
+		[][]byte{
+			[]byte(`unknown`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "status",
+					"target_key": "class",
+				},
+			},
+		},
+		[]byte(`{"status":301}`),
+		[][]byte{
+			[]byte(`{"status":301,"class":"redirect"}`),
+		},
+	},
+	{
+		"object string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "status",
+					"target_key": "class",
+				},
+			},
+		},
+		[]byte(`{"status":"503"}`),
+		[][]byte{
+			[]byte(`{"status":"503","class":"server_error"}`),
+		},
+	},
+	{
+		"object reason",
+		config.Config{
+			Settings: map[string]interface{}{
+				"reason_key": "reason",
+				"object": map[string]interface{}{
+					"source_key": "status",
+					"target_key": "class",
+				},
+			},
+		},
+		[]byte(`{"status":404}`),
+		[][]byte{
+			[]byte(`{"status":404,"class":"client_error","reason":"Not Found"}`),
+		},
+	},
+	{
+		"object out of range",
+		config.Config{
+			Settings: map[string]interface{}{
+				"reason_key": "reason",
+				"object": map[string]interface{}{
+					"source_key": "status",
+					"target_key": "class",
+				},
+			},
+		},
+		[]byte(`{"status":700}`),
+		[][]byte{
+			[]byte(`{"status":700,"class":"unknown","reason":""}`),
+		},
+	},
+}
+
+func TestNetworkHTTPStatus(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range networkHTTPStatusTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newNetworkHTTPStatus(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkNetworkHTTPStatus(b *testing.B, tf *networkHTTPStatus, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkNetworkHTTPStatus(b *testing.B) {
+	for _, test := range networkHTTPStatusTests {
+		tf, err := newNetworkHTTPStatus(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkNetworkHTTPStatus(b, tf, test.test)
+			},
+		)
+	}
+}
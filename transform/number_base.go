@@ -0,0 +1,170 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+// numberBasePrefixes are the optional prefixes of integers in each base.
+var numberBasePrefixes = map[int]string{
+	2:  "0b",
+	8:  "0o",
+	16: "0x",
+}
+
+type numberBaseConfig struct {
+	// From is the base of the input integer. Integers can have the prefix of
+	// their base (e.g., 0x for base 16).
+	//
+	// Must be one of:
+	//	- 2
+	//	- 8
+	//	- 10
+	//	- 16
+	//
+	// This is optional and defaults to 10.
+	From int `json:"from"`
+	// To is the base of the output integer. This uses the same bases as From.
+	// If the base is 10, then the output is a number, otherwise it is a string
+	// without a prefix.
+	//
+	// This is optional and defaults to 10.
+	To int `json:"to"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *numberBaseConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *numberBaseConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	bases := []int{2, 8, 10, 16}
+	if !slices.Contains(bases, c.From) {
+		return fmt.Errorf("from %d: %v", c.From, errors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(bases, c.To) {
+		return fmt.Errorf("to %d: %v", c.To, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newNumberBase(_ context.Context, cfg config.Config) (*numberBase, error) {
+	conf := numberBaseConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: number_base: %v", err)
+	}
+
+	if conf.From == 0 {
+		conf.From = 10
+	}
+
+	if conf.To == 0 {
+		conf.To = 10
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: number_base: %v", err)
+	}
+
+	tf := numberBase{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// numberBase converts integers between bases (e.g., "ff" in base 16 is 255 in
+// base 10). Integers that contain invalid digits for the base return an error.
+type numberBase struct {
+	conf     numberBaseConfig
+	isObject bool
+}
+
+func (tf *numberBase) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s, err := tf.convert(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: number_base: %v", err)
+	}
+
+	if !tf.isObject {
+		msg.SetData([]byte(s))
+		return []*message.Message{msg}, nil
+	}
+
+	// Base 10 integers are numbers so that they can be compared by conditions.
+	var out interface{} = s
+	if tf.conf.To == 10 {
+		out = json.RawMessage(s)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, out); err != nil {
+		return nil, fmt.Errorf("transform: number_base: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *numberBase) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *numberBase) convert(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	if p, ok := numberBasePrefixes[tf.conf.From]; ok && len(digits) > len(p) && strings.EqualFold(digits[:len(p)], p) {
+		digits = digits[len(p):]
+	}
+
+	// Integers are parsed as unsigned to support the full range of
+	// values in bases like 16 (e.g., ffffffffffffffff).
+	u, err := strconv.ParseUint(digits, tf.conf.From, 64)
+	if err != nil {
+		return "", fmt.Errorf("base %d: %v", tf.conf.From, err.(*strconv.NumError).Err)
+	}
+
+	out := strconv.FormatUint(u, tf.conf.To)
+	if neg && u != 0 {
+		out = "-" + out
+	}
+
+	return out, nil
+}
@@ -0,0 +1,212 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &numberBase{}
+
+var numberBaseTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": 16,
+			},
+		},
+		[]byte(`ff`),
+		[][]byte{
+			[]byte(`255`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": 16,
+			},
+		},
+		[]byte(`0xFF`),
+		[][]byte{
+			[]byte(`255`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": 16,
+				"to":   2,
+			},
+		},
+		[]byte(`0x0a`),
+		[][]byte{
+			[]byte(`1010`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"to": 16,
+			},
+		},
+		[]byte(`-255`),
+		[][]byte{
+			[]byte(`-ff`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": 16,
+			},
+		},
+		[]byte(`ffffffffffffffff`),
+		[][]byte{
+			[]byte(`18446744073709551615`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"from": 8,
+			},
+		},
+		[]byte(`{"a":"0755"}`),
+		[][]byte{
+			[]byte(`{"a":"0755","b":493}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"from": 2,
+				"to":   8,
+			},
+		},
+		[]byte(`{"a":"0b111101101"}`),
+		[][]byte{
+			[]byte(`{"a":"0b111101101","b":"755"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"to": 16,
+			},
+		},
+		[]byte(`{"a":4096}`),
+		[][]byte{
+			[]byte(`{"a":4096,"b":"1000"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"c":"d"}`),
+		[][]byte{
+			[]byte(`{"c":"d"}`),
+		},
+	},
+}
+
+func TestNumberBase(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range numberBaseTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newNumberBase(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkNumberBase(b *testing.B, tf *numberBase, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkNumberBase(b *testing.B) {
+	for _, test := range numberBaseTests {
+		tf, err := newNumberBase(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkNumberBase(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestNumberBaseInvalid(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newNumberBase(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"from": 8,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`789`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
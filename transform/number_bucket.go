@@ -0,0 +1,143 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type numberBucketConfig struct {
+	// Boundaries are the lower bounds of each bucket, in ascending order. A
+	// value is in a bucket if it is greater than or equal to the bucket's
+	// boundary and less than the next boundary.
+	Boundaries []float64 `json:"boundaries"`
+	// Labels are the names of each bucket (e.g., boundaries [0, 100, 500]
+	// and labels ["fast", "ok", "slow"]). Values that are greater than the
+	// last boundary are put in the last bucket.
+	//
+	// The number of labels must equal the number of boundaries.
+	Labels []string `json:"labels"`
+	// Underflow is the label for values that are less than the first boundary.
+	//
+	// This is optional and defaults to the first label.
+	Underflow string `json:"underflow"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *numberBucketConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *numberBucketConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if len(c.Boundaries) == 0 {
+		return fmt.Errorf("boundaries: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !sort.Float64sAreSorted(c.Boundaries) {
+		return fmt.Errorf("boundaries: %v", errors.ErrInvalidOption)
+	}
+
+	if len(c.Labels) != len(c.Boundaries) {
+		return fmt.Errorf("labels: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newNumberBucket(_ context.Context, cfg config.Config) (*numberBucket, error) {
+	conf := numberBucketConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: number_bucket: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: number_bucket: %v", err)
+	}
+
+	if conf.Underflow == "" {
+		conf.Underflow = conf.Labels[0]
+	}
+
+	tf := numberBucket{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// numberBucket replaces a number with the label of the bucket that it is in.
+// Values that are not numbers are not changed.
+type numberBucket struct {
+	conf     numberBucketConfig
+	isObject bool
+}
+
+func (tf *numberBucket) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	f, err := strconv.ParseFloat(value.String(), 64)
+	if err != nil {
+		return []*message.Message{msg}, nil
+	}
+
+	label := tf.label(f)
+	if !tf.isObject {
+		msg.SetData([]byte(label))
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, label); err != nil {
+		return nil, fmt.Errorf("transform: number_bucket: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *numberBucket) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *numberBucket) label(f float64) string {
+	// i is the index of the first boundary that is greater than the value,
+	// so the value is in the bucket before it.
+	i := sort.Search(len(tf.conf.Boundaries), func(i int) bool {
+		return tf.conf.Boundaries[i] > f
+	})
+
+	if i == 0 {
+		return tf.conf.Underflow
+	}
+
+	return tf.conf.Labels[i-1]
+}
@@ -0,0 +1,180 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &numberBucket{}
+
+var numberBucketTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+			},
+		},
+		[]byte(`42`),
+		[][]byte{
+			[]byte(`fast`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+			},
+		},
+		[]byte(`100`),
+		[][]byte{
+			[]byte(`ok`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+			},
+		},
+		[]byte(`abc`),
+		[][]byte{
+			[]byte(`abc`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":499.9}`),
+		[][]byte{
+			[]byte(`{"a":499.9,"b":"ok"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"10000"}`),
+		[][]byte{
+			[]byte(`{"a":"10000","b":"slow"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":-1}`),
+		[][]byte{
+			[]byte(`{"a":-1,"b":"fast"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"boundaries": []float64{0, 100, 500},
+				"labels":     []string{"fast", "ok", "slow"},
+				"underflow":  "invalid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":-1}`),
+		[][]byte{
+			[]byte(`{"a":-1,"b":"invalid"}`),
+		},
+	},
+}
+
+func TestNumberBucket(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range numberBucketTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newNumberBucket(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkNumberBucket(b *testing.B, tf *numberBucket, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkNumberBucket(b *testing.B) {
+	for _, test := range numberBucketTests {
+		tf, err := newNumberBucket(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkNumberBucket(b, tf, test.test)
+			},
+		)
+	}
+}
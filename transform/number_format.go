@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type numberFormatConfig struct {
+	// Decimals is the number of decimal places in the formatted number. Values
+	// are rounded if they have more decimal places than this. If set to -1, then
+	// the minimum number of decimal places required to represent the number is
+	// used.
+	//
+	// This is optional and defaults to 0.
+	Decimals int `json:"decimals"`
+	// ThousandsSeparator is the string used to group digits into thousands
+	// (e.g., "," for 1,000 or "." for 1.000).
+	//
+	// This is optional and has no default (digits are not grouped).
+	ThousandsSeparator string `json:"thousands_separator"`
+	// DecimalSeparator is the string used to separate the integer from the
+	// fractional part of the number (e.g., "." for 1.5 or "," for 1,5).
+	//
+	// This is optional and defaults to ".".
+	DecimalSeparator string `json:"decimal_separator"`
+	// Prefix is prepended to the formatted number, after the sign (e.g., "$"
+	// for -$1,000.00).
+	//
+	// This is optional and has no default.
+	Prefix string `json:"prefix"`
+	// Suffix is appended to the formatted number (e.g., " USD" or "%").
+	//
+	// This is optional and has no default.
+	Suffix string `json:"suffix"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *numberFormatConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *numberFormatConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Decimals < -1 {
+		return fmt.Errorf("decimals: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newNumberFormat(_ context.Context, cfg config.Config) (*numberFormat, error) {
+	conf := numberFormatConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: number_format: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: number_format: %v", err)
+	}
+
+	if conf.DecimalSeparator == "" {
+		conf.DecimalSeparator = "."
+	}
+
+	tf := numberFormat{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type numberFormat struct {
+	conf     numberFormatConfig
+	isObject bool
+}
+
+func (tf *numberFormat) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s := tf.format(value.Float())
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+			return nil, fmt.Errorf("transform: number_format: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(s))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *numberFormat) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *numberFormat) format(f float64) string {
+	s := strconv.FormatFloat(math.Abs(f), 'f', tf.conf.Decimals, 64)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	// The sign is dropped if the number rounds to zero (-0.001 -> 0).
+	if f < 0 && strings.ContainsAny(s, "123456789") {
+		b.WriteString("-")
+	}
+
+	b.WriteString(tf.conf.Prefix)
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(tf.conf.ThousandsSeparator)
+		}
+
+		b.WriteRune(r)
+	}
+
+	if fraction != "" {
+		b.WriteString(tf.conf.DecimalSeparator)
+		b.WriteString(fraction)
+	}
+
+	b.WriteString(tf.conf.Suffix)
+	return b.String()
+}
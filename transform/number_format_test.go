@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &numberFormat{}
+
+var numberFormatTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"decimals":            2,
+				"thousands_separator": ",",
+				"prefix":              "$",
+			},
+		},
+		[]byte(`-1234567.891`),
+		[][]byte{
+			[]byte(`-$1,234,567.89`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"decimals":            -1,
+				"thousands_separator": ".",
+				"decimal_separator":   ",",
+				"suffix":              " EUR",
+			},
+		},
+		[]byte(`1234.5`),
+		[][]byte{
+			[]byte(`1.234,5 EUR`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`999.5`),
+		[][]byte{
+			[]byte(`1000`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+				"decimals":            0,
+				"thousands_separator": ",",
+			},
+		},
+		[]byte(`{"a":1000}`),
+		[][]byte{
+			[]byte(`{"a":"1,000"}`),
+		},
+	},
+}
+
+func TestNumberFormat(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range numberFormatTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newNumberFormat(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkNumberFormat(b *testing.B, tf *numberFormat, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkNumberFormat(b *testing.B) {
+	for _, test := range numberFormatTests {
+		tf, err := newNumberFormat(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkNumberFormat(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,157 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+var errNumberMathFieldsDivideByZero = fmt.Errorf("division by zero")
+
+type numberMathFieldsConfig struct {
+	// Operation is the math operation that is applied to the values, from
+	// left to right.
+	//
+	// Must be one of:
+	//	- addition
+	//	- subtraction
+	//	- multiplication
+	//	- division
+	Operation string `json:"operation"`
+	// Values is an ordered list of keys and literals that the operation is
+	// applied to. Strings are keys that are retrieved from the message and
+	// numbers are used as-is (e.g., ["a", "b", 100]).
+	Values []interface{} `json:"values"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *numberMathFieldsConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *numberMathFieldsConfig) Validate() error {
+	if !slices.Contains(
+		[]string{
+			"addition",
+			"subtraction",
+			"multiplication",
+			"division",
+		},
+		c.Operation) {
+		return fmt.Errorf("operation %q: %v", c.Operation, errors.ErrInvalidOption)
+	}
+
+	if len(c.Values) == 0 {
+		return fmt.Errorf("values: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, v := range c.Values {
+		switch v.(type) {
+		case string, float64:
+		default:
+			return fmt.Errorf("values %v: %v", v, errors.ErrInvalidOption)
+		}
+	}
+
+	return nil
+}
+
+func newNumberMathFields(_ context.Context, cfg config.Config) (*numberMathFields, error) {
+	conf := numberMathFieldsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: number_math_fields: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: number_math_fields: %v", err)
+	}
+
+	tf := numberMathFields{
+		conf:     conf,
+		isObject: conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// numberMathFields applies a math operation across multiple values in an
+// object. Unlike the other number_math transforms, the values do not need
+// to be in an array. If any key does not exist, then the message is not
+// changed.
+type numberMathFields struct {
+	conf     numberMathFieldsConfig
+	isObject bool
+}
+
+func (tf *numberMathFields) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var vFloat64 float64
+	for i, v := range tf.conf.Values {
+		var f float64
+		switch v := v.(type) {
+		case string:
+			value := msg.GetValue(v)
+			if !value.Exists() {
+				return []*message.Message{msg}, nil
+			}
+
+			f = value.Float()
+		case float64:
+			f = v
+		}
+
+		if i == 0 {
+			vFloat64 = f
+			continue
+		}
+
+		switch tf.conf.Operation {
+		case "addition":
+			vFloat64 += f
+		case "subtraction":
+			vFloat64 -= f
+		case "multiplication":
+			vFloat64 *= f
+		case "division":
+			if f == 0 {
+				return nil, fmt.Errorf("transform: number_math_fields: %v", errNumberMathFieldsDivideByZero)
+			}
+
+			vFloat64 /= f
+		}
+	}
+
+	strFloat64 := numberFloat64ToString(vFloat64)
+	if !tf.isObject {
+		msg.SetData([]byte(strFloat64))
+
+		return []*message.Message{msg}, nil
+	}
+
+	f, err := strconv.ParseFloat(strFloat64, 64)
+	if err != nil {
+		return nil, fmt.Errorf("transform: number_math_fields: %v", err)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, f); err != nil {
+		return nil, fmt.Errorf("transform: number_math_fields: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *numberMathFields) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,183 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &numberMathFields{}
+
+var numberMathFieldsTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "addition",
+				"values":    []interface{}{"a", "b"},
+			},
+		},
+		[]byte(`{"a":1,"b":2}`),
+		[][]byte{
+			[]byte(`3`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "addition",
+				"values":    []interface{}{"a", "b"},
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":1,"b":2}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":2,"c":3}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "subtraction",
+				"values":    []interface{}{"a", "b", "c"},
+				"object": map[string]interface{}{
+					"target_key": "d",
+				},
+			},
+		},
+		[]byte(`{"a":10,"b":2.5,"c":"1"}`),
+		[][]byte{
+			[]byte(`{"a":10,"b":2.5,"c":"1","d":6.5}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "multiplication",
+				"values":    []interface{}{"a", 1000.0},
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":1.5}`),
+		[][]byte{
+			[]byte(`{"a":1.5,"c":1500}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "division",
+				"values":    []interface{}{"a.b", "a.c"},
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1,"c":3}}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1,"c":3},"c":0.3333333333333333}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operation": "addition",
+				"values":    []interface{}{"a", "b"},
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":1}`),
+		[][]byte{
+			[]byte(`{"a":1}`),
+		},
+	},
+}
+
+func TestNumberMathFields(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range numberMathFieldsTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newNumberMathFields(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkNumberMathFields(b *testing.B, tf *numberMathFields, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkNumberMathFields(b *testing.B) {
+	for _, test := range numberMathFieldsTests {
+		tf, err := newNumberMathFields(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkNumberMathFields(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestNumberMathFieldsDivideByZero(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newNumberMathFields(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"operation": "division",
+			"values":    []interface{}{"a", "b"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":1,"b":0}`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
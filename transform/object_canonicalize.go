@@ -0,0 +1,215 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectCanonicalizeConfig struct {
+	// Keys is the order of keys at the top level of the object. Keys that
+	// are not in this list are sorted alphabetically after the listed keys.
+	//
+	// This is optional and defaults to sorting all keys alphabetically.
+	Keys []string `json:"keys"`
+	// Recursive determines if keys in nested objects (including objects in
+	// arrays) are sorted alphabetically.
+	//
+	// This is optional and defaults to false.
+	Recursive bool `json:"recursive"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectCanonicalizeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectCanonicalizeConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectCanonicalize(_ context.Context, cfg config.Config) (*objectCanonicalize, error) {
+	conf := objectCanonicalizeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_canonicalize: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_canonicalize: %v", err)
+	}
+
+	tf := objectCanonicalize{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+		order:    make(map[string]int),
+	}
+
+	for i, k := range conf.Keys {
+		if _, ok := tf.order[k]; !ok {
+			tf.order[k] = i
+		}
+	}
+
+	return &tf, nil
+}
+
+// objectCanonicalize rewrites an object so that its keys are in a stable
+// order. Values that are not objects are not changed.
+type objectCanonicalize struct {
+	conf     objectCanonicalizeConfig
+	isObject bool
+
+	// order maps the configured keys to their position.
+	order map[string]int
+}
+
+func (tf *objectCanonicalize) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var b []byte
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.IsObject() {
+			return []*message.Message{msg}, nil
+		}
+
+		b = value.Bytes()
+	} else {
+		b = msg.Data()
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || b[0] != '{' || !json.Valid(b) {
+		return []*message.Message{msg}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := tf.write(&buf, b, tf.order); err != nil {
+		return nil, fmt.Errorf("transform: object_canonicalize: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := json.Compact(&out, buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("transform: object_canonicalize: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out.Bytes())); err != nil {
+			return nil, fmt.Errorf("transform: object_canonicalize: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out.Bytes())
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectCanonicalize) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// write writes the object in b to buf with its keys sorted. Keys in order are
+// written first, followed by all other keys in alphabetical order.
+func (tf *objectCanonicalize) write(buf *bytes.Buffer, b []byte, order map[string]int) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		oi, iok := order[keys[i]]
+		oj, jok := order[keys[j]]
+
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		if err := tf.writeValue(buf, obj[k]); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// writeValue writes a value nested in an object to buf. If the transform is
+// recursive, then nested objects are sorted.
+func (tf *objectCanonicalize) writeValue(buf *bytes.Buffer, b json.RawMessage) error {
+	b = bytes.TrimSpace(b)
+	if !tf.conf.Recursive || len(b) == 0 {
+		buf.Write(b)
+		return nil
+	}
+
+	switch b[0] {
+	case '{':
+		return tf.write(buf, b, nil)
+	case '[':
+		var arr []json.RawMessage
+		if err := json.Unmarshal(b, &arr); err != nil {
+			return err
+		}
+
+		buf.WriteByte('[')
+		for i, v := range arr {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := tf.writeValue(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		buf.Write(b)
+	}
+
+	return nil
+}
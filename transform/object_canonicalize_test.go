@@ -0,0 +1,130 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectCanonicalize{}
+
+var objectCanonicalizeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{},
+		},
+		[]byte(`{"c":1,"a":{"z":1,"y":2},"b":[3]}`),
+		[][]byte{
+			[]byte(`{"a":{"z":1,"y":2},"b":[3],"c":1}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"id", "time"},
+			},
+		},
+		[]byte(`{"c":1,"time":"t","a":2,"id":"x"}`),
+		[][]byte{
+			[]byte(`{"id":"x","time":"t","a":2,"c":1}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":      []string{"b"},
+				"recursive": true,
+			},
+		},
+		[]byte(`{"a":{"z":1,"y":{"d":1,"c":2}},"b":[{"f":1,"e":2}]}`),
+		[][]byte{
+			[]byte(`{"b":[{"e":2,"f":1}],"a":{"y":{"c":2,"d":1},"z":1}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{},
+		},
+		[]byte(`["b","a"]`),
+		[][]byte{
+			[]byte(`["b","a"]`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "x",
+					"target_key": "x",
+				},
+			},
+		},
+		[]byte(`{"x":{"b":1,"a":2},"a":1}`),
+		[][]byte{
+			[]byte(`{"x":{"a":2,"b":1},"a":1}`),
+		},
+	},
+}
+
+func TestObjectCanonicalize(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectCanonicalizeTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectCanonicalize(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectCanonicalize(b *testing.B, tf *objectCanonicalize, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectCanonicalize(b *testing.B) {
+	for _, test := range objectCanonicalizeTests {
+		tf, err := newObjectCanonicalize(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectCanonicalize(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// objectCoerceNumber matches strings that are valid JSON numbers. Numbers
+// with leading zeros (e.g., ZIP codes) are not valid.
+var objectCoerceNumber = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+
+type objectCoerceConfig struct {
+	// AllowKeys are the keys (and the keys nested in them) that are coerced.
+	// Nested keys use dot notation and array elements use the key of the
+	// array (e.g., "a.b" includes "a.b.c" and the elements in "a.b").
+	//
+	// This is optional and defaults to all keys.
+	AllowKeys []string `json:"allow_keys"`
+	// DenyKeys are the keys (and the keys nested in them) that are not
+	// coerced. This takes precedence over AllowKeys.
+	//
+	// This is optional and has no default.
+	DenyKeys []string `json:"deny_keys"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectCoerceConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectCoerceConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectCoerce(_ context.Context, cfg config.Config) (*objectCoerce, error) {
+	conf := objectCoerceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_coerce: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_coerce: %v", err)
+	}
+
+	tf := objectCoerce{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// objectCoerce recursively converts strings that contain numbers (e.g., "1.5")
+// or booleans ("true" or "false") into numbers and booleans. All other values
+// are not changed.
+type objectCoerce struct {
+	conf     objectCoerceConfig
+	isObject bool
+}
+
+func (tf *objectCoerce) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var b []byte
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		b = value.Bytes()
+	} else {
+		b = msg.Data()
+	}
+
+	doc, err := objPatchDecode(b)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_coerce: %v", err)
+	}
+
+	out, err := json.Marshal(tf.coerce(doc, ""))
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_coerce: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out)); err != nil {
+			return nil, fmt.Errorf("transform: object_coerce: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectCoerce) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *objectCoerce) coerce(v interface{}, key string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			p := k
+			if key != "" {
+				p = key + "." + k
+			}
+
+			v[k] = tf.coerce(val, p)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = tf.coerce(val, key)
+		}
+	case string:
+		if !tf.allowed(key) {
+			return v
+		}
+
+		switch {
+		case v == "true":
+			return true
+		case v == "false":
+			return false
+		case objectCoerceNumber.MatchString(v):
+			return json.Number(v)
+		}
+	}
+
+	return v
+}
+
+func (tf *objectCoerce) allowed(key string) bool {
+	for _, k := range tf.conf.DenyKeys {
+		if objectCoerceMatch(key, k) {
+			return false
+		}
+	}
+
+	if len(tf.conf.AllowKeys) == 0 {
+		return true
+	}
+
+	for _, k := range tf.conf.AllowKeys {
+		if objectCoerceMatch(key, k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// objectCoerceMatch returns true if the key is the same as or nested in the
+// parent key.
+func objectCoerceMatch(key, parent string) bool {
+	return key == parent || strings.HasPrefix(key, parent+".")
+}
@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectCoerce{}
+
+var objectCoerceTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{},
+		},
+		[]byte(`{"a":"1","b":"-2.5e3","c":"true","d":"false","e":"00123","f":"abc","g":{"h":["1","x",{"i":"True"}]}}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":-2.5e3,"c":true,"d":false,"e":"00123","f":"abc","g":{"h":[1,"x",{"i":"True"}]}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"allow_keys": []string{"a", "b.c"},
+			},
+		},
+		[]byte(`{"a":{"x":"1"},"b":{"c":"2","d":"3"},"e":"4"}`),
+		[][]byte{
+			[]byte(`{"a":{"x":1},"b":{"c":2,"d":"3"},"e":"4"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"deny_keys": []string{"a.x"},
+			},
+		},
+		[]byte(`{"a":{"x":["1"],"y":"2"},"xa":"3"}`),
+		[][]byte{
+			[]byte(`{"a":{"x":["1"],"y":2},"xa":3}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{},
+		},
+		[]byte(`"1"`),
+		[][]byte{
+			[]byte(`1`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":"1"},"c":"2"}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1},"c":"2"}`),
+		},
+	},
+}
+
+func TestObjectCoerce(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectCoerceTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectCoerce(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectCoerce(b *testing.B, tf *objectCoerce, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectCoerce(b *testing.B) {
+	for _, test := range objectCoerceTests {
+		tf, err := newObjectCoerce(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectCoerce(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -11,6 +11,12 @@ import (
 )
 
 type objectCopyConfig struct {
+	// NoOverwrite determines if the value is not copied when the target key
+	// already exists. This can be used to set default values.
+	//
+	// This is optional and defaults to false (values are overwritten).
+	NoOverwrite bool `json:"no_overwrite"`
+
 	Object iconfig.Object `json:"object"`
 }
 
@@ -33,6 +39,9 @@ func newObjectCopy(_ context.Context, cfg config.Config) (*objectCopy, error) {
 	return &tf, nil
 }
 
+// objectCopy copies a value from the source key to the target key. The source
+// key supports all GJSON syntax, so the results of modifiers (e.g., a|@reverse)
+// and queries (e.g., a.#(age>40)#.name) can be copied.
 type objectCopy struct {
 	conf            objectCopyConfig
 	hasObjectKey    bool
@@ -72,6 +81,10 @@ func (tf *objectCopy) Transform(ctx context.Context, msg *message.Message) ([]*m
 		return []*message.Message{msg}, nil
 	}
 
+	if tf.conf.NoOverwrite && msg.GetValue(tf.conf.Object.TargetKey).Exists() {
+		return []*message.Message{msg}, nil
+	}
+
 	if err := msg.SetValue(tf.conf.Object.TargetKey, value); err != nil {
 		return nil, fmt.Errorf("transform: object_copy: %v", err)
 	}
@@ -132,6 +132,84 @@ var objectCopyTests = []struct {
 			[]byte(`{"a":"eJwFwDENAAAAwjCtTAL+j6YdAl0BNg=="}`),
 		},
 	},
+	{
+		"no_overwrite",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+				"no_overwrite": true,
+			},
+		},
+		[]byte(`{"a":"b","c":"d"}`),
+		[][]byte{
+			[]byte(`{"a":"b","c":"d"}`),
+		},
+	},
+	{
+		"no_overwrite missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+				"no_overwrite": true,
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b","c":"b"}`),
+		},
+	},
+	// GJSON syntax tests
+	{
+		"modifier",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a|@reverse",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":[1,2,3]}`),
+		[][]byte{
+			[]byte(`{"a":[1,2,3],"b":[3,2,1]}`),
+		},
+	},
+	{
+		"query",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a.#(age>40)#.name",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":[{"name":"x","age":30},{"name":"y","age":50}]}`),
+		[][]byte{
+			[]byte(`{"a":[{"name":"x","age":30},{"name":"y","age":50}],"b":["y"]}`),
+		},
+	},
+	{
+		"multipath",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "{a,c}",
+					"target_key": "d",
+				},
+			},
+		},
+		[]byte(`{"a":1,"b":2,"c":3}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":2,"c":3,"d":{"a":1,"c":3}}`),
+		},
+	},
 }
 
 func TestObjectCopy(t *testing.T) {
@@ -0,0 +1,187 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectDiffConfig struct {
+	// BeforeKey retrieves the object before it was changed.
+	BeforeKey string `json:"before_key"`
+	// AfterKey retrieves the object after it was changed.
+	AfterKey string `json:"after_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectDiffConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectDiffConfig) Validate() error {
+	if c.BeforeKey == "" {
+		return fmt.Errorf("before_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.AfterKey == "" {
+		return fmt.Errorf("after_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectDiff(_ context.Context, cfg config.Config) (*objectDiff, error) {
+	conf := objectDiffConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	tf := objectDiff{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// objectDiff compares two objects and puts the difference between them into
+// the target key. The difference has this structure, where each section
+// mirrors the structure of the objects and is omitted if it is empty:
+//
+//	{
+//		"added": {"a": 1},
+//		"removed": {"b": {"c": 2}},
+//		"changed": {"d": {"before": 3, "after": 4}}
+//	}
+//
+// Nested objects are compared recursively and arrays are compared as
+// single values. If one of the objects does not exist (e.g., an insert or
+// delete), then it is treated as an empty object.
+type objectDiff struct {
+	conf objectDiffConfig
+}
+
+func (tf *objectDiff) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	before := msg.GetValue(tf.conf.BeforeKey)
+	after := msg.GetValue(tf.conf.AfterKey)
+	if !before.Exists() && !after.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := objDiffObject(before)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	a, err := objDiffObject(after)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	diff := make(map[string]interface{})
+	added, removed, changed := objDiff(b, a)
+	if len(added) > 0 {
+		diff["added"] = added
+	}
+
+	if len(removed) > 0 {
+		diff["removed"] = removed
+	}
+
+	if len(changed) > 0 {
+		diff["changed"] = changed
+	}
+
+	out, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out)); err != nil {
+		return nil, fmt.Errorf("transform: object_diff: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectDiff) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// objDiffObject returns the value as an object. Values that do not exist or
+// are not objects are empty objects.
+func objDiffObject(v message.Value) (map[string]interface{}, error) {
+	if !v.IsObject() {
+		return map[string]interface{}{}, nil
+	}
+
+	doc, err := objPatchDecode(v.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.(map[string]interface{}), nil
+}
+
+func objDiff(before, after map[string]interface{}) (added, removed, changed map[string]interface{}) {
+	added = make(map[string]interface{})
+	removed = make(map[string]interface{})
+	changed = make(map[string]interface{})
+
+	for k, bv := range before {
+		av, ok := after[k]
+		if !ok {
+			removed[k] = bv
+			continue
+		}
+
+		bm, bOk := bv.(map[string]interface{})
+		am, aOk := av.(map[string]interface{})
+		if bOk && aOk {
+			add, rem, chg := objDiff(bm, am)
+			if len(add) > 0 {
+				added[k] = add
+			}
+
+			if len(rem) > 0 {
+				removed[k] = rem
+			}
+
+			if len(chg) > 0 {
+				changed[k] = chg
+			}
+
+			continue
+		}
+
+		if !objPatchEqual(bv, av) {
+			changed[k] = map[string]interface{}{"before": bv, "after": av}
+		}
+	}
+
+	for k, av := range after {
+		if _, ok := before[k]; !ok {
+			added[k] = av
+		}
+	}
+
+	return added, removed, changed
+}
@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectDiff{}
+
+var objectDiffTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"before":{"a":1,"b":2,"c":[1]},"after":{"a":1,"b":3,"c":[1,2],"d":"x"}}`),
+		[][]byte{
+			[]byte(`{"before":{"a":1,"b":2,"c":[1]},"after":{"a":1,"b":3,"c":[1,2],"d":"x"},"diff":{"added":{"d":"x"},"changed":{"b":{"after":3,"before":2},"c":{"after":[1,2],"before":[1]}}}}`),
+		},
+	},
+	{
+		"object nested",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"before":{"a":{"b":1,"c":2}},"after":{"a":{"b":1,"d":3}}}`),
+		[][]byte{
+			[]byte(`{"before":{"a":{"b":1,"c":2}},"after":{"a":{"b":1,"d":3}},"diff":{"added":{"a":{"d":3}},"removed":{"a":{"c":2}}}}`),
+		},
+	},
+	{
+		"object type change",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"before":{"a":{"b":1}},"after":{"a":1.0}}`),
+		[][]byte{
+			[]byte(`{"before":{"a":{"b":1}},"after":{"a":1.0},"diff":{"changed":{"a":{"after":1.0,"before":{"b":1}}}}}`),
+		},
+	},
+	{
+		"object insert",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"after":{"a":1}}`),
+		[][]byte{
+			[]byte(`{"after":{"a":1},"diff":{"added":{"a":1}}}`),
+		},
+	},
+	{
+		"object equal",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"before":{"a":1},"after":{"a":1.0}}`),
+		[][]byte{
+			[]byte(`{"before":{"a":1},"after":{"a":1.0},"diff":{}}`),
+		},
+	},
+	{
+		"object missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"before_key": "before",
+				"after_key":  "after",
+				"object": map[string]interface{}{
+					"target_key": "diff",
+				},
+			},
+		},
+		[]byte(`{"a":1}`),
+		[][]byte{
+			[]byte(`{"a":1}`),
+		},
+	},
+}
+
+func TestObjectDiff(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectDiffTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectDiff(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectDiff(b *testing.B, tf *objectDiff, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectDiff(b *testing.B) {
+	for _, test := range objectDiffTests {
+		tf, err := newObjectDiff(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectDiff(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,139 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectEnvelopeConfig struct {
+	// Key is the key in the envelope where the data is put.
+	//
+	// This is optional and defaults to "data".
+	Key string `json:"key"`
+	// Fields are static values that are put into the envelope (e.g.,
+	// {"source":"app"}).
+	//
+	// This is optional and has no default.
+	Fields map[string]interface{} `json:"fields"`
+	// Keys maps keys in the envelope to keys in the message that their values
+	// are copied from. Values are copied before the data is wrapped and can be
+	// copied from metadata (e.g., {"ts":"meta timestamp"}).
+	//
+	// This is optional and has no default.
+	Keys map[string]string `json:"keys"`
+	// Unwrap determines if the data is replaced with the value of Key, which
+	// removes the envelope.
+	//
+	// This is optional and defaults to false.
+	Unwrap bool `json:"unwrap"`
+}
+
+func (c *objectEnvelopeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectEnvelopeConfig) Validate() error {
+	if c.Unwrap && (len(c.Fields) > 0 || len(c.Keys) > 0) {
+		return fmt.Errorf("unwrap: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newObjectEnvelope(_ context.Context, cfg config.Config) (*objectEnvelope, error) {
+	conf := objectEnvelopeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_envelope: %v", err)
+	}
+
+	if conf.Key == "" {
+		conf.Key = "data"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_envelope: %v", err)
+	}
+
+	tf := objectEnvelope{
+		conf:   conf,
+		fields: make([]string, 0, len(conf.Fields)),
+		keys:   make([]string, 0, len(conf.Keys)),
+	}
+
+	// Envelope keys are sorted so that the output is deterministic.
+	for k := range conf.Fields {
+		tf.fields = append(tf.fields, k)
+	}
+
+	for k := range conf.Keys {
+		tf.keys = append(tf.keys, k)
+	}
+
+	sort.Strings(tf.fields)
+	sort.Strings(tf.keys)
+
+	return &tf, nil
+}
+
+// objectEnvelope wraps data in an object that contains other values, which
+// is required by some destinations (e.g., {"data":{...},"source":"app"}).
+// Data that is not JSON is put into the envelope as a string.
+type objectEnvelope struct {
+	conf objectEnvelopeConfig
+
+	fields []string
+	keys   []string
+}
+
+func (tf *objectEnvelope) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Unwrap {
+		value := msg.GetValue(tf.conf.Key)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		msg.SetData(value.Bytes())
+		return []*message.Message{msg}, nil
+	}
+
+	env := message.New()
+	if err := env.SetValue(tf.conf.Key, msg.Data()); err != nil {
+		return nil, fmt.Errorf("transform: object_envelope: %v", err)
+	}
+
+	for _, k := range tf.fields {
+		if err := env.SetValue(k, tf.conf.Fields[k]); err != nil {
+			return nil, fmt.Errorf("transform: object_envelope: %v", err)
+		}
+	}
+
+	for _, k := range tf.keys {
+		value := msg.GetValue(tf.conf.Keys[k])
+		if !value.Exists() {
+			continue
+		}
+
+		if err := env.SetValue(k, value); err != nil {
+			return nil, fmt.Errorf("transform: object_envelope: %v", err)
+		}
+	}
+
+	msg.SetData(env.Data())
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectEnvelope) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
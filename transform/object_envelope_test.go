@@ -0,0 +1,132 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectEnvelope{}
+
+var objectEnvelopeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"wrap",
+		config.Config{},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"data":{"a":"b"}}`),
+		},
+	},
+	{
+		"wrap string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key": "message",
+			},
+		},
+		[]byte(`a b c`),
+		[][]byte{
+			[]byte(`{"message":"a b c"}`),
+		},
+	},
+	{
+		"wrap fields",
+		config.Config{
+			Settings: map[string]interface{}{
+				"fields": map[string]interface{}{
+					"source":  "app",
+					"version": 2,
+				},
+				"keys": map[string]string{
+					"id": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"data":{"a":"b"},"source":"app","version":2,"id":"b"}`),
+		},
+	},
+	{
+		"unwrap",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+			},
+		},
+		[]byte(`{"data":{"a":"b"},"source":"app"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+	{
+		"unwrap missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+}
+
+func TestObjectEnvelope(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectEnvelopeTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectEnvelope(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectEnvelope(b *testing.B, tf *objectEnvelope, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectEnvelope(b *testing.B) {
+	for _, test := range objectEnvelopeTests {
+		tf, err := newObjectEnvelope(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectEnvelope(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	iexpr "github.com/brexhq/substation/internal/expr"
+	"github.com/brexhq/substation/message"
+)
+
+// errObjectExpressionNotFinite is returned when the expression evaluates to
+// NaN or infinity (e.g., division by zero), which cannot be encoded as JSON.
+var errObjectExpressionNotFinite = fmt.Errorf("result is not a finite number")
+
+type objectExpressionConfig struct {
+	// Expression is the expression that is evaluated against the message.
+	// Keys in the object are referenced by name (e.g., "(a + b) / c") and
+	// nested keys are referenced using dot notation (e.g., "a.b * 2").
+	//
+	// The expression supports arithmetic operators (+, -, *, /, %, **),
+	// comparison operators (==, !=, <, <=, >, >=), logical operators (&&,
+	// ||, !), and parentheses. These functions are supported:
+	//	- abs, ceil, floor, round
+	//	- min, max, sum, mean, median
+	//	- int, float, string, len
+	//	- lower, upper, trim
+	//
+	// Refer to https://expr-lang.org/docs/language-definition for the
+	// full language definition.
+	Expression string `json:"expression"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectExpressionConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectExpressionConfig) Validate() error {
+	if c.Expression == "" {
+		return fmt.Errorf("expression: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectExpression(_ context.Context, cfg config.Config) (*objectExpression, error) {
+	conf := objectExpressionConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	prog, err := iexpr.Compile(conf.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	tf := objectExpression{
+		conf:     conf,
+		isObject: conf.Object.TargetKey != "",
+		prog:     prog,
+	}
+
+	return &tf, nil
+}
+
+// objectExpression evaluates an expression against an object and puts the
+// result into the message. The expression is compiled once when the
+// transform is created.
+type objectExpression struct {
+	conf     objectExpressionConfig
+	isObject bool
+
+	prog *vm.Program
+}
+
+func (tf *objectExpression) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	env, err := iexpr.Env(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	res, err := iexpr.Run(tf.prog, env)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	// Floats are normalized the same way as the number_math transforms.
+	if f, ok := res.(float64); ok {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("transform: object_expression: %v", errObjectExpressionNotFinite)
+		}
+
+		res, err = strconv.ParseFloat(numberFloat64ToString(f), 64)
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_expression: %v", err)
+		}
+	}
+
+	if !tf.isObject {
+		b, err := json.Marshal(res)
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_expression: %v", err)
+		}
+
+		msg.SetData(b)
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, res); err != nil {
+		return nil, fmt.Errorf("transform: object_expression: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectExpression) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,218 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectExpression{}
+
+var objectExpressionTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "(bytes_in + bytes_out) / duration",
+			},
+		},
+		[]byte(`{"bytes_in":100,"bytes_out":50,"duration":4}`),
+		[][]byte{
+			[]byte(`37.5`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "(a + b) / d",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":1,"b":2,"d":4}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":2,"d":4,"c":0.75}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "a % 3",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":10}`),
+		[][]byte{
+			[]byte(`{"a":10,"c":1}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "max(a.b, a.d) - min(a.b, a.d)",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":5,"d":2.5}}`),
+		[][]byte{
+			[]byte(`{"a":{"b":5,"d":2.5},"c":2.5}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "abs(a) * 2",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":-1.5}`),
+		[][]byte{
+			[]byte(`{"a":-1.5,"c":3}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "a > 10 && b == \"d\"",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":11,"b":"d"}`),
+		[][]byte{
+			[]byte(`{"a":11,"b":"d","c":true}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"expression": "0.1 + 0.2",
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{}`),
+		[][]byte{
+			[]byte(`{"c":0.30000000000000004}`),
+		},
+	},
+}
+
+func TestObjectExpression(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectExpressionTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectExpression(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectExpression(b *testing.B, tf *objectExpression, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectExpression(b *testing.B) {
+	for _, test := range objectExpressionTests {
+		tf, err := newObjectExpression(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectExpression(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestObjectExpressionInvalid(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newObjectExpression(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"expression": "a + 1",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"b":1}`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestObjectExpressionNotFinite(t *testing.T) {
+	tests := []string{
+		"a / b",
+		"-a / b",
+		"b / b",
+	}
+
+	ctx := context.TODO()
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			tf, err := newObjectExpression(ctx, config.Config{
+				Settings: map[string]interface{}{
+					"expression": test,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData([]byte(`{"a":1,"b":0}`))
+			if _, err := tf.Transform(ctx, msg); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
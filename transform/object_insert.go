@@ -14,6 +14,11 @@ import (
 type objectInsertConfig struct {
 	// Value inserted into the object.
 	Value interface{} `json:"value"`
+	// NoOverwrite determines if the value is not inserted when the target key
+	// already exists. This can be used to set default values.
+	//
+	// This is optional and defaults to false (values are overwritten).
+	NoOverwrite bool `json:"no_overwrite"`
 
 	Object iconfig.Object `json:"object"`
 }
@@ -60,6 +65,10 @@ func (tf *objectInsert) Transform(ctx context.Context, msg *message.Message) ([]
 		return []*message.Message{msg}, nil
 	}
 
+	if tf.conf.NoOverwrite && msg.GetValue(tf.conf.Object.TargetKey).Exists() {
+		return []*message.Message{msg}, nil
+	}
+
 	if err := msg.SetValue(tf.conf.Object.TargetKey, tf.conf.Value); err != nil {
 		return nil, fmt.Errorf("transform: object_insert: %v", err)
 	}
@@ -105,6 +105,36 @@ var objectInsertTests = []struct {
 			[]byte(`{"a":"eJwFwDENAAAAwjCtTAL+j6YdAl0BNg=="}`),
 		},
 	},
+	{
+		"no_overwrite",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "environment",
+				}, "value": "production",
+				"no_overwrite": true,
+			},
+		},
+		[]byte(`{"environment":"staging"}`),
+		[][]byte{
+			[]byte(`{"environment":"staging"}`),
+		},
+	},
+	{
+		"no_overwrite missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "environment",
+				}, "value": "production",
+				"no_overwrite": true,
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b","environment":"production"}`),
+		},
+	},
 }
 
 func TestObjectInsert(t *testing.T) {
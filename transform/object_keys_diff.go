@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectKeysDiffConfig struct {
+	// Keys are the keys that are expected to be in the object. Nested keys
+	// use dot notation (e.g., "a.b").
+	Keys []string `json:"keys"`
+	// MissingKey is the key where an array of expected keys that are not in
+	// the object is put.
+	//
+	// This is optional and has no default (missing keys are not put into the
+	// object).
+	MissingKey string `json:"missing_key"`
+	// ExtraKey is the key where an array of keys that are in the object but
+	// are not expected is put.
+	//
+	// This is optional and has no default (extra keys are not put into the
+	// object).
+	ExtraKey string `json:"extra_key"`
+	// Object.SourceKey retrieves the object that is compared. If not set, then
+	// the entire message is compared.
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectKeysDiffConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectKeysDiffConfig) Validate() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("keys: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.MissingKey == "" && c.ExtraKey == "" {
+		return fmt.Errorf("missing_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectKeysDiff(_ context.Context, cfg config.Config) (*objectKeysDiff, error) {
+	conf := objectKeysDiffConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_keys_diff: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_keys_diff: %v", err)
+	}
+
+	tf := objectKeysDiff{
+		conf:     conf,
+		expected: make(map[string]bool),
+		parents:  make(map[string]bool),
+	}
+
+	for _, k := range conf.Keys {
+		tf.expected[k] = true
+
+		// Parents of nested keys are expected, so they are never extra keys.
+		parts := strings.Split(k, ".")
+		for i := 1; i < len(parts); i++ {
+			p := strings.Join(parts[:i], ".")
+			tf.expected[p] = true
+			tf.parents[p] = true
+		}
+	}
+
+	return &tf, nil
+}
+
+// objectKeysDiff compares the keys in an object to a list of expected keys
+// and puts the keys that are missing and the keys that are not expected into
+// the message. Nested objects are only compared if the list contains their
+// keys (e.g., if "a.b" is expected, then "a.c" is an extra key, but if
+// "a" is expected, then any keys in "a" are allowed). Keys are sorted, and if
+// there are no differences, then empty arrays are put into the message.
+type objectKeysDiff struct {
+	conf     objectKeysDiffConfig
+	expected map[string]bool
+	parents  map[string]bool
+}
+
+func (tf *objectKeysDiff) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	prefix := ""
+	value := bytesToValue(msg.Data())
+	if tf.conf.Object.SourceKey != "" {
+		prefix = tf.conf.Object.SourceKey + "."
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	}
+
+	if !value.IsObject() {
+		return []*message.Message{msg}, nil
+	}
+
+	missing := []string{}
+	for _, k := range tf.conf.Keys {
+		if !msg.GetValue(prefix + k).Exists() {
+			missing = append(missing, k)
+		}
+	}
+
+	extra := []string{}
+	tf.extra(value, "", &extra)
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	if tf.conf.MissingKey != "" {
+		if err := msg.SetValue(tf.conf.MissingKey, missing); err != nil {
+			return nil, fmt.Errorf("transform: object_keys_diff: %v", err)
+		}
+	}
+
+	if tf.conf.ExtraKey != "" {
+		if err := msg.SetValue(tf.conf.ExtraKey, extra); err != nil {
+			return nil, fmt.Errorf("transform: object_keys_diff: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectKeysDiff) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *objectKeysDiff) extra(value message.Value, prefix string, out *[]string) {
+	for k, v := range value.Map() {
+		key := prefix + k
+		if !tf.expected[key] {
+			*out = append(*out, key)
+			continue
+		}
+
+		if tf.parents[key] && v.IsObject() {
+			tf.extra(v, key+".", out)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectKeysDiff{}
+
+var objectKeysDiffTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":        []string{"a", "b.c", "b.d"},
+				"missing_key": "missing",
+				"extra_key":   "extra",
+			},
+		},
+		[]byte(`{"a":{"x":1},"b":{"c":1,"e":2},"f":3}`),
+		[][]byte{
+			[]byte(`{"a":{"x":1},"b":{"c":1,"e":2},"f":3,"missing":["b.d"],"extra":["b.e","f"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":        []string{"a", "b.c", "b.d"},
+				"missing_key": "missing",
+				"extra_key":   "extra",
+			},
+		},
+		[]byte(`{"a":1,"b":{"c":1,"d":null}}`),
+		[][]byte{
+			[]byte(`{"a":1,"b":{"c":1,"d":null},"missing":[],"extra":[]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":        []string{"a", "b.c", "b.d"},
+				"missing_key": "missing",
+				"extra_key":   "extra",
+			},
+		},
+		[]byte(`{"b":"c"}`),
+		[][]byte{
+			[]byte(`{"b":"c","missing":["a","b.c","b.d"],"extra":[]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":        []string{"a", "b.c", "b.d"},
+				"missing_key": "missing",
+				"extra_key":   "extra",
+			},
+		},
+		[]byte(`[1,2]`),
+		[][]byte{
+			[]byte(`[1,2]`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys":        []string{"a"},
+				"missing_key": "missing",
+				"object": map[string]interface{}{
+					"source_key": "x",
+				},
+			},
+		},
+		[]byte(`{"x":{"b":1}}`),
+		[][]byte{
+			[]byte(`{"x":{"b":1},"missing":["a"]}`),
+		},
+	},
+}
+
+func TestObjectKeysDiff(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectKeysDiffTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectKeysDiff(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectKeysDiff(b *testing.B, tf *objectKeysDiff, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectKeysDiff(b *testing.B) {
+	for _, test := range objectKeysDiffTests {
+		tf, err := newObjectKeysDiff(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectKeysDiff(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,103 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectLengthConfig struct {
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectLengthConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectLengthConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectLength(_ context.Context, cfg config.Config) (*objectLength, error) {
+	conf := objectLengthConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_length: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_length: %v", err)
+	}
+
+	tf := objectLength{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type objectLength struct {
+	conf     objectLengthConfig
+	isObject bool
+}
+
+func (tf *objectLength) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	// The length of arrays is the number of elements, the length of objects
+	// is the number of keys, and the length of all other values is the number
+	// of characters in the value.
+	var v int
+	switch {
+	case value.IsArray():
+		v = len(value.Array())
+	case value.IsObject():
+		v = len(value.Map())
+	default:
+		v = utf8.RuneCountInString(value.String())
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: object_length: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(strconv.Itoa(v)))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectLength) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,133 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectLength{}
+
+var objectLengthTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data array",
+		config.Config{},
+		[]byte(`[1,2,3]`),
+		[][]byte{
+			[]byte(`3`),
+		},
+	},
+	{
+		"data string",
+		config.Config{},
+		[]byte(`ñandú`),
+		[][]byte{
+			[]byte(`5`),
+		},
+	},
+	// object tests
+	{
+		"object array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":["x","y"]}`),
+		[][]byte{
+			[]byte(`{"a":["x","y"],"b":2}`),
+		},
+	},
+	{
+		"object object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":{"x":1,"y":2,"z":3}}`),
+		[][]byte{
+			[]byte(`{"a":{"x":1,"y":2,"z":3},"b":3}`),
+		},
+	},
+	{
+		"object string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"abcd"}`),
+		[][]byte{
+			[]byte(`{"a":"abcd","b":4}`),
+		},
+	},
+}
+
+func TestObjectLength(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectLengthTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectLength(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectLength(b *testing.B, tf *objectLength, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectLength(b *testing.B) {
+	for _, test := range objectLengthTests {
+		tf, err := newObjectLength(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectLength(b, tf, test.test)
+			},
+		)
+	}
+}
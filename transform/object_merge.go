@@ -0,0 +1,237 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectMergeConfig struct {
+	// Value is the object that is merged into the target object. If the source
+	// key is set, then the value of the source key is merged instead.
+	//
+	// This is optional if the source key is set.
+	Value map[string]interface{} `json:"value"`
+	// Strategy determines how nested objects are merged.
+	//
+	// Must be one of:
+	//	- deep: nested objects are merged recursively
+	//	- shallow: only top-level keys are merged, so nested objects are
+	//	replaced
+	//
+	// This is optional and defaults to deep.
+	Strategy string `json:"strategy"`
+	// NoOverwrite determines if values that already exist in the target object
+	// are preserved. This can be used to set default values.
+	//
+	// This is optional and defaults to false (values are overwritten).
+	NoOverwrite bool `json:"no_overwrite"`
+	// Arrays determines how arrays are merged when both objects contain an
+	// array with the same key.
+	//
+	// Must be one of:
+	//	- replace: the array is replaced
+	//	- concat: the arrays are concatenated
+	//
+	// This is optional and defaults to replace.
+	Arrays string `json:"arrays"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectMergeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectMergeConfig) Validate() error {
+	if c.Value == nil && c.Object.SourceKey == "" {
+		return fmt.Errorf("value: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Value != nil && c.Object.SourceKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"deep",
+			"shallow",
+		},
+		c.Strategy) {
+		return fmt.Errorf("strategy %q: %v", c.Strategy, errors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"replace",
+			"concat",
+		},
+		c.Arrays) {
+		return fmt.Errorf("arrays %q: %v", c.Arrays, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newObjectMerge(_ context.Context, cfg config.Config) (*objectMerge, error) {
+	conf := objectMergeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_merge: %v", err)
+	}
+
+	if conf.Strategy == "" {
+		conf.Strategy = "deep"
+	}
+
+	if conf.Arrays == "" {
+		conf.Arrays = "replace"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_merge: %v", err)
+	}
+
+	tf := objectMerge{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// objectMerge merges an object into the message. The object is either a
+// static value or the value of the source key, which can be used to layer
+// objects within the message (e.g., merging "overrides" into "base"). If the
+// target key is not set, then the object is merged into the root of the
+// message. If the value of the target key is not an object, then it is
+// replaced (or preserved, if NoOverwrite is set).
+type objectMerge struct {
+	conf objectMergeConfig
+}
+
+func (tf *objectMerge) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var src map[string]interface{}
+	if tf.conf.Object.SourceKey != "" {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.IsObject() {
+			return []*message.Message{msg}, nil
+		}
+
+		v, err := objPatchDecode([]byte(value.String()))
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_merge: %v", err)
+		}
+
+		src = v.(map[string]interface{})
+	} else {
+		// The configured value is copied so that it is never modified by a merge.
+		v, err := objMergeCopy(tf.conf.Value)
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_merge: %v", err)
+		}
+
+		src = v
+	}
+
+	var b []byte
+	if tf.conf.Object.TargetKey != "" {
+		value := msg.GetValue(tf.conf.Object.TargetKey)
+		if value.Exists() && !value.IsObject() && tf.conf.NoOverwrite {
+			return []*message.Message{msg}, nil
+		}
+
+		if value.IsObject() {
+			b = []byte(value.String())
+		}
+	} else {
+		b = msg.Data()
+	}
+
+	dst := make(map[string]interface{})
+	if len(b) != 0 {
+		v, err := objPatchDecode(b)
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_merge: %v", err)
+		}
+
+		// Data that is not an object is replaced.
+		if m, ok := v.(map[string]interface{}); ok {
+			dst = m
+		}
+	}
+
+	out, err := json.Marshal(tf.merge(dst, src))
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_merge: %v", err)
+	}
+
+	if tf.conf.Object.TargetKey != "" {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out)); err != nil {
+			return nil, fmt.Errorf("transform: object_merge: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectMerge) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *objectMerge) merge(dst, src map[string]interface{}) map[string]interface{} {
+	for k, sv := range src {
+		dv, ok := dst[k]
+		if !ok {
+			dst[k] = sv
+			continue
+		}
+
+		dm, dIsMap := dv.(map[string]interface{})
+		sm, sIsMap := sv.(map[string]interface{})
+		if tf.conf.Strategy == "deep" && dIsMap && sIsMap {
+			dst[k] = tf.merge(dm, sm)
+			continue
+		}
+
+		da, dIsArr := dv.([]interface{})
+		sa, sIsArr := sv.([]interface{})
+		if tf.conf.Arrays == "concat" && dIsArr && sIsArr {
+			dst[k] = append(da, sa...)
+			continue
+		}
+
+		if !tf.conf.NoOverwrite {
+			dst[k] = sv
+		}
+	}
+
+	return dst
+}
+
+func objMergeCopy(m map[string]interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := objPatchDecode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(map[string]interface{}), nil
+}
@@ -0,0 +1,224 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectMerge{}
+
+var objectMergeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"deep",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": map[string]interface{}{
+						"c": 3,
+					},
+					"d": "e",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1,"c":2},"d":"f"}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1,"c":3},"d":"e"}`),
+		},
+	},
+	{
+		"shallow",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": map[string]interface{}{
+						"c": 3,
+					},
+					"d": "e",
+				},
+				"strategy": "shallow",
+			},
+		},
+		[]byte(`{"a":{"b":1,"c":2},"d":"f"}`),
+		[][]byte{
+			[]byte(`{"a":{"c":3},"d":"e"}`),
+		},
+	},
+	{
+		"no_overwrite",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": map[string]interface{}{
+						"c": 3,
+					},
+					"d": "e",
+				},
+				"no_overwrite": true,
+			},
+		},
+		[]byte(`{"a":{"b":1,"c":2}}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1,"c":2},"d":"e"}`),
+		},
+	},
+	{
+		"target_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": map[string]interface{}{
+						"c": 3,
+					},
+					"d": "e",
+				},
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+			},
+		},
+		[]byte(`{"x":{"a":{"b":1}},"y":1}`),
+		[][]byte{
+			[]byte(`{"x":{"a":{"b":1,"c":3},"d":"e"},"y":1}`),
+		},
+	},
+	{
+		"target_key missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": map[string]interface{}{
+						"c": 3,
+					},
+					"d": "e",
+				},
+				"object": map[string]interface{}{
+					"target_key": "x",
+				},
+			},
+		},
+		[]byte(`{"y":1}`),
+		[][]byte{
+			[]byte(`{"y":1,"x":{"a":{"c":3},"d":"e"}}`),
+		},
+	},
+	{
+		"arrays concat",
+		config.Config{
+			Settings: map[string]interface{}{
+				"value": map[string]interface{}{
+					"a": []interface{}{3},
+				},
+				"arrays": "concat",
+			},
+		},
+		[]byte(`{"a":[1,2]}`),
+		[][]byte{
+			[]byte(`{"a":[1,2,3]}`),
+		},
+	},
+	{
+		"source_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "overrides",
+					"target_key": "base",
+				},
+			},
+		},
+		[]byte(`{"base":{"a":{"b":1,"c":2},"d":[1]},"overrides":{"a":{"c":3},"d":[2]}}`),
+		[][]byte{
+			[]byte(`{"base":{"a":{"b":1,"c":3},"d":[2]},"overrides":{"a":{"c":3},"d":[2]}}`),
+		},
+	},
+	{
+		"source_key concat",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "overrides",
+					"target_key": "base",
+				},
+				"arrays": "concat",
+			},
+		},
+		[]byte(`{"base":{"d":[1]},"overrides":{"d":[2]}}`),
+		[][]byte{
+			[]byte(`{"base":{"d":[1,2]},"overrides":{"d":[2]}}`),
+		},
+	},
+	{
+		"source_key missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "overrides",
+					"target_key": "base",
+				},
+			},
+		},
+		[]byte(`{"base":{"a":1}}`),
+		[][]byte{
+			[]byte(`{"base":{"a":1}}`),
+		},
+	},
+}
+
+func TestObjectMerge(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectMergeTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectMerge(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectMerge(b *testing.B, tf *objectMerge, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectMerge(b *testing.B) {
+	for _, test := range objectMergeTests {
+		tf, err := newObjectMerge(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectMerge(b, tf, test.test)
+			},
+		)
+	}
+}
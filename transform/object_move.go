@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectMoveConfig struct {
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectMoveConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectMoveConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectMove(_ context.Context, cfg config.Config) (*objectMove, error) {
+	conf := objectMoveConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_move: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_move: %v", err)
+	}
+
+	tf := objectMove{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// objectMove copies a value to the target key and then deletes it from the
+// source key. Keys prefixed with "meta" move values between data and metadata,
+// which keeps routing information (e.g., partition keys used as batch keys in
+// send transforms) out of the data.
+type objectMove struct {
+	conf objectMoveConfig
+}
+
+func (tf *objectMove) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, value); err != nil {
+		return nil, fmt.Errorf("transform: object_move: %v", err)
+	}
+
+	if err := msg.DeleteValue(tf.conf.Object.SourceKey); err != nil {
+		return nil, fmt.Errorf("transform: object_move: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectMove) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectMove{}
+
+var objectMoveTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"c":"b"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "c.d",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1},"e":2}`),
+		[][]byte{
+			[]byte(`{"e":2,"c":{"d":{"b":1}}}`),
+		},
+	},
+	{
+		"metadata",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "meta a",
+				},
+			},
+		},
+		[]byte(`{"a":"b","c":"d"}`),
+		[][]byte{
+			[]byte(`{"c":"d"}`),
+		},
+	},
+	{
+		"missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "x",
+					"target_key": "y",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+}
+
+func TestObjectMove(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectMoveTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectMove(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func TestObjectMoveMetadata(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newObjectMove(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"object": map[string]interface{}{
+				"source_key": "a",
+				"target_key": "meta b",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":"c"}`))
+	result, err := tf.Transform(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"b":"c"}`
+	if string(result[0].Metadata()) != expected {
+		t.Errorf("expected %s, got %s", expected, result[0].Metadata())
+	}
+}
+
+func benchmarkObjectMove(b *testing.B, tf *objectMove, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectMove(b *testing.B) {
+	for _, test := range objectMoveTests {
+		tf, err := newObjectMove(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectMove(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,443 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// errObjectPatchTestFailed is returned when the value of a test operation does
+// not match the value in the object.
+var errObjectPatchTestFailed = fmt.Errorf("test operation failed")
+
+// errObjectPatchPathNotFound is returned when the path of an operation does not
+// exist in the object.
+var errObjectPatchPathNotFound = fmt.Errorf("path not found")
+
+type objectPatchOperation struct {
+	// Op is the operation that is applied to the object.
+	//
+	// Must be one of: add, remove, replace, move, copy, test.
+	Op string `json:"op"`
+	// Path is the JSON Pointer (RFC 6901) that the operation is applied to.
+	Path string `json:"path"`
+	// From is the JSON Pointer that values are moved or copied from. This is
+	// only used by the move and copy operations.
+	From string `json:"from"`
+	// Value is the value that is used by the add, replace, and test operations.
+	Value json.RawMessage `json:"value"`
+}
+
+type objectPatchConfig struct {
+	// Operations is a JSON Patch (RFC 6902) document that is applied to the
+	// object. Operations are applied in order.
+	Operations []objectPatchOperation `json:"operations"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectPatchConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectPatchConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if len(c.Operations) == 0 {
+		return fmt.Errorf("operations: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, op := range c.Operations {
+		if !slices.Contains(
+			[]string{
+				"add",
+				"remove",
+				"replace",
+				"move",
+				"copy",
+				"test",
+			},
+			op.Op) {
+			return fmt.Errorf("op %q: %v", op.Op, errors.ErrInvalidOption)
+		}
+
+		if op.Path != "" && !strings.HasPrefix(op.Path, "/") {
+			return fmt.Errorf("path %q: %v", op.Path, errors.ErrInvalidOption)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return fmt.Errorf("%s value: %v", op.Op, errors.ErrMissingRequiredOption)
+			}
+		case "move", "copy":
+			if op.From != "" && !strings.HasPrefix(op.From, "/") {
+				return fmt.Errorf("from %q: %v", op.From, errors.ErrInvalidOption)
+			}
+
+			if op.Op == "move" && strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				return fmt.Errorf("move path %q: %v", op.Path, errors.ErrInvalidOption)
+			}
+		}
+	}
+
+	return nil
+}
+
+func newObjectPatch(_ context.Context, cfg config.Config) (*objectPatch, error) {
+	conf := objectPatchConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_patch: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_patch: %v", err)
+	}
+
+	tf := objectPatch{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// objectPatch applies a JSON Patch (RFC 6902) document to an object. If any
+// operation fails (including a test operation), then the message is not
+// changed and an error is returned.
+type objectPatch struct {
+	conf     objectPatchConfig
+	isObject bool
+}
+
+func (tf *objectPatch) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var b []byte
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		b = []byte(value.String())
+	} else {
+		b = msg.Data()
+	}
+
+	doc, err := objPatchDecode(b)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_patch: %v", err)
+	}
+
+	for _, op := range tf.conf.Operations {
+		doc, err = objPatchApply(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("transform: object_patch: %s %s: %v", op.Op, op.Path, err)
+		}
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_patch: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out)); err != nil {
+			return nil, fmt.Errorf("transform: object_patch: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectPatch) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// objPatchDecode decodes JSON without converting numbers to floats, which
+// preserves large integers.
+func objPatchDecode(b []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func objPatchApply(doc interface{}, op objectPatchOperation) (interface{}, error) {
+	path := objPatchPointer(op.Path)
+
+	switch op.Op {
+	case "add", "replace":
+		v, err := objPatchDecode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return objPatchMutate(doc, path, op.Op, v)
+	case "remove":
+		return objPatchMutate(doc, path, op.Op, nil)
+	case "move", "copy":
+		from := objPatchPointer(op.From)
+
+		v, err := objPatchGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "move" {
+			if doc, err = objPatchMutate(doc, from, "remove", nil); err != nil {
+				return nil, err
+			}
+		} else {
+			// Copied values must not share maps or slices with the source.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			if v, err = objPatchDecode(b); err != nil {
+				return nil, err
+			}
+		}
+
+		return objPatchMutate(doc, path, "add", v)
+	case "test":
+		expected, err := objPatchDecode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := objPatchGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !objPatchEqual(v, expected) {
+			return nil, errObjectPatchTestFailed
+		}
+	}
+
+	return doc, nil
+}
+
+// objPatchPointer splits a JSON Pointer into reference tokens.
+func objPatchPointer(p string) []string {
+	if p == "" {
+		return nil
+	}
+
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+
+	return tokens
+}
+
+// objPatchIndex returns the array index of a reference token. Indexes with
+// leading zeros are invalid.
+func objPatchIndex(tok string, length int) (int, error) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, errObjectPatchPathNotFound
+	}
+
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 || i >= length {
+		return 0, errObjectPatchPathNotFound
+	}
+
+	return i, nil
+}
+
+func objPatchGet(node interface{}, tokens []string) (interface{}, error) {
+	for _, tok := range tokens {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[tok]
+			if !ok {
+				return nil, errObjectPatchPathNotFound
+			}
+
+			node = v
+		case []interface{}:
+			i, err := objPatchIndex(tok, len(n))
+			if err != nil {
+				return nil, err
+			}
+
+			node = n[i]
+		default:
+			return nil, errObjectPatchPathNotFound
+		}
+	}
+
+	return node, nil
+}
+
+// objPatchMutate adds, replaces, or removes the value at the path and returns
+// the updated node. Arrays are returned as new slices when their length
+// changes, so the parent of each node is always updated.
+func objPatchMutate(node interface{}, tokens []string, op string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		if op == "remove" {
+			return nil, errObjectPatchPathNotFound
+		}
+
+		return value, nil
+	}
+
+	tok := tokens[0]
+	last := len(tokens) == 1
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		v, ok := n[tok]
+		if !last {
+			if !ok {
+				return nil, errObjectPatchPathNotFound
+			}
+
+			child, err := objPatchMutate(v, tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+
+			n[tok] = child
+			return n, nil
+		}
+
+		switch op {
+		case "add":
+			n[tok] = value
+		case "replace":
+			if !ok {
+				return nil, errObjectPatchPathNotFound
+			}
+
+			n[tok] = value
+		case "remove":
+			if !ok {
+				return nil, errObjectPatchPathNotFound
+			}
+
+			delete(n, tok)
+		}
+
+		return n, nil
+	case []interface{}:
+		if last && op == "add" {
+			if tok == "-" {
+				return append(n, value), nil
+			}
+
+			// Values can be added at the end of the array.
+			i, err := objPatchIndex(tok, len(n)+1)
+			if err != nil {
+				return nil, err
+			}
+
+			return slices.Insert(n, i, value), nil
+		}
+
+		i, err := objPatchIndex(tok, len(n))
+		if err != nil {
+			return nil, err
+		}
+
+		if !last {
+			child, err := objPatchMutate(n[i], tokens[1:], op, value)
+			if err != nil {
+				return nil, err
+			}
+
+			n[i] = child
+			return n, nil
+		}
+
+		if op == "remove" {
+			return slices.Delete(n, i, i+1), nil
+		}
+
+		n[i] = value
+		return n, nil
+	}
+
+	return nil, errObjectPatchPathNotFound
+}
+
+// objPatchEqual compares values using JSON equality, so numbers are equal if
+// they have the same value (e.g., 1 and 1.0).
+func objPatchEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+
+		if a == b {
+			return true
+		}
+
+		af, aErr := a.Float64()
+		bf, bErr := b.Float64()
+		return aErr == nil && bErr == nil && af == bf
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for k, v := range a {
+			bv, ok := b[k]
+			if !ok || !objPatchEqual(v, bv) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !objPatchEqual(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return a == b
+}
@@ -0,0 +1,176 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectPatch{}
+
+var objectPatchTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data add",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "add", "path": "/b", "value": map[string]interface{}{"c": 1}},
+					{"op": "add", "path": "/a/1", "value": "x"},
+					{"op": "add", "path": "/a/-", "value": "z"},
+				},
+			},
+		},
+		[]byte(`{"a":["w","y"]}`),
+		[][]byte{
+			[]byte(`{"a":["w","x","y","z"],"b":{"c":1}}`),
+		},
+	},
+	{
+		"data remove replace",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "remove", "path": "/a/0"},
+					{"op": "replace", "path": "/b~1c", "value": uint64(12345678901234567890)},
+				},
+			},
+		},
+		[]byte(`{"a":[1,2],"b/c":0}`),
+		[][]byte{
+			[]byte(`{"a":[2],"b/c":12345678901234567890}`),
+		},
+	},
+	{
+		"data move copy",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "move", "from": "/a/b", "path": "/c"},
+					{"op": "copy", "from": "/c", "path": "/a/d"},
+				},
+			},
+		},
+		[]byte(`{"a":{"b":[1]}}`),
+		[][]byte{
+			[]byte(`{"a":{"d":[1]},"c":[1]}`),
+		},
+	},
+	{
+		"data test",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "test", "path": "/a", "value": map[string]interface{}{"b": 1.0}},
+					{"op": "remove", "path": "/a"},
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1}}`),
+		[][]byte{
+			[]byte(`{}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "add", "path": "/c", "value": true},
+				},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1}}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1,"c":true}}`),
+		},
+	},
+}
+
+func TestObjectPatch(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectPatchTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectPatch(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectPatch(b *testing.B, tf *objectPatch, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectPatch(b *testing.B) {
+	for _, test := range objectPatchTests {
+		tf, err := newObjectPatch(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectPatch(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestObjectPatchInvalid(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newObjectPatch(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"operations": []map[string]interface{}{
+				{"op": "remove", "path": "/b"},
+				{"op": "test", "path": "/a", "value": "c"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":"b","b":1}`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+
+	// The message is not changed if any operation fails.
+	if string(msg.Data()) != `{"a":"b","b":1}` {
+		t.Errorf("expected unchanged message, got %s", msg.Data())
+	}
+}
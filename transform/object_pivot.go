@@ -0,0 +1,157 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectPivotConfig struct {
+	// NameKey is the key in each element that contains the name of the value.
+	//
+	// This is optional and defaults to "name".
+	NameKey string `json:"name_key"`
+	// ValueKey is the key in each element that contains the value.
+	//
+	// This is optional and defaults to "value".
+	ValueKey string `json:"value_key"`
+	// Duplicates determines how elements with the same name are handled.
+	//
+	// Must be one of:
+	//	- last: the value of the last element is used
+	//	- array: the values of all elements are put into an array
+	//
+	// This is optional and defaults to last.
+	Duplicates string `json:"duplicates"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectPivotConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectPivotConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"last",
+			"array",
+		},
+		c.Duplicates) {
+		return fmt.Errorf("duplicates %q: %v", c.Duplicates, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newObjectPivot(_ context.Context, cfg config.Config) (*objectPivot, error) {
+	conf := objectPivotConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_pivot: %v", err)
+	}
+
+	if conf.NameKey == "" {
+		conf.NameKey = "name"
+	}
+
+	if conf.ValueKey == "" {
+		conf.ValueKey = "value"
+	}
+
+	if conf.Duplicates == "" {
+		conf.Duplicates = "last"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_pivot: %v", err)
+	}
+
+	tf := objectPivot{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// objectPivot converts an array of name and value objects into an object
+// (e.g., [{"name":"a","value":1},{"name":"b","value":2}] is {"a":1,"b":2}).
+// Elements that do not have a name are ignored.
+type objectPivot struct {
+	conf     objectPivotConfig
+	isObject bool
+}
+
+func (tf *objectPivot) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() || !value.IsArray() {
+		return []*message.Message{msg}, nil
+	}
+
+	obj := make(map[string]interface{})
+	for _, elem := range value.Array() {
+		m := elem.Map()
+
+		name, ok := m[tf.conf.NameKey]
+		if !ok {
+			continue
+		}
+
+		key := name.String()
+		val := m[tf.conf.ValueKey].Value()
+
+		if tf.conf.Duplicates == "last" {
+			obj[key] = val
+			continue
+		}
+
+		arr, _ := obj[key].([]interface{})
+		obj[key] = append(arr, val)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_pivot: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: object_pivot: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectPivot) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectPivot{}
+
+var objectPivotTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`[{"name":"x","value":1},{"name":"y","value":{"z":true}}]`),
+		[][]byte{
+			[]byte(`{"x":1,"y":{"z":true}}`),
+		},
+	},
+	{
+		"data keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"name_key":  "Key",
+				"value_key": "Value",
+			},
+		},
+		[]byte(`[{"Key":"env","Value":"prod"},{"Key":"team","Value":"sec"}]`),
+		[][]byte{
+			[]byte(`{"env":"prod","team":"sec"}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[{"name":"x","value":1},{"name":"x","value":2},{"value":3}]}`),
+		[][]byte{
+			[]byte(`{"a":{"x":2}}`),
+		},
+	},
+	{
+		"object duplicates array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"duplicates": "array",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[{"name":"x","value":1},{"name":"y","value":2},{"name":"x","value":3}]}`),
+		[][]byte{
+			[]byte(`{"a":{"x":[1,3],"y":[2]}}`),
+		},
+	},
+}
+
+func TestObjectPivot(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectPivotTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectPivot(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectPivot(b *testing.B, tf *objectPivot, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectPivot(b *testing.B) {
+	for _, test := range objectPivotTests {
+		tf, err := newObjectPivot(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectPivot(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,79 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectProjectConfig struct {
+	// Keys are the keys that are kept in the object. Nested keys (e.g., a.b)
+	// are kept in the same location and all other keys are removed.
+	Keys []string `json:"keys"`
+}
+
+func (c *objectProjectConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectProjectConfig) Validate() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("keys: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectProject(_ context.Context, cfg config.Config) (*objectProject, error) {
+	conf := objectProjectConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_project: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_project: %v", err)
+	}
+
+	tf := objectProject{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// objectProject replaces an object with a new object that only contains an
+// allowlist of keys. This is the inverse of object_delete and is safer for
+// removing sensitive data because unknown keys are always removed.
+type objectProject struct {
+	conf objectProjectConfig
+}
+
+func (tf *objectProject) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	outMsg := message.New().SetMetadata(msg.Metadata()).SetData([]byte(`{}`))
+	for _, key := range tf.conf.Keys {
+		value := msg.GetValue(key)
+		if !value.Exists() {
+			continue
+		}
+
+		if err := outMsg.SetValue(key, value); err != nil {
+			return nil, fmt.Errorf("transform: object_project: %v", err)
+		}
+	}
+
+	return []*message.Message{outMsg}, nil
+}
+
+func (tf *objectProject) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
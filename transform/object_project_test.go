@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectProject{}
+
+var objectProjectTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"a", "c.d"},
+			},
+		},
+		[]byte(`{"a":"b","c":{"d":"e","f":"g"},"h":"i"}`),
+		[][]byte{
+			[]byte(`{"a":"b","c":{"d":"e"}}`),
+		},
+	},
+	{
+		"array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"a"},
+			},
+		},
+		[]byte(`{"a":[1,{"b":"c"}],"d":"e"}`),
+		[][]byte{
+			[]byte(`{"a":[1,{"b":"c"}]}`),
+		},
+	},
+	{
+		"missing",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"a", "x.y"},
+			},
+		},
+		[]byte(`{"a":"b","c":"d"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+	{
+		"none",
+		config.Config{
+			Settings: map[string]interface{}{
+				"keys": []string{"x"},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{}`),
+		},
+	},
+}
+
+func TestObjectProject(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectProjectTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectProject(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectProject(b *testing.B, tf *objectProject, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectProject(b *testing.B) {
+	for _, test := range objectProjectTests {
+		tf, err := newObjectProject(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectProject(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,178 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// errObjectResolveRefsCircular is returned when a reference refers to itself,
+// either directly or through other references.
+var errObjectResolveRefsCircular = fmt.Errorf("circular reference")
+
+type objectResolveRefsConfig struct {
+	// Key is the key that identifies references in the object.
+	//
+	// This is optional and defaults to "$ref".
+	Key string `json:"key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectResolveRefsConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectResolveRefsConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectResolveRefs(_ context.Context, cfg config.Config) (*objectResolveRefs, error) {
+	conf := objectResolveRefsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+	}
+
+	if conf.Key == "" {
+		conf.Key = "$ref"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+	}
+
+	tf := objectResolveRefs{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// objectResolveRefs replaces references in an object (e.g., {"$ref":
+// "#/definitions/x"}) with the value that they refer to. Only references to
+// the same document (JSON Pointers in a URI fragment) are resolved, other
+// references are not changed. Keys that are next to a reference are removed
+// when it is replaced.
+type objectResolveRefs struct {
+	conf     objectResolveRefsConfig
+	isObject bool
+}
+
+func (tf *objectResolveRefs) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var b []byte
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		b = []byte(value.String())
+	} else {
+		b = msg.Data()
+	}
+
+	doc, err := objPatchDecode(b)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+	}
+
+	doc, err = tf.resolve(doc, doc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(out)); err != nil {
+			return nil, fmt.Errorf("transform: object_resolve_refs: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectResolveRefs) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// resolve returns a copy of the node with all references replaced. The stack
+// contains the references that are being resolved and is used to detect
+// circular references.
+func (tf *objectResolveRefs) resolve(root, node interface{}, stack []string) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := n[tf.conf.Key].(string); ok && strings.HasPrefix(ref, "#") {
+			for _, s := range stack {
+				if s == ref {
+					return nil, fmt.Errorf("%s: %v", ref, errObjectResolveRefsCircular)
+				}
+			}
+
+			p, err := url.PathUnescape(ref[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", ref, err)
+			}
+
+			v, err := objPatchGet(root, objPatchPointer(p))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", ref, err)
+			}
+
+			return tf.resolve(root, v, append(stack, ref))
+		}
+
+		m := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			r, err := tf.resolve(root, v, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			m[k] = r
+		}
+
+		return m, nil
+	case []interface{}:
+		a := make([]interface{}, len(n))
+		for i, v := range n {
+			r, err := tf.resolve(root, v, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			a[i] = r
+		}
+
+		return a, nil
+	}
+
+	return node, nil
+}
@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectResolveRefs{}
+
+var objectResolveRefsTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"definitions":{"x":{"a":1}},"b":{"$ref":"#/definitions/x"}}`),
+		[][]byte{
+			[]byte(`{"b":{"a":1},"definitions":{"x":{"a":1}}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"d":{"x":{"$ref":"#/d/y"},"y":[1,2]},"b":[{"$ref":"#/d/x"},{"$ref":"#/d/y/0"}]}`),
+		[][]byte{
+			[]byte(`{"b":[[1,2],1],"d":{"x":[1,2],"y":[1,2]}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"d":{"a/b":12345678901234567890},"b":{"$ref":"#/d/a~1b"}}`),
+		[][]byte{
+			[]byte(`{"b":12345678901234567890,"d":{"a/b":12345678901234567890}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"b":{"$ref":"https://example.com/schema.json"}}`),
+		[][]byte{
+			[]byte(`{"b":{"$ref":"https://example.com/schema.json"}}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key": "ref",
+			},
+		},
+		[]byte(`{"d":"x","b":{"ref":"#/d"}}`),
+		[][]byte{
+			[]byte(`{"b":"x","d":"x"}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"d":{"x":true},"b":{"$ref":"#/d/x"}},"c":1}`),
+		[][]byte{
+			[]byte(`{"a":{"b":true,"d":{"x":true}},"c":1}`),
+		},
+	},
+}
+
+func TestObjectResolveRefs(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectResolveRefsTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectResolveRefs(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectResolveRefs(b *testing.B, tf *objectResolveRefs, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectResolveRefs(b *testing.B) {
+	for _, test := range objectResolveRefsTests {
+		tf, err := newObjectResolveRefs(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectResolveRefs(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestObjectResolveRefsInvalid(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newObjectResolveRefs(ctx, config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range [][]byte{
+		[]byte(`{"a":{"$ref":"#/a"}}`),
+		[]byte(`{"a":{"$ref":"#/b"},"b":{"c":{"$ref":"#/a"}}}`),
+		[]byte(`{"a":{"$ref":"#/c"}}`),
+	} {
+		msg := message.New().SetData(test)
+		if _, err := tf.Transform(ctx, msg); err == nil {
+			t.Errorf("expected error for %s", test)
+		}
+	}
+}
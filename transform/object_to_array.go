@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectToArrayConfig struct {
+	// Unwrap determines if single element arrays are converted to the element,
+	// which reverses the default behavior of converting values to single element
+	// arrays. Arrays that have more or less than one element are not changed.
+	//
+	// This is optional and defaults to false.
+	Unwrap bool `json:"unwrap"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectToArrayConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectToArrayConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newObjectToArray(_ context.Context, cfg config.Config) (*objectToArray, error) {
+	conf := objectToArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_to_array: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_to_array: %v", err)
+	}
+
+	tf := objectToArray{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// objectToArray converts values that are not arrays into single element arrays
+// (e.g., "a" is ["a"]), which normalizes values that are sometimes sent as a
+// scalar and sometimes as an array. Values that are already arrays are not
+// changed.
+type objectToArray struct {
+	conf objectToArrayConfig
+}
+
+func (tf *objectToArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	var v interface{}
+	switch {
+	case tf.conf.Unwrap && value.IsArray() && len(value.Array()) == 1:
+		v = value.Array()[0].Value()
+	case !tf.conf.Unwrap && !value.IsArray():
+		v = []interface{}{value.Value()}
+	default:
+		v = value.Value()
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+		return nil, fmt.Errorf("transform: object_to_array: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectToArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectToArray{}
+
+var objectToArrayTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":["b"]}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"b":1}}`),
+		[][]byte{
+			[]byte(`{"a":[{"b":1}]}`),
+		},
+	},
+	{
+		"array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":["b","c"]}`),
+		[][]byte{
+			[]byte(`{"a":["b","c"]}`),
+		},
+	},
+	{
+		"unwrap",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":["b"]}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+	{
+		"unwrap object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[{"b":1}]}`),
+		[][]byte{
+			[]byte(`{"a":{"b":1}}`),
+		},
+	},
+	{
+		"unwrap array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":["b","c"]}`),
+		[][]byte{
+			[]byte(`{"a":["b","c"]}`),
+		},
+	},
+	{
+		"unwrap string",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unwrap": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+}
+
+func TestObjectToArray(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectToArrayTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectToArray(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectToArray(b *testing.B, tf *objectToArray, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectToArray(b *testing.B) {
+	for _, test := range objectToArrayTests {
+		tf, err := newObjectToArray(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectToArray(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/brexhq/substation/config"
 	iconfig "github.com/brexhq/substation/internal/config"
@@ -11,7 +12,28 @@ import (
 	"github.com/brexhq/substation/message"
 )
 
+// errObjectToBooleanUnknown is returned when a value is not in the true or
+// false values and the transform is configured to error on unknown values.
+var errObjectToBooleanUnknown = fmt.Errorf("unknown boolean value")
+
 type objectToBooleanConfig struct {
+	// TrueValues are the strings that are converted to true. Values are
+	// compared without case and surrounding whitespace.
+	//
+	// This is optional and defaults to ["true", "t", "1", "yes", "y", "on"].
+	TrueValues []string `json:"true_values"`
+	// FalseValues are the strings that are converted to false. Values are
+	// compared without case and surrounding whitespace.
+	//
+	// This is optional and defaults to ["false", "f", "0", "no", "n", "off"].
+	FalseValues []string `json:"false_values"`
+	// ErrorOnUnknown determines if the transform returns an error when a
+	// string is not in the true or false values. If this is false, then
+	// unknown values are converted to false.
+	//
+	// This is optional and defaults to false.
+	ErrorOnUnknown bool `json:"error_on_unknown"`
+
 	Object iconfig.Object `json:"object"`
 }
 
@@ -28,6 +50,14 @@ func (c *objectToBooleanConfig) Validate() error {
 		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
 	}
 
+	for _, t := range c.TrueValues {
+		for _, f := range c.FalseValues {
+			if strings.EqualFold(strings.TrimSpace(t), strings.TrimSpace(f)) {
+				return fmt.Errorf("true_values %q: %v", t, errors.ErrInvalidOption)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -37,19 +67,42 @@ func newObjectToBoolean(_ context.Context, cfg config.Config) (*objectToBoolean,
 		return nil, fmt.Errorf("transform: object_to_boolean: %v", err)
 	}
 
+	if conf.TrueValues == nil {
+		conf.TrueValues = []string{"true", "t", "1", "yes", "y", "on"}
+	}
+
+	if conf.FalseValues == nil {
+		conf.FalseValues = []string{"false", "f", "0", "no", "n", "off"}
+	}
+
 	if err := conf.Validate(); err != nil {
 		return nil, fmt.Errorf("transform: object_to_boolean: %v", err)
 	}
 
 	tf := objectToBoolean{
-		conf: conf,
+		conf:   conf,
+		values: make(map[string]bool),
+	}
+
+	for _, v := range conf.TrueValues {
+		tf.values[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+
+	for _, v := range conf.FalseValues {
+		tf.values[strings.ToLower(strings.TrimSpace(v))] = false
 	}
 
 	return &tf, nil
 }
 
+// objectToBoolean converts a value to a boolean. Strings are converted using
+// the configured true and false values, numbers are true if they are not 0,
+// and booleans are not changed.
 type objectToBoolean struct {
 	conf objectToBooleanConfig
+
+	// values maps normalized strings to their boolean value.
+	values map[string]bool
 }
 
 func (tf *objectToBoolean) String() string {
@@ -67,7 +120,17 @@ func (tf *objectToBoolean) Transform(ctx context.Context, msg *message.Message)
 		return []*message.Message{msg}, nil
 	}
 
-	if err := msg.SetValue(tf.conf.Object.TargetKey, value.Bool()); err != nil {
+	b := value.Bool()
+	if s, isStr := value.Value().(string); isStr {
+		v, ok := tf.values[strings.ToLower(strings.TrimSpace(s))]
+		if !ok && tf.conf.ErrorOnUnknown {
+			return nil, fmt.Errorf("transform: object_to_boolean: value %q: %v", s, errObjectToBooleanUnknown)
+		}
+
+		b = v
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, b); err != nil {
 		return nil, fmt.Errorf("transform: object_to_boolean: %v", err)
 	}
 
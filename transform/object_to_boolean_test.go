@@ -107,6 +107,85 @@ var objectToBooleanTests = []struct {
 			[]byte(`{"a":false}`),
 		},
 	},
+	{
+		"str to_bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":" Yes "}`),
+		[][]byte{
+			[]byte(`{"a":true}`),
+		},
+	},
+	{
+		"str to_bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"OFF"}`),
+		[][]byte{
+			[]byte(`{"a":false}`),
+		},
+	},
+	{
+		"str to_bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"maybe"}`),
+		[][]byte{
+			[]byte(`{"a":false}`),
+		},
+	},
+	{
+		"str to_bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"true_values":  []string{"enabled"},
+				"false_values": []string{"disabled"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"Enabled"}`),
+		[][]byte{
+			[]byte(`{"a":true}`),
+		},
+	},
+	{
+		"str to_bool",
+		config.Config{
+			Settings: map[string]interface{}{
+				"true_values":  []string{"enabled"},
+				"false_values": []string{"disabled"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"yes"}`),
+		[][]byte{
+			[]byte(`{"a":false}`),
+		},
+	},
 }
 
 func TestObjectToBoolean(t *testing.T) {
@@ -137,6 +216,27 @@ func TestObjectToBoolean(t *testing.T) {
 	}
 }
 
+func TestObjectToBooleanUnknown(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newObjectToBoolean(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"error_on_unknown": true,
+			"object": map[string]interface{}{
+				"source_key": "a",
+				"target_key": "a",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":"maybe"}`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
+
 func benchmarkObjectToBoolean(b *testing.B, tf *objectToBoolean, data []byte) {
 	ctx := context.TODO()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,134 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type objectUnpivotConfig struct {
+	// NameKey is the key in each element that contains the name of the value.
+	//
+	// This is optional and defaults to "key".
+	NameKey string `json:"name_key"`
+	// ValueKey is the key in each element that contains the value.
+	//
+	// This is optional and defaults to "value".
+	ValueKey string `json:"value_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *objectUnpivotConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *objectUnpivotConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.NameKey == c.ValueKey {
+		return fmt.Errorf("name_key %q: %v", c.NameKey, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newObjectUnpivot(_ context.Context, cfg config.Config) (*objectUnpivot, error) {
+	conf := objectUnpivotConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: object_unpivot: %v", err)
+	}
+
+	if conf.NameKey == "" {
+		conf.NameKey = "key"
+	}
+
+	if conf.ValueKey == "" {
+		conf.ValueKey = "value"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: object_unpivot: %v", err)
+	}
+
+	tf := objectUnpivot{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// objectUnpivot converts an object into an array of name and value objects
+// (e.g., {"b":2,"a":1} is [{"key":"a","value":1},{"key":"b","value":2}]). The
+// array is sorted by name. This is the inverse of objectPivot.
+type objectUnpivot struct {
+	conf     objectUnpivotConfig
+	isObject bool
+}
+
+func (tf *objectUnpivot) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() || !value.IsObject() {
+		return []*message.Message{msg}, nil
+	}
+
+	m := value.Map()
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	arr := make([]map[string]interface{}, 0, len(keys))
+	for _, k := range keys {
+		arr = append(arr, map[string]interface{}{
+			tf.conf.NameKey:  k,
+			tf.conf.ValueKey: m[k].Value(),
+		})
+	}
+
+	b, err := json.Marshal(arr)
+	if err != nil {
+		return nil, fmt.Errorf("transform: object_unpivot: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: object_unpivot: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *objectUnpivot) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
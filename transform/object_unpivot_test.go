@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &objectUnpivot{}
+
+var objectUnpivotTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`{"y":{"z":true},"x":1}`),
+		[][]byte{
+			[]byte(`[{"key":"x","value":1},{"key":"y","value":{"z":true}}]`),
+		},
+	},
+	{
+		"data keys",
+		config.Config{
+			Settings: map[string]interface{}{
+				"name_key":  "Key",
+				"value_key": "Value",
+			},
+		},
+		[]byte(`{"team":"sec","env":"prod"}`),
+		[][]byte{
+			[]byte(`[{"Key":"env","Value":"prod"},{"Key":"team","Value":"sec"}]`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":{"c":[1,2],"b":null}}`),
+		[][]byte{
+			[]byte(`{"a":[{"key":"b","value":null},{"key":"c","value":[1,2]}]}`),
+		},
+	},
+	{
+		"object not object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"b"}`),
+		[][]byte{
+			[]byte(`{"a":"b"}`),
+		},
+	},
+}
+
+func TestObjectUnpivot(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range objectUnpivotTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newObjectUnpivot(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkObjectUnpivot(b *testing.B, tf *objectUnpivot, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkObjectUnpivot(b *testing.B) {
+	for _, test := range objectUnpivotTests {
+		tf, err := newObjectUnpivot(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkObjectUnpivot(b, tf, test.test)
+			},
+		)
+	}
+}
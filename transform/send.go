@@ -2,8 +2,15 @@ package transform
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"strings"
 
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/secrets"
 	"github.com/brexhq/substation/message"
 )
 
@@ -12,6 +19,74 @@ import (
 // or duration limit.
 var errSendBatchMisconfigured = fmt.Errorf("data could not be added to batch")
 
+// errSendTLSInvalidCA is returned when the certificate authority bundle
+// does not contain any valid certificates.
+var errSendTLSInvalidCA = fmt.Errorf("invalid certificate authority")
+
+// sendTLSConfig returns the TLS configuration for network clients. Certificates
+// and keys are interpolated with secrets, so they can be loaded from files,
+// environment variables, or secrets managers.
+func sendTLSConfig(ctx context.Context, cfg iconfig.TLS) (*tls.Config, error) {
+	conf := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // Only enabled by configuration.
+	}
+
+	if cfg.CACertificate != "" {
+		ca, err := sendTLSLoadPEM(ctx, cfg.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("ca_certificate: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(ca); !ok {
+			return nil, fmt.Errorf("ca_certificate: %v", errSendTLSInvalidCA)
+		}
+
+		conf.RootCAs = pool
+	}
+
+	if (cfg.ClientCertificate == "") != (cfg.ClientKey == "") {
+		return nil, fmt.Errorf("client_certificate and client_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if cfg.ClientCertificate != "" {
+		cert, err := sendTLSLoadPEM(ctx, cfg.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("client_certificate: %v", err)
+		}
+
+		key, err := sendTLSLoadPEM(ctx, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("client_key: %v", err)
+		}
+
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+
+		conf.Certificates = []tls.Certificate{pair}
+	}
+
+	return conf, nil
+}
+
+// sendTLSLoadPEM returns PEM-encoded data from a string or a file.
+func sendTLSLoadPEM(ctx context.Context, s string) ([]byte, error) {
+	s, err := secrets.Interpolate(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(s), "-----BEGIN") {
+		return []byte(s), nil
+	}
+
+	return os.ReadFile(s)
+}
+
 func withTransforms(ctx context.Context, tf []Transformer, items [][]byte) ([][]byte, error) {
 	if tf == nil {
 		return items, nil
@@ -2,6 +2,9 @@ package transform
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -14,11 +17,17 @@ import (
 	"github.com/brexhq/substation/internal/errors"
 	"github.com/brexhq/substation/message"
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 )
 
-// Records greater than 1 MiB in size cannot be
-// put into a Kinesis Data Stream.
-const sendAWSKinesisDataStreamMessageSizeLimit = 1024 * 1024 * 1
+const (
+	// Records greater than 1 MiB in size cannot be
+	// put into a Kinesis Data Stream.
+	sendAWSKinesisDataStreamMessageSizeLimit = 1024 * 1024 * 1
+	// PutRecords requests are limited to 500 records and 5 MiB.
+	sendAWSKinesisDataStreamPutRecordsCountLimit = 500
+	sendAWSKinesisDataStreamPutRecordsSizeLimit  = sendAWSKinesisDataStreamMessageSizeLimit * 5
+)
 
 // errSendAWSKinesisDataStreamMessageSizeLimit is returned when data
 // exceeds the Kinesis record size limit. If this error occurs, then
@@ -31,8 +40,34 @@ type sendAWSKinesisDataStreamConfig struct {
 	StreamName string `json:"stream_name"`
 	// UseBatchKeyAsPartitionKey determines if the batch key should be used as the partition key.
 	UseBatchKeyAsPartitionKey bool `json:"use_batch_key_as_partition_key"`
+	// PartitionKey retrieves a value from each record that is used as the record's
+	// partition key, which sends related records to the same shard. If the value
+	// does not exist, then a random partition key is used. This takes precedence
+	// over UseBatchKeyAsPartitionKey.
+	//
+	// If record aggregation is enabled, then records are aggregated separately
+	// for each partition key.
+	//
+	// This is optional and has no default.
+	PartitionKey string `json:"partition_key"`
+	// PartitionKeyHash is the hash function applied to the value retrieved by
+	// PartitionKey. This limits the length of the partition key and avoids storing
+	// sensitive values in the key.
+	//
+	// Must be one of:
+	//	- md5
+	//	- sha256
+	//
+	// This is optional and has no default (the value is not hashed).
+	PartitionKeyHash string `json:"partition_key_hash"`
 	// EnableRecordAggregation determines if records should be aggregated.
 	EnableRecordAggregation bool `json:"enable_record_aggregation"`
+	// SplitOversizedArrays determines if records that exceed the size limit are
+	// split into multiple records. This only applies to records that are JSON
+	// arrays; each element in the array is sent as a separate record.
+	//
+	// This is optional and defaults to false (oversized records cause an error).
+	SplitOversizedArrays bool `json:"split_oversized_arrays"`
 	// AuxTransforms are applied to batched data before it is sent.
 	AuxTransforms []config.Config `json:"auxiliary_transforms"`
 
@@ -51,6 +86,15 @@ func (c *sendAWSKinesisDataStreamConfig) Validate() error {
 		return fmt.Errorf("stream_name: %v", errors.ErrMissingRequiredOption)
 	}
 
+	if c.PartitionKeyHash != "" && !slices.Contains(
+		[]string{
+			"md5",
+			"sha256",
+		},
+		c.PartitionKeyHash) {
+		return fmt.Errorf("partition_key_hash %q: %v", c.PartitionKeyHash, errors.ErrInvalidOption)
+	}
+
 	return nil
 }
 
@@ -69,9 +113,8 @@ func newSendAWSKinesisDataStream(_ context.Context, cfg config.Config) (*sendAWS
 	}
 
 	agg, err := aggregate.New(aggregate.Config{
-		// Kinesis Data Streams limits batch operations to 500 records and 5MiB.
-		Count:    500,
-		Size:     sendAWSKinesisDataStreamMessageSizeLimit * 5,
+		Count:    sendAWSKinesisDataStreamPutRecordsCountLimit,
+		Size:     sendAWSKinesisDataStreamPutRecordsSizeLimit,
 		Duration: conf.Batch.Duration,
 	})
 	if err != nil {
@@ -97,6 +140,7 @@ func newSendAWSKinesisDataStream(_ context.Context, cfg config.Config) (*sendAWS
 		RoleARN:         conf.AWS.RoleARN,
 		MaxRetries:      conf.Retry.Count,
 		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
 	})
 
 	return &tf, nil
@@ -132,27 +176,57 @@ func (tf *sendAWSKinesisDataStream) Transform(ctx context.Context, msg *message.
 		return []*message.Message{msg}, nil
 	}
 
-	if len(msg.Data()) > sendAWSKinesisDataStreamMessageSizeLimit {
-		return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: %v", errSendAWSKinesisDataStreamMessageSizeLimit)
-	}
-
 	// If this value does not exist, then all data is batched together.
 	key := msg.GetValue(tf.conf.Object.BatchKey).String()
-	if ok := tf.agg.Add(key, msg.Data()); ok {
+
+	if len(msg.Data()) <= sendAWSKinesisDataStreamMessageSizeLimit {
+		if err := tf.batch(ctx, key, msg.Data()); err != nil {
+			return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: %v", err)
+		}
+
 		return []*message.Message{msg}, nil
 	}
 
+	value := bytesToValue(msg.Data())
+	if !tf.conf.SplitOversizedArrays || !value.IsArray() {
+		return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: size %d: %v", len(msg.Data()), errSendAWSKinesisDataStreamMessageSizeLimit)
+	}
+
+	for _, v := range value.Array() {
+		// Elements are checked before any are batched so that a partial
+		// array is not sent.
+		if len(v.Bytes()) > sendAWSKinesisDataStreamMessageSizeLimit {
+			return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: element size %d: %v", len(v.Bytes()), errSendAWSKinesisDataStreamMessageSizeLimit)
+		}
+	}
+
+	for _, v := range value.Array() {
+		if err := tf.batch(ctx, key, v.Bytes()); err != nil {
+			return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+// batch adds data to the batch. If the batch is full, then it is sent
+// before the data is added.
+func (tf *sendAWSKinesisDataStream) batch(ctx context.Context, key string, data []byte) error {
+	if ok := tf.agg.Add(key, data); ok {
+		return nil
+	}
+
 	if err := tf.send(ctx, key); err != nil {
-		return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: %v", err)
+		return err
 	}
 
 	// If data cannot be added after reset, then the batch is misconfgured.
 	tf.agg.Reset(key)
-	if ok := tf.agg.Add(key, msg.Data()); !ok {
-		return nil, fmt.Errorf("transform: send_aws_kinesis_data_stream: %v", errSendBatchMisconfigured)
+	if ok := tf.agg.Add(key, data); !ok {
+		return errSendBatchMisconfigured
 	}
 
-	return []*message.Message{msg}, nil
+	return nil
 }
 
 func (tf *sendAWSKinesisDataStream) String() string {
@@ -166,21 +240,50 @@ func (tf *sendAWSKinesisDataStream) send(ctx context.Context, key string) error
 		return err
 	}
 
-	var partitionKey string
-	switch tf.conf.UseBatchKeyAsPartitionKey {
-	case true:
-		partitionKey = key
-	case false:
-		partitionKey = uuid.NewString()
+	partitionKeys := make([]string, len(data))
+	switch {
+	case tf.conf.PartitionKey != "":
+		for i, d := range data {
+			partitionKeys[i] = tf.partitionKey(d)
+		}
+	case tf.conf.UseBatchKeyAsPartitionKey:
+		for i := range data {
+			partitionKeys[i] = key
+		}
+	default:
+		pk := uuid.NewString()
+		for i := range data {
+			partitionKeys[i] = pk
+		}
 	}
 
-	switch tf.conf.EnableRecordAggregation {
-	case false:
-		if _, err := tf.client.PutRecords(ctx, tf.conf.StreamName, partitionKey, data); err != nil {
-			return err
+	if tf.conf.EnableRecordAggregation {
+		data, partitionKeys = tf.aggregateRecords(partitionKeys, data)
+	}
+
+	// Auxiliary transforms can change the size and number of records, so
+	// records are checked and split into requests that are within the
+	// PutRecords limits.
+	var size, start int
+	for i, d := range data {
+		if len(d) > sendAWSKinesisDataStreamMessageSizeLimit {
+			return fmt.Errorf("size %d: %v", len(d), errSendAWSKinesisDataStreamMessageSizeLimit)
 		}
-	case true:
-		if _, err := tf.client.PutRecords(ctx, tf.conf.StreamName, partitionKey, tf.aggregateRecords(partitionKey, data)); err != nil {
+
+		if i-start == sendAWSKinesisDataStreamPutRecordsCountLimit || size+len(d) > sendAWSKinesisDataStreamPutRecordsSizeLimit {
+			if _, err := tf.client.PutRecordsWithPartitionKeys(ctx, tf.conf.StreamName, partitionKeys[start:i], data[start:i]); err != nil {
+				return err
+			}
+
+			start = i
+			size = 0
+		}
+
+		size += len(d)
+	}
+
+	if start < len(data) {
+		if _, err := tf.client.PutRecordsWithPartitionKeys(ctx, tf.conf.StreamName, partitionKeys[start:], data[start:]); err != nil {
 			return err
 		}
 	}
@@ -188,26 +291,75 @@ func (tf *sendAWSKinesisDataStream) send(ctx context.Context, key string) error
 	return nil
 }
 
-func (tf *sendAWSKinesisDataStream) aggregateRecords(partitionKey string, data [][]byte) [][]byte {
+// partitionKey returns the partition key for a record. If the value does
+// not exist in the record, then a random partition key is returned.
+func (tf *sendAWSKinesisDataStream) partitionKey(data []byte) string {
+	value := message.New().SetData(data).GetValue(tf.conf.PartitionKey)
+	if !value.Exists() || value.String() == "" {
+		return uuid.NewString()
+	}
+
+	switch tf.conf.PartitionKeyHash {
+	case "md5":
+		sum := md5.Sum(value.Bytes())
+		return hex.EncodeToString(sum[:])
+	case "sha256":
+		sum := sha256.Sum256(value.Bytes())
+		return hex.EncodeToString(sum[:])
+	default:
+		// Partition keys are limited to 256 Unicode characters, so keys are
+		// truncated by rune to avoid splitting multi-byte characters.
+		pk := []rune(value.String())
+		if len(pk) > 256 {
+			pk = pk[:256]
+		}
+
+		return string(pk)
+	}
+}
+
+// aggregateRecords aggregates records that have the same partition key and
+// returns the aggregated records and their partition keys. Records are
+// aggregated in order, and aggregated records are returned in the order that
+// they are completed.
+func (tf *sendAWSKinesisDataStream) aggregateRecords(partitionKeys []string, data [][]byte) ([][]byte, []string) {
 	var records [][]byte
+	var keys []string
 
-	agg := &kinesis.Aggregate{}
-	agg.New()
+	// order tracks when each partition key was first seen so that the
+	// remaining aggregated records are returned in a stable order.
+	var order []string
+	aggs := make(map[string]*kinesis.Aggregate)
 
-	for _, b := range data {
-		if ok := agg.Add(b, partitionKey); ok {
+	for i, b := range data {
+		pk := partitionKeys[i]
+
+		agg, ok := aggs[pk]
+		if !ok {
+			agg = &kinesis.Aggregate{}
+			agg.New()
+
+			aggs[pk] = agg
+			order = append(order, pk)
+		}
+
+		if ok := agg.Add(b, pk); ok {
 			continue
 		}
 
 		records = append(records, agg.Get())
+		keys = append(keys, agg.PartitionKey)
 
 		agg.New()
-		_ = agg.Add(b, partitionKey)
+		_ = agg.Add(b, pk)
 	}
 
-	if agg.Count > 0 {
-		records = append(records, agg.Get())
+	for _, pk := range order {
+		if agg := aggs[pk]; agg.Count > 0 {
+			records = append(records, agg.Get())
+			keys = append(keys, agg.PartitionKey)
+		}
 	}
 
-	return records
+	return records, keys
 }
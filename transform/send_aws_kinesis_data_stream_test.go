@@ -0,0 +1,284 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	rec "github.com/awslabs/kinesis-aggregation/go/records"
+	"golang.org/x/exp/slices"
+
+	//nolint: staticcheck // not ready to switch package
+	"github.com/golang/protobuf/proto"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/aggregate"
+	ikinesis "github.com/brexhq/substation/internal/aws/kinesis"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &sendAWSKinesisDataStream{}
+
+// sendAWSKinesisDataStreamMockedPutRecords records the partition keys and data
+// of every PutRecords request.
+type sendAWSKinesisDataStreamMockedPutRecords struct {
+	kinesisiface.KinesisAPI
+	keys [][]string
+	data [][]string
+}
+
+func (m *sendAWSKinesisDataStreamMockedPutRecords) PutRecordsWithContext(ctx aws.Context, in *kinesis.PutRecordsInput, opts ...request.Option) (*kinesis.PutRecordsOutput, error) {
+	var keys, data []string
+	for _, r := range in.Records {
+		keys = append(keys, *r.PartitionKey)
+		data = append(data, string(r.Data))
+	}
+
+	m.keys = append(m.keys, keys)
+	m.data = append(m.data, data)
+
+	return &kinesis.PutRecordsOutput{}, nil
+}
+
+func sendAWSKinesisDataStreamApply(t *testing.T, settings map[string]interface{}, data ...[]byte) (*sendAWSKinesisDataStreamMockedPutRecords, error) {
+	t.Helper()
+
+	ctx := context.TODO()
+	settings["stream_name"] = "stream"
+
+	tf, err := newSendAWSKinesisDataStream(ctx, config.Config{Settings: settings})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &sendAWSKinesisDataStreamMockedPutRecords{}
+	tf.client = ikinesis.API{Client: mock}
+
+	for _, d := range data {
+		if _, err := tf.Transform(ctx, message.New().SetData(d)); err != nil {
+			return mock, err
+		}
+	}
+
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+		return mock, err
+	}
+
+	return mock, nil
+}
+
+func TestSendAWSKinesisDataStreamPartitionKey(t *testing.T) {
+	long := strings.Repeat("é", 300)
+
+	tests := []struct {
+		name     string
+		hash     string
+		data     string
+		expected string
+	}{
+		{"value", "", `{"a":"alice"}`, "alice"},
+		{"md5", "md5", `{"a":"alice"}`, "6384e2b2184bcbf58eccf10ca7a6563c"},
+		{"sha256", "sha256", `{"a":"alice"}`, "2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90"},
+		// Keys are truncated to 256 characters, not 256 bytes.
+		{"truncate", "", fmt.Sprintf(`{"a":%q}`, long), long[:512]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+				"partition_key":      "a",
+				"partition_key_hash": test.hash,
+			}, []byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(mock.keys) != 1 || len(mock.keys[0]) != 1 {
+				t.Fatalf("expected 1 record, got %v", mock.keys)
+			}
+
+			if mock.keys[0][0] != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, mock.keys[0][0])
+			}
+		})
+	}
+}
+
+func TestSendAWSKinesisDataStreamPartitionKeyMissing(t *testing.T) {
+	mock, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+		"partition_key": "a",
+	}, []byte(`{"b":1}`), []byte(`{"b":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Records without the key use random partition keys.
+	keys := mock.keys[0]
+	if len(keys) != 2 || keys[0] == "" || keys[0] == keys[1] {
+		t.Errorf("expected 2 random keys, got %v", keys)
+	}
+}
+
+func TestSendAWSKinesisDataStreamAggregationPartitionKey(t *testing.T) {
+	mock, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+		"partition_key":             "a",
+		"enable_record_aggregation": true,
+	}, []byte(`{"a":"alice","b":1}`), []byte(`{"a":"bob","b":2}`), []byte(`{"a":"alice","b":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mock.keys) != 1 || !slices.Equal(mock.keys[0], []string{"alice", "bob"}) {
+		t.Fatalf("expected aggregated records for alice and bob, got %v", mock.keys)
+	}
+
+	expected := [][]string{
+		{`{"a":"alice","b":1}`, `{"a":"alice","b":3}`},
+		{`{"a":"bob","b":2}`},
+	}
+
+	for i, d := range mock.data[0] {
+		// Aggregated records have a 4 byte header and a 16 byte checksum.
+		var agg rec.AggregatedRecord
+		if err := proto.Unmarshal([]byte(d[4:len(d)-16]), &agg); err != nil {
+			t.Fatal(err)
+		}
+
+		var records []string
+		for _, r := range agg.Records {
+			records = append(records, string(r.Data))
+		}
+
+		if !slices.Equal(records, expected[i]) {
+			t.Errorf("expected %v, got %v", expected[i], records)
+		}
+
+		for _, pk := range agg.PartitionKeyTable {
+			if pk != mock.keys[0][i] {
+				t.Errorf("expected partition key %s, got %s", mock.keys[0][i], pk)
+			}
+		}
+	}
+}
+
+func TestSendAWSKinesisDataStreamSplitOversizedArrays(t *testing.T) {
+	elem := fmt.Sprintf(`{"a":%q}`, strings.Repeat("a", sendAWSKinesisDataStreamMessageSizeLimit/2))
+	data := []byte("[" + strings.Join([]string{elem, elem, elem}, ",") + "]")
+
+	t.Run("split", func(t *testing.T) {
+		mock, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+			"split_oversized_arrays": true,
+		}, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var records []string
+		for _, d := range mock.data {
+			records = append(records, d...)
+		}
+
+		if !slices.Equal(records, []string{elem, elem, elem}) {
+			t.Errorf("expected 3 elements, got %d records", len(records))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if _, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{}, data); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("not array", func(t *testing.T) {
+		obj := []byte(fmt.Sprintf(`{"a":%s,"b":%s,"c":%s}`, elem, elem, elem))
+		if _, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+			"split_oversized_arrays": true,
+		}, obj); err == nil {
+			t.Error("expected error")
+		}
+	})
+
+	t.Run("oversized element", func(t *testing.T) {
+		big := fmt.Sprintf(`[{"a":%q}]`, strings.Repeat("a", sendAWSKinesisDataStreamMessageSizeLimit))
+		mock, err := sendAWSKinesisDataStreamApply(t, map[string]interface{}{
+			"split_oversized_arrays": true,
+		}, []byte(big))
+		if err == nil {
+			t.Error("expected error")
+		}
+
+		if len(mock.data) != 0 {
+			t.Errorf("expected no requests, got %d", len(mock.data))
+		}
+	})
+}
+
+func TestSendAWSKinesisDataStreamPutRecordsLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		size     int
+		expected []int
+	}{
+		{"500 records", 500, 1, []int{500}},
+		{"501 records", 501, 1, []int{500, 1}},
+		{"1001 records", 1001, 1, []int{500, 500, 1}},
+		{"5 MiB", 5, sendAWSKinesisDataStreamMessageSizeLimit, []int{5}},
+		{"over 5 MiB", 6, sendAWSKinesisDataStreamMessageSizeLimit, []int{5, 1}},
+		{"5 MiB exactly", 10, sendAWSKinesisDataStreamPutRecordsSizeLimit / 10, []int{10}},
+		{"over 5 MiB by 10 bytes", 10, sendAWSKinesisDataStreamPutRecordsSizeLimit/10 + 1, []int{9, 1}},
+	}
+
+	ctx := context.TODO()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newSendAWSKinesisDataStream(ctx, config.Config{
+				Settings: map[string]interface{}{
+					"stream_name": "stream",
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mock := &sendAWSKinesisDataStreamMockedPutRecords{}
+			tf.client = ikinesis.API{Client: mock}
+
+			// The batch is larger than a PutRecords request, which is the
+			// same as auxiliary transforms creating more records.
+			agg, err := aggregate.New(aggregate.Config{
+				Count: test.count,
+				Size:  test.count * test.size,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tf.agg = agg
+
+			record := []byte(strings.Repeat("a", test.size))
+			for i := 0; i < test.count; i++ {
+				if ok := tf.agg.Add("", record); !ok {
+					t.Fatal("record could not be added to batch")
+				}
+			}
+
+			if err := tf.send(ctx, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			var requests []int
+			for _, d := range mock.data {
+				requests = append(requests, len(d))
+			}
+
+			if !slices.Equal(requests, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, requests)
+			}
+		})
+	}
+}
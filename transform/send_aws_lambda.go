@@ -91,6 +91,7 @@ func newSendAWSLambda(_ context.Context, cfg config.Config) (*sendAWSLambda, err
 		RoleARN:         conf.AWS.RoleARN,
 		MaxRetries:      conf.Retry.Count,
 		RetryableErrors: conf.Retry.ErrorMessages,
+		MaxRetryDelay:   conf.Retry.MaxDelay,
 	})
 
 	return &tf, nil
@@ -0,0 +1,321 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/aggregate"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	sendGRPCBackoffBase = 100 * time.Millisecond
+	sendGRPCBackoffMax  = 10 * time.Second
+)
+
+// errSendGRPCInvalidMessage is returned when the gRPC codec receives
+// a message that is not a byte slice.
+var errSendGRPCInvalidMessage = fmt.Errorf("invalid message type")
+
+type sendGRPCConfig struct {
+	// Target is the address of the gRPC server (e.g., localhost:50051).
+	Target string `json:"target"`
+	// Method is the full name of the streaming RPC that data is sent to
+	// (e.g., /package.Service/Method).
+	//
+	// The RPC can be client-streaming or bidirectional-streaming. Each
+	// message is sent to the server as-is, so data must be encoded in
+	// the format expected by the server (e.g., a serialized protobuf).
+	Method string `json:"method"`
+	// Insecure determines if the connection is made without TLS.
+	//
+	// This is optional and defaults to false (TLS is used).
+	Insecure bool `json:"insecure"`
+	// TLS configures the connection, which supports custom certificate
+	// authorities and mutual TLS. This is not used when Insecure is true.
+	//
+	// This is optional and defaults to the system's certificate pool.
+	TLS iconfig.TLS `json:"tls"`
+	// AuxTransforms are applied to batched data before it is sent.
+	AuxTransforms []config.Config `json:"auxiliary_transforms"`
+
+	Object iconfig.Object `json:"object"`
+	Batch  iconfig.Batch  `json:"batch"`
+	Retry  iconfig.Retry  `json:"retry"`
+}
+
+func (c *sendGRPCConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *sendGRPCConfig) Validate() error {
+	if c.Target == "" {
+		return fmt.Errorf("target: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Method == "" {
+		return fmt.Errorf("method: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newSendGRPC(ctx context.Context, cfg config.Config) (*sendGRPC, error) {
+	conf := sendGRPCConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: %v", err)
+	}
+
+	tf := sendGRPC{
+		conf:     conf,
+		maxDelay: sendGRPCBackoffMax,
+	}
+
+	if conf.Retry.MaxDelay != "" {
+		d, err := time.ParseDuration(conf.Retry.MaxDelay)
+		if err != nil {
+			return nil, fmt.Errorf("transform: send_grpc: %v", err)
+		}
+
+		tf.maxDelay = d
+	}
+
+	agg, err := aggregate.New(aggregate.Config{
+		Count:    conf.Batch.Count,
+		Size:     conf.Batch.Size,
+		Duration: conf.Batch.Duration,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: %v", err)
+	}
+	tf.agg = agg
+
+	if len(conf.AuxTransforms) > 0 {
+		tf.tforms = make([]Transformer, len(conf.AuxTransforms))
+		for i, c := range conf.AuxTransforms {
+			t, err := New(context.Background(), c)
+			if err != nil {
+				return nil, fmt.Errorf("transform: send_grpc: %v", err)
+			}
+
+			tf.tforms[i] = t
+		}
+	}
+
+	tlsConf, err := sendTLSConfig(ctx, conf.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: tls: %v", err)
+	}
+
+	creds := credentials.NewTLS(tlsConf)
+	if conf.Insecure {
+		creds = insecure.NewCredentials()
+	}
+
+	// The connection is lazily established when the first RPC is made.
+	conn, err := grpc.Dial(conf.Target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(sendGRPCCodec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: %v", err)
+	}
+	tf.conn = conn
+
+	return &tf, nil
+}
+
+type sendGRPC struct {
+	conf sendGRPCConfig
+
+	// conn is safe for concurrent use.
+	conn *grpc.ClientConn
+	// maxDelay is the maximum amount of time between retries.
+	maxDelay time.Duration
+
+	mu     sync.Mutex
+	agg    *aggregate.Aggregate
+	tforms []Transformer
+}
+
+func (tf *sendGRPC) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		for key := range tf.agg.GetAll() {
+			if tf.agg.Count(key) == 0 {
+				continue
+			}
+
+			if err := tf.send(ctx, key); err != nil {
+				return nil, fmt.Errorf("transform: send_grpc: %v", err)
+			}
+		}
+
+		tf.agg.ResetAll()
+		return []*message.Message{msg}, nil
+	}
+
+	// If this value does not exist, then all data is batched together.
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	if ok := tf.agg.Add(key, msg.Data()); ok {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := tf.send(ctx, key); err != nil {
+		return nil, fmt.Errorf("transform: send_grpc: %v", err)
+	}
+
+	// If data cannot be added after reset, then the batch is misconfgured.
+	tf.agg.Reset(key)
+	if ok := tf.agg.Add(key, msg.Data()); !ok {
+		return nil, fmt.Errorf("transform: send_grpc: %v", errSendBatchMisconfigured)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *sendGRPC) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// send streams the batch to the server and retries the entire batch
+// with exponential backoff if the server returns a transient error.
+func (tf *sendGRPC) send(ctx context.Context, key string) error {
+	data, err := withTransforms(ctx, tf.tforms, tf.agg.Get(key))
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := tf.stream(ctx, data)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= tf.conf.Retry.Count || !sendGRPCIsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sendGRPCBackoff(attempt, tf.maxDelay)):
+		}
+	}
+}
+
+// stream opens a new stream, sends all data, and then half-closes the
+// stream and drains all responses from the server. Responses are
+// discarded, but they must be received to confirm that the server
+// accepted the data.
+func (tf *sendGRPC) stream(ctx context.Context, data [][]byte) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	desc := &grpc.StreamDesc{
+		ClientStreams: true,
+		ServerStreams: true,
+	}
+
+	stream, err := tf.conn.NewStream(ctx, desc, tf.conf.Method)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range data {
+		if err := stream.SendMsg(d); err != nil {
+			// The real error is returned by RecvMsg.
+			if err == io.EOF {
+				break
+			}
+
+			return err
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		var resp []byte
+		if err := stream.RecvMsg(&resp); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+func sendGRPCIsRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendGRPCBackoff returns an exponential backoff duration with full jitter.
+func sendGRPCBackoff(attempt int, max time.Duration) time.Duration {
+	d := sendGRPCBackoffBase << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+
+	//nolint: gosec // Jitter does not require a secure random number generator.
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+// sendGRPCCodec sends and receives messages as raw bytes, which removes
+// the requirement of having generated code for the service.
+type sendGRPCCodec struct{}
+
+func (sendGRPCCodec) Marshal(v any) ([]byte, error) {
+	switch m := v.(type) {
+	case []byte:
+		return m, nil
+	case *[]byte:
+		return *m, nil
+	default:
+		return nil, errSendGRPCInvalidMessage
+	}
+}
+
+func (sendGRPCCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(*[]byte)
+	if !ok {
+		return errSendGRPCInvalidMessage
+	}
+
+	*m = append((*m)[:0], data...)
+	return nil
+}
+
+// Name is used by the server to determine the content-subtype of the
+// message. This is "proto" for compatibility with most servers.
+func (sendGRPCCodec) Name() string {
+	return "proto"
+}
@@ -2,10 +2,12 @@ package transform
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/brexhq/substation/config"
@@ -24,11 +26,24 @@ type sendHTTPPostConfig struct {
 	//
 	// This is optional and has no default.
 	Headers map[string]string `json:"headers"`
+	// IdempotencyHeader is the HTTP header that contains a key the server can use
+	// to deduplicate requests (e.g., Idempotency-Key). The key is the same when a
+	// request is retried.
+	//
+	// This is optional and has no default (the header is not sent).
+	IdempotencyHeader string `json:"idempotency_header"`
+	// IdempotencyKey retrieves the value of the idempotency header from the data
+	// in each request. If the value does not exist, then the SHA-256 hash of the
+	// data is used. This is only used when IdempotencyHeader is set.
+	//
+	// This is optional and defaults to the SHA-256 hash of the data.
+	IdempotencyKey string `json:"idempotency_key"`
 	// AuxTransforms are applied to batched data before it is sent.
 	AuxTransforms []config.Config `json:"auxiliary_transforms"`
 
 	Object iconfig.Object `json:"object"`
 	Batch  iconfig.Batch  `json:"batch"`
+	TLS    iconfig.TLS    `json:"tls"`
 }
 
 func (c *sendHTTPPostConfig) Decode(in interface{}) error {
@@ -43,7 +58,7 @@ func (c *sendHTTPPostConfig) Validate() error {
 	return nil
 }
 
-func newSendHTTPPost(_ context.Context, cfg config.Config) (*sendHTTPPost, error) {
+func newSendHTTPPost(ctx context.Context, cfg config.Config) (*sendHTTPPost, error) {
 	conf := sendHTTPPostConfig{}
 	if err := conf.Decode(cfg.Settings); err != nil {
 		return nil, fmt.Errorf("transform: send_http_post: %v", err)
@@ -58,6 +73,15 @@ func newSendHTTPPost(_ context.Context, cfg config.Config) (*sendHTTPPost, error
 	}
 
 	tf.client.Setup()
+	if conf.TLS != (iconfig.TLS{}) {
+		tlsConf, err := sendTLSConfig(ctx, conf.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("transform: send_http_post: tls: %v", err)
+		}
+
+		tf.client.SetTLSConfig(tlsConf)
+	}
+
 	if _, ok := os.LookupEnv("AWS_XRAY_DAEMON_ADDRESS"); ok {
 		tf.client.EnableXRay()
 	}
@@ -168,7 +192,15 @@ func (tf *sendHTTPPost) send(ctx context.Context, key string) error {
 	}
 
 	for _, d := range data {
-		resp, err := tf.client.Post(ctx, url, d, headers...)
+		h := headers
+		if tf.conf.IdempotencyHeader != "" {
+			h = append(slices.Clip(headers), http.Header{
+				Key:   tf.conf.IdempotencyHeader,
+				Value: tf.idempotencyKey(d),
+			})
+		}
+
+		resp, err := tf.client.Post(ctx, url, d, h...)
 		if err != nil {
 			return err
 		}
@@ -180,3 +212,15 @@ func (tf *sendHTTPPost) send(ctx context.Context, key string) error {
 
 	return nil
 }
+
+func (tf *sendHTTPPost) idempotencyKey(data []byte) string {
+	if tf.conf.IdempotencyKey != "" {
+		msg := message.New().SetData(data)
+		if v := msg.GetValue(tf.conf.IdempotencyKey); v.Exists() {
+			return v.String()
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%x", sum)
+}
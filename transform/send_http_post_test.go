@@ -0,0 +1,218 @@
+package transform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &sendHTTPPost{}
+
+// sendHTTPPostApply sends the data followed by a control message.
+func sendHTTPPostApply(t *testing.T, tf *sendHTTPPost, data ...string) error {
+	t.Helper()
+
+	ctx := context.TODO()
+	for _, d := range data {
+		if _, err := tf.Transform(ctx, message.New().SetData([]byte(d))); err != nil {
+			return err
+		}
+	}
+
+	_, err := tf.Transform(ctx, message.New().AsControl())
+	return err
+}
+
+func TestSendHTTPPostTLS(t *testing.T) {
+	var requests int
+	serv := httptest.NewTLSServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer serv.Close()
+
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serv.Certificate().Raw}))
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		err      bool
+	}{
+		{
+			"untrusted",
+			map[string]interface{}{
+				"url": serv.URL,
+			},
+			true,
+		},
+		{
+			"ca pem",
+			map[string]interface{}{
+				"url": serv.URL,
+				"tls": map[string]interface{}{
+					"ca_certificate": ca,
+				},
+			},
+			false,
+		},
+		{
+			"ca file",
+			map[string]interface{}{
+				"url": serv.URL,
+				"tls": map[string]interface{}{
+					"ca_certificate": sendTestFile(t, "ca.pem", ca),
+				},
+			},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests = 0
+
+			tf, err := newSendHTTPPost(context.TODO(), config.Config{Settings: test.settings})
+			if err != nil {
+				t.Fatal(err)
+			}
+			tf.client.Client.RetryMax = 0
+
+			err = sendHTTPPostApply(t, tf, `{"a":"b"}`)
+			if test.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if requests != 1 {
+				t.Errorf("expected 1 request, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestSendHTTPPostTLSInvalid(t *testing.T) {
+	_, err := newSendHTTPPost(context.TODO(), config.Config{
+		Settings: map[string]interface{}{
+			"url": "https://localhost",
+			"tls": map[string]interface{}{
+				"client_certificate": "-----BEGIN CERTIFICATE-----",
+			},
+		},
+	})
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSendHTTPPostIdempotencyKey(t *testing.T) {
+	data := []byte(`{"id":"abc","meta":{"key":"xyz","n":123}}`)
+	sum := sha256.Sum256(data)
+	hash := fmt.Sprintf("%x", sum)
+
+	tests := []struct {
+		name     string
+		key      string
+		data     []byte
+		expected string
+	}{
+		{"key", "id", data, "abc"},
+		{"nested key", "meta.key", data, "xyz"},
+		{"number", "meta.n", data, "123"},
+		{"missing key", "missing", data, hash},
+		{"no key", "", data, hash},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newSendHTTPPost(context.TODO(), config.Config{
+				Settings: map[string]interface{}{
+					"url":                "http://localhost",
+					"idempotency_header": "Idempotency-Key",
+					"idempotency_key":    test.key,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if k := tf.idempotencyKey(test.data); k != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, k)
+			}
+		})
+	}
+}
+
+func TestSendHTTPPostIdempotencyRetry(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string][]string)
+
+	serv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			keys[string(body)] = append(keys[string(body)], r.Header.Get("Idempotency-Key"))
+
+			// The first attempt for each request fails and is retried.
+			if len(keys[string(body)]) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer serv.Close()
+
+	tf, err := newSendHTTPPost(context.TODO(), config.Config{
+		Settings: map[string]interface{}{
+			"url":                serv.URL,
+			"idempotency_header": "Idempotency-Key",
+			"batch": map[string]interface{}{
+				"count": 1,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.client.Client.RetryWaitMin = time.Millisecond
+	tf.client.Client.RetryWaitMax = time.Millisecond
+
+	data := []string{`{"a":"b"}`, `{"a":"c"}`}
+	if err := sendHTTPPostApply(t, tf, data...); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, d := range data {
+		sum := sha256.Sum256([]byte(d))
+		expected := []string{fmt.Sprintf("%x", sum), fmt.Sprintf("%x", sum)}
+
+		if !slices.Equal(keys[d], expected) {
+			t.Errorf("%s: expected %v, got %v", d, expected, keys[d])
+		}
+	}
+}
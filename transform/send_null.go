@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/log"
+	"github.com/brexhq/substation/message"
+)
+
+type sendNullConfig struct {
+	// LogCount determines if the number of discarded messages is logged when
+	// the transform receives a control message. Counts are logged at the debug
+	// level.
+	//
+	// This is optional and defaults to false.
+	LogCount bool `json:"log_count"`
+}
+
+func (c *sendNullConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func newSendNull(_ context.Context, cfg config.Config) (*sendNull, error) {
+	conf := sendNullConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: send_null: %v", err)
+	}
+
+	tf := sendNull{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// sendNull discards all data. This can be used to benchmark transforms or to
+// replace other send transforms during testing (e.g., SUBSTATION_SINK_TYPE=send_null).
+type sendNull struct {
+	conf sendNullConfig
+
+	count atomic.Int64
+}
+
+func (tf *sendNull) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		count := tf.count.Swap(0)
+		if tf.conf.LogCount {
+			log.WithField("count", count).Debug("send_null: discarded messages")
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	tf.count.Add(1)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *sendNull) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,316 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/http"
+	"github.com/brexhq/substation/internal/secrets"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+const sendPrometheusPushgatewayDefaultMaxSeries = 1000
+
+// errSendPrometheusPushgatewaySeriesLimit is returned when the number of unique
+// series (metric names and label sets) exceeds the configured limit. If this error
+// occurs, then conditions or transforms should be applied to reduce the cardinality
+// of the labels.
+var errSendPrometheusPushgatewaySeriesLimit = fmt.Errorf("series limit exceeded")
+
+var (
+	// sendPrometheusPushgatewayEscaper escapes label values as required by the
+	// text exposition format.
+	sendPrometheusPushgatewayEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	sendPrometheusPushgatewayMetricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	sendPrometheusPushgatewayLabelName  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+type sendPrometheusPushgatewayConfig struct {
+	// URL is the Pushgateway endpoint that metrics are sent to, including the
+	// grouping key (e.g., http://localhost:9091/metrics/job/substation).
+	URL string `json:"url"`
+	// Headers are an array of objects that contain HTTP headers sent in the request.
+	//
+	// This is optional and has no default.
+	Headers map[string]string `json:"headers"`
+	// NameKey retrieves the name of the metric from each message.
+	NameKey string `json:"name_key"`
+	// ValueKey retrieves the value of the metric from each message.
+	ValueKey string `json:"value_key"`
+	// LabelsKey retrieves an object of labels from each message.
+	//
+	// This is optional and has no default.
+	LabelsKey string `json:"labels_key"`
+	// Type is the Prometheus metric type.
+	//
+	// Must be one of:
+	//	- counter
+	//	- gauge
+	//	- untyped
+	//
+	// This is optional and defaults to untyped.
+	Type string `json:"type"`
+	// MaxSeries is the maximum number of unique series (metric name and labels)
+	// that can be pushed at once.
+	//
+	// This is optional and defaults to 1000.
+	MaxSeries int `json:"max_series"`
+}
+
+func (c *sendPrometheusPushgatewayConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *sendPrometheusPushgatewayConfig) Validate() error {
+	if c.URL == "" {
+		return fmt.Errorf("url: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.NameKey == "" {
+		return fmt.Errorf("name_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.ValueKey == "" {
+		return fmt.Errorf("value_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"counter",
+			"gauge",
+			"untyped",
+		},
+		c.Type) {
+		return fmt.Errorf("type %q: %v", c.Type, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newSendPrometheusPushgateway(_ context.Context, cfg config.Config) (*sendPrometheusPushgateway, error) {
+	conf := sendPrometheusPushgatewayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: send_prometheus_pushgateway: %v", err)
+	}
+
+	if conf.Type == "" {
+		conf.Type = "untyped"
+	}
+
+	if conf.MaxSeries <= 0 {
+		conf.MaxSeries = sendPrometheusPushgatewayDefaultMaxSeries
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: send_prometheus_pushgateway: %v", err)
+	}
+
+	tf := sendPrometheusPushgateway{
+		conf:   conf,
+		series: make(map[string]sendPrometheusPushgatewaySample),
+	}
+
+	tf.client.Setup()
+	if _, ok := os.LookupEnv("AWS_XRAY_DAEMON_ADDRESS"); ok {
+		tf.client.EnableXRay()
+	}
+
+	return &tf, nil
+}
+
+type sendPrometheusPushgatewaySample struct {
+	name   string
+	labels string
+	value  float64
+}
+
+// sendPrometheusPushgateway pushes metrics from messages to a Prometheus
+// Pushgateway when the transform receives a control message. Only the most
+// recent value of each series is pushed. Messages are skipped if the metric
+// name or a label name is missing or not valid in the Prometheus data model,
+// or if the value is not a number.
+type sendPrometheusPushgateway struct {
+	conf sendPrometheusPushgatewayConfig
+
+	// client is safe for concurrent use.
+	client http.HTTP
+
+	mu sync.Mutex
+	// series contains the most recent sample for each unique series.
+	series map[string]sendPrometheusPushgatewaySample
+}
+
+func (tf *sendPrometheusPushgateway) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		if len(tf.series) == 0 {
+			return []*message.Message{msg}, nil
+		}
+
+		if err := tf.send(ctx); err != nil {
+			return nil, fmt.Errorf("transform: send_prometheus_pushgateway: %v", err)
+		}
+
+		tf.series = make(map[string]sendPrometheusPushgatewaySample)
+		return []*message.Message{msg}, nil
+	}
+
+	name := msg.GetValue(tf.conf.NameKey).String()
+	if !sendPrometheusPushgatewayMetricName.MatchString(name) {
+		return []*message.Message{msg}, nil
+	}
+
+	value, ok := sendPrometheusPushgatewayValue(msg.GetValue(tf.conf.ValueKey))
+	if !ok {
+		return []*message.Message{msg}, nil
+	}
+
+	labels, ok := tf.labels(msg)
+	if !ok {
+		return []*message.Message{msg}, nil
+	}
+
+	key := name + labels
+	if _, ok := tf.series[key]; !ok && len(tf.series) >= tf.conf.MaxSeries {
+		return nil, fmt.Errorf("transform: send_prometheus_pushgateway: %v", errSendPrometheusPushgatewaySeriesLimit)
+	}
+
+	tf.series[key] = sendPrometheusPushgatewaySample{
+		name:   name,
+		labels: labels,
+		value:  value,
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *sendPrometheusPushgateway) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// labels returns the labels in the text exposition format (e.g., {a="b",c="d"}).
+// Labels are sorted so that the same label set always produces the same series.
+// If any label name is not valid, then false is returned.
+func (tf *sendPrometheusPushgateway) labels(msg *message.Message) (string, bool) {
+	if tf.conf.LabelsKey == "" {
+		return "", true
+	}
+
+	m := msg.GetValue(tf.conf.LabelsKey).Map()
+	if len(m) == 0 {
+		return "", true
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !sendPrometheusPushgatewayLabelName.MatchString(k) {
+			return "", false
+		}
+
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, k, sendPrometheusPushgatewayEscaper.Replace(m[k].String()))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}", true
+}
+
+// sendPrometheusPushgatewayValue returns the value as a float. Numbers and
+// strings that contain numbers (including "NaN" and "+Inf", which are valid
+// sample values) are supported.
+func sendPrometheusPushgatewayValue(v message.Value) (float64, bool) {
+	switch val := v.Value().(type) {
+	case float64:
+		return val, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func (tf *sendPrometheusPushgateway) send(ctx context.Context) error {
+	samples := make([]sendPrometheusPushgatewaySample, 0, len(tf.series))
+	for _, s := range tf.series {
+		samples = append(samples, s)
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].name != samples[j].name {
+			return samples[i].name < samples[j].name
+		}
+
+		return samples[i].labels < samples[j].labels
+	})
+
+	var buf bytes.Buffer
+	var prev string
+	for _, s := range samples {
+		// The TYPE comment must appear once before the first sample of each metric.
+		if s.name != prev {
+			fmt.Fprintf(&buf, "# TYPE %s %s\n", s.name, tf.conf.Type)
+			prev = s.name
+		}
+
+		fmt.Fprintf(&buf, "%s%s %s\n", s.name, s.labels, strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+
+	headers := []http.Header{
+		{
+			Key:   "Content-Type",
+			Value: "text/plain; version=0.0.4",
+		},
+	}
+
+	for k, v := range tf.conf.Headers {
+		// Retrieve secret and interpolate with header value.
+		v, err := secrets.Interpolate(ctx, v)
+		if err != nil {
+			return err
+		}
+
+		headers = append(headers, http.Header{
+			Key:   k,
+			Value: v,
+		})
+	}
+
+	// Retrieve secret and interpolate with URL.
+	url, err := secrets.Interpolate(ctx, tf.conf.URL)
+	if err != nil {
+		return err
+	}
+
+	resp, err := tf.client.Post(ctx, url, buf.Bytes(), headers...)
+	if err != nil {
+		return err
+	}
+
+	//nolint:errcheck // Response body is discarded to avoid resource leaks.
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package transform
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &sendPrometheusPushgateway{}
+
+func TestSendPrometheusPushgatewayConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		err      bool
+	}{
+		{
+			"valid",
+			map[string]interface{}{
+				"url":       "http://localhost:9091/metrics/job/substation",
+				"name_key":  "name",
+				"value_key": "value",
+			},
+			false,
+		},
+		{
+			"missing url",
+			map[string]interface{}{
+				"name_key":  "name",
+				"value_key": "value",
+			},
+			true,
+		},
+		{
+			"missing name_key",
+			map[string]interface{}{
+				"url":       "http://localhost:9091/metrics/job/substation",
+				"value_key": "value",
+			},
+			true,
+		},
+		{
+			"missing value_key",
+			map[string]interface{}{
+				"url":      "http://localhost:9091/metrics/job/substation",
+				"name_key": "name",
+			},
+			true,
+		},
+		{
+			"invalid type",
+			map[string]interface{}{
+				"url":       "http://localhost:9091/metrics/job/substation",
+				"name_key":  "name",
+				"value_key": "value",
+				"type":      "histogram",
+			},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newSendPrometheusPushgateway(context.TODO(), config.Config{Settings: test.settings})
+			if test.err && err == nil {
+				t.Error("expected error")
+			}
+
+			if !test.err && err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSendPrometheusPushgateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		test     []string
+		expected string
+	}{
+		{
+			"gauge",
+			map[string]interface{}{
+				"type": "gauge",
+			},
+			[]string{
+				`{"name":"b_total","value":2}`,
+				`{"name":"a_total","value":"1.5"}`,
+				// The most recent value of each series is sent.
+				`{"name":"b_total","value":3}`,
+			},
+			"# TYPE a_total gauge\na_total 1.5\n# TYPE b_total gauge\nb_total 3\n",
+		},
+		{
+			"labels",
+			map[string]interface{}{
+				"labels_key": "labels",
+			},
+			[]string{
+				`{"name":"a","value":1,"labels":{"y":"2","x":"1"}}`,
+				`{"name":"a","value":2,"labels":{"x":"say \"hi\""}}`,
+			},
+			"# TYPE a untyped\na{x=\"1\",y=\"2\"} 1\na{x=\"say \\\"hi\\\"\"} 2\n",
+		},
+		{
+			"skipped",
+			map[string]interface{}{
+				"labels_key": "labels",
+			},
+			[]string{
+				`{"name":"a","value":1}`,
+				// Missing and invalid names.
+				`{"value":1}`,
+				`{"name":"1a","value":1}`,
+				`{"name":"a","value":1,"labels":{"1x":"y"}}`,
+				// Missing and non-numeric values.
+				`{"name":"b"}`,
+				`{"name":"b","value":"x"}`,
+				`{"name":"b","value":true}`,
+				`{"name":"b","value":{"c":1}}`,
+			},
+			"# TYPE a untyped\na 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body string
+			var requests int
+			serv := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests++
+					b, _ := io.ReadAll(r.Body)
+					body = string(b)
+
+					if ct := r.Header.Get("Content-Type"); ct != "text/plain; version=0.0.4" {
+						t.Errorf("unexpected content type %q", ct)
+					}
+
+					w.WriteHeader(http.StatusOK)
+				}))
+			defer serv.Close()
+
+			settings := map[string]interface{}{
+				"url":       serv.URL,
+				"name_key":  "name",
+				"value_key": "value",
+			}
+
+			for k, v := range test.settings {
+				settings[k] = v
+			}
+
+			ctx := context.TODO()
+			tf, err := newSendPrometheusPushgateway(ctx, config.Config{Settings: settings})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, d := range test.test {
+				msg := message.New().SetData([]byte(d))
+				result, err := tf.Transform(ctx, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Messages are always passed through.
+				if len(result) != 1 || string(result[0].Data()) != d {
+					t.Errorf("expected %s, got %v", d, result)
+				}
+			}
+
+			if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+				t.Fatal(err)
+			}
+
+			if body != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, body)
+			}
+
+			// Series are reset after they are sent, so nothing is sent with
+			// the next control message.
+			if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+				t.Fatal(err)
+			}
+
+			if requests != 1 {
+				t.Errorf("expected 1 request, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestSendPrometheusPushgatewaySeriesLimit(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newSendPrometheusPushgateway(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"url":        "http://localhost:9091/metrics/job/substation",
+			"name_key":   "name",
+			"value_key":  "value",
+			"max_series": 1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"name":"a","value":1}`))); err != nil {
+		t.Fatal(err)
+	}
+
+	// Existing series can be updated.
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"name":"a","value":2}`))); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"name":"b","value":1}`))); err == nil {
+		t.Error("expected error")
+	}
+}
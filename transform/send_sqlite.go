@@ -0,0 +1,339 @@
+package transform
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/aggregate"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+	_ "modernc.org/sqlite" // Registers the sqlite driver.
+)
+
+// sendSQLiteIdentifier matches table and column names that are safe to use
+// in SQL statements.
+var sendSQLiteIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type sendSQLiteColumn struct {
+	// Name is the name of the column in the table.
+	Name string `json:"name"`
+	// Key retrieves the value for the column from each message.
+	// If the key does not exist in the message or the value is null,
+	// then NULL is inserted.
+	Key string `json:"key"`
+	// Type is the SQLite type of the column. Values that are not numbers
+	// or numeric strings are inserted as NULL into integer and real columns.
+	//
+	// Must be one of:
+	//	- text
+	//	- integer
+	//	- real
+	//
+	// This is optional and defaults to text.
+	Type string `json:"type"`
+}
+
+type sendSQLiteConfig struct {
+	// FilePath is the path to the SQLite database. The database is created
+	// if it does not exist.
+	FilePath string `json:"file_path"`
+	// Table is the table that rows are inserted into. The table is created
+	// if it does not exist.
+	Table string `json:"table"`
+	// Columns map values from each message to columns in the table.
+	//
+	// This is optional and has no default.
+	Columns []sendSQLiteColumn `json:"columns"`
+	// RecordColumn is the column that contains the full message as JSON.
+	//
+	// This is optional and defaults to record.
+	RecordColumn string `json:"record_column"`
+	// AuxTransforms are applied to batched data before it is sent.
+	AuxTransforms []config.Config `json:"auxiliary_transforms"`
+
+	Object iconfig.Object `json:"object"`
+	Batch  iconfig.Batch  `json:"batch"`
+}
+
+func (c *sendSQLiteConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *sendSQLiteConfig) Validate() error {
+	if c.FilePath == "" {
+		return fmt.Errorf("file_path: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Table == "" {
+		return fmt.Errorf("table: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !sendSQLiteIdentifier.MatchString(c.Table) {
+		return fmt.Errorf("table %q: %v", c.Table, errors.ErrInvalidOption)
+	}
+
+	if !sendSQLiteIdentifier.MatchString(c.RecordColumn) {
+		return fmt.Errorf("record_column %q: %v", c.RecordColumn, errors.ErrInvalidOption)
+	}
+
+	names := []string{strings.ToLower(c.RecordColumn)}
+	for _, col := range c.Columns {
+		if col.Name == "" {
+			return fmt.Errorf("columns.name: %v", errors.ErrMissingRequiredOption)
+		}
+
+		if col.Key == "" {
+			return fmt.Errorf("columns.key: %v", errors.ErrMissingRequiredOption)
+		}
+
+		// Column names in SQLite are case-insensitive.
+		name := strings.ToLower(col.Name)
+		if !sendSQLiteIdentifier.MatchString(col.Name) || slices.Contains(names, name) {
+			return fmt.Errorf("columns.name %q: %v", col.Name, errors.ErrInvalidOption)
+		}
+
+		names = append(names, name)
+
+		if !slices.Contains(
+			[]string{
+				"text",
+				"integer",
+				"real",
+			},
+			col.Type) {
+			return fmt.Errorf("columns.type %q: %v", col.Type, errors.ErrInvalidOption)
+		}
+	}
+
+	return nil
+}
+
+func newSendSQLite(_ context.Context, cfg config.Config) (*sendSQLite, error) {
+	conf := sendSQLiteConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+	}
+
+	if conf.RecordColumn == "" {
+		conf.RecordColumn = "record"
+	}
+
+	for i := range conf.Columns {
+		conf.Columns[i].Type = strings.ToLower(conf.Columns[i].Type)
+		if conf.Columns[i].Type == "" {
+			conf.Columns[i].Type = "text"
+		}
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+	}
+
+	tf := sendSQLite{
+		conf: conf,
+	}
+
+	names := make([]string, 0, len(conf.Columns)+1)
+	defs := make([]string, 0, len(conf.Columns)+1)
+	for _, col := range conf.Columns {
+		names = append(names, fmt.Sprintf("%q", col.Name))
+		defs = append(defs, fmt.Sprintf("%q %s", col.Name, strings.ToUpper(col.Type)))
+	}
+
+	names = append(names, fmt.Sprintf("%q", conf.RecordColumn))
+	defs = append(defs, fmt.Sprintf("%q TEXT", conf.RecordColumn))
+
+	tf.create = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %q (%s)", conf.Table, strings.Join(defs, ", "))
+	tf.insert = fmt.Sprintf("INSERT INTO %q (%s) VALUES (%s)", conf.Table, strings.Join(names, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", "))
+
+	agg, err := aggregate.New(aggregate.Config{
+		Count:    conf.Batch.Count,
+		Size:     conf.Batch.Size,
+		Duration: conf.Batch.Duration,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+	}
+	tf.agg = agg
+
+	if len(conf.AuxTransforms) > 0 {
+		tf.tforms = make([]Transformer, len(conf.AuxTransforms))
+		for i, c := range conf.AuxTransforms {
+			t, err := New(context.Background(), c)
+			if err != nil {
+				return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+			}
+
+			tf.tforms[i] = t
+		}
+	}
+
+	return &tf, nil
+}
+
+// sendSQLite inserts messages as rows into a table in a local SQLite database.
+// Each batch is committed in a single transaction and the database is closed
+// after every commit, so a control message (e.g., at the end of a pipeline)
+// commits the final batch and leaves the database in a consistent state.
+type sendSQLite struct {
+	conf   sendSQLiteConfig
+	create string
+	insert string
+
+	mu     sync.Mutex
+	agg    *aggregate.Aggregate
+	tforms []Transformer
+}
+
+func (tf *sendSQLite) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		for key := range tf.agg.GetAll() {
+			if tf.agg.Count(key) == 0 {
+				continue
+			}
+
+			if err := tf.send(ctx, key); err != nil {
+				return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+			}
+		}
+
+		tf.agg.ResetAll()
+		return []*message.Message{msg}, nil
+	}
+
+	// If this value does not exist, then all data is batched together.
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	if ok := tf.agg.Add(key, msg.Data()); ok {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := tf.send(ctx, key); err != nil {
+		return nil, fmt.Errorf("transform: send_sqlite: %v", err)
+	}
+
+	// If data cannot be added after reset, then the batch is misconfgured.
+	tf.agg.Reset(key)
+	if ok := tf.agg.Add(key, msg.Data()); !ok {
+		return nil, fmt.Errorf("transform: send_sqlite: %v", errSendBatchMisconfigured)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *sendSQLite) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *sendSQLite) send(ctx context.Context, key string) error {
+	data, err := withTransforms(ctx, tf.tforms, tf.agg.Get(key))
+	if err != nil {
+		return err
+	}
+
+	// Ensures that the path is OS agnostic.
+	path := filepath.FromSlash(tf.conf.FilePath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o770); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, tf.create); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback is a no-op after Commit.
+
+	stmt, err := tx.PrepareContext(ctx, tf.insert)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, d := range data {
+		if _, err := stmt.ExecContext(ctx, tf.row(d)...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return db.Close()
+}
+
+// row returns the values of a row in the table. Values that do not exist in
+// the data, are null, or do not match the column type are inserted as NULL.
+func (tf *sendSQLite) row(data []byte) []interface{} {
+	msg := message.New().SetData(data)
+
+	row := make([]interface{}, 0, len(tf.conf.Columns)+1)
+	for _, col := range tf.conf.Columns {
+		v := msg.GetValue(col.Key)
+		if !v.Exists() || v.Value() == nil {
+			row = append(row, nil)
+			continue
+		}
+
+		switch col.Type {
+		case "integer":
+			if !sendSQLiteIsNumeric(v) {
+				row = append(row, nil)
+				continue
+			}
+
+			row = append(row, v.Int())
+		case "real":
+			if !sendSQLiteIsNumeric(v) {
+				row = append(row, nil)
+				continue
+			}
+
+			row = append(row, v.Float())
+		default:
+			row = append(row, v.String())
+		}
+	}
+
+	return append(row, string(data))
+}
+
+// sendSQLiteIsNumeric returns true if the value is a number or a string
+// that contains a finite number.
+func sendSQLiteIsNumeric(v message.Value) bool {
+	switch x := v.Value().(type) {
+	case float64:
+		return true
+	case string:
+		f, err := strconv.ParseFloat(x, 64)
+		return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,259 @@
+package transform
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &sendSQLite{}
+
+// sendSQLiteRows returns all rows from the table in insertion order.
+func sendSQLiteRows(t *testing.T, path, query string) [][]interface{} {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out [][]interface{}
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			t.Fatal(err)
+		}
+
+		out = append(out, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+// sendSQLiteApply sends the data followed by a control message.
+func sendSQLiteApply(t *testing.T, settings map[string]interface{}, data ...string) {
+	t.Helper()
+
+	ctx := context.TODO()
+	tf, err := newSendSQLite(ctx, config.Config{Settings: settings})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := make([]*message.Message, 0, len(data)+1)
+	for _, d := range data {
+		msgs = append(msgs, message.New().SetData([]byte(d)))
+	}
+	msgs = append(msgs, message.New().AsControl())
+
+	if _, err := Apply(ctx, []Transformer{tf}, msgs...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var sendSQLiteTests = []struct {
+	name     string
+	settings map[string]interface{}
+	query    string
+	test     []string
+	expected [][]interface{}
+}{
+	{
+		"columns",
+		map[string]interface{}{
+			"columns": []map[string]interface{}{
+				{"name": "name", "key": "user.name"},
+				{"name": "age", "key": "user.age", "type": "integer"},
+				{"name": "score", "key": "score", "type": "real"},
+			},
+		},
+		`SELECT name, age, score FROM logs`,
+		[]string{
+			`{"user":{"name":"alice","age":30},"score":1.5}`,
+			`{"user":{"name":"bob","age":"41"},"score":"2.25"}`,
+		},
+		[][]interface{}{
+			{"alice", int64(30), 1.5},
+			{"bob", int64(41), 2.25},
+		},
+	},
+	{
+		"record column",
+		map[string]interface{}{
+			"record_column": "raw",
+		},
+		`SELECT raw FROM logs`,
+		[]string{
+			`{"a":"b"}`,
+			`{"c":[1,2]}`,
+		},
+		[][]interface{}{
+			{`{"a":"b"}`},
+			{`{"c":[1,2]}`},
+		},
+	},
+	{
+		"missing keys",
+		map[string]interface{}{
+			"columns": []map[string]interface{}{
+				{"name": "name", "key": "name"},
+				{"name": "age", "key": "age", "type": "integer"},
+				{"name": "score", "key": "score", "type": "real"},
+			},
+		},
+		`SELECT name, age, score FROM logs`,
+		[]string{
+			`{"name":"alice"}`,
+			`{"name":null,"age":null,"score":null}`,
+		},
+		[][]interface{}{
+			{"alice", nil, nil},
+			{nil, nil, nil},
+		},
+	},
+	{
+		"non-numeric values",
+		map[string]interface{}{
+			"columns": []map[string]interface{}{
+				{"name": "age", "key": "age", "type": "integer"},
+				{"name": "score", "key": "score", "type": "real"},
+			},
+		},
+		`SELECT age, score FROM logs`,
+		[]string{
+			`{"age":"unknown","score":"n/a"}`,
+			`{"age":true,"score":{"a":1}}`,
+			`{"age":"","score":"NaN"}`,
+			`{"age":0,"score":0}`,
+		},
+		[][]interface{}{
+			{nil, nil},
+			{nil, nil},
+			{nil, nil},
+			{int64(0), 0.0},
+		},
+	},
+}
+
+func TestSendSQLite(t *testing.T) {
+	for _, test := range sendSQLiteTests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "db", "test.db")
+
+			settings := map[string]interface{}{
+				"file_path": path,
+				"table":     "logs",
+			}
+			for k, v := range test.settings {
+				settings[k] = v
+			}
+
+			sendSQLiteApply(t, settings, test.test...)
+
+			rows := sendSQLiteRows(t, path, test.query)
+			if !reflect.DeepEqual(rows, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, rows)
+			}
+		})
+	}
+}
+
+func TestSendSQLiteControl(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	tf, err := newSendSQLite(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"file_path": path,
+			"table":     "logs",
+			"batch": map[string]interface{}{
+				"count": 3,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []string{`{"a":1}`, `{"a":2}`} {
+		if _, err := tf.Transform(ctx, message.New().SetData([]byte(d))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The batch is not full, so nothing is committed until a control message
+	// is received.
+	if rows := sendSQLiteRows(t, path, `SELECT name FROM sqlite_master WHERE name = 'logs'`); len(rows) != 0 {
+		t.Fatalf("expected no table before control message, got %v", rows)
+	}
+
+	msgs, err := tf.Transform(ctx, message.New().AsControl())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msgs) != 1 || !msgs[0].IsControl() {
+		t.Errorf("expected control message, got %v", msgs)
+	}
+
+	expected := [][]interface{}{{`{"a":1}`}, {`{"a":2}`}}
+	if rows := sendSQLiteRows(t, path, `SELECT record FROM logs`); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	// The batch is reset after the commit, so a second control message does
+	// not insert duplicate rows.
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+		t.Fatal(err)
+	}
+
+	if rows := sendSQLiteRows(t, path, `SELECT record FROM logs`); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
+
+func TestSendSQLiteExistingTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	settings := map[string]interface{}{
+		"file_path": path,
+		"table":     "logs",
+		"columns": []map[string]interface{}{
+			{"name": "id", "key": "id", "type": "integer"},
+		},
+	}
+
+	// Each run opens the existing database and appends to the table.
+	sendSQLiteApply(t, settings, `{"id":1}`)
+	sendSQLiteApply(t, settings, `{"id":2}`, `{"id":3}`)
+
+	expected := [][]interface{}{{int64(1)}, {int64(2)}, {int64(3)}}
+	if rows := sendSQLiteRows(t, path, `SELECT id FROM logs`); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
@@ -0,0 +1,207 @@
+package transform
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	iconfig "github.com/brexhq/substation/internal/config"
+)
+
+// sendTestCertificate returns a PEM-encoded self-signed certificate and key.
+func sendTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "substation"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	priv := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: k})
+
+	return string(cert), string(priv)
+}
+
+// sendTestFile writes data to a file in a temporary directory and returns the path.
+func sendTestFile(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestSendTLSLoadPEM(t *testing.T) {
+	ctx := context.TODO()
+	cert, _ := sendTestCertificate(t)
+
+	// PEM-encoded data is returned as is.
+	b, err := sendTLSLoadPEM(ctx, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != cert {
+		t.Errorf("expected %s, got %s", cert, b)
+	}
+
+	// Anything else is read from a file.
+	b, err = sendTLSLoadPEM(ctx, sendTestFile(t, "cert.pem", cert))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != cert {
+		t.Errorf("expected %s, got %s", cert, b)
+	}
+
+	if _, err := sendTLSLoadPEM(ctx, filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSendTLSConfig(t *testing.T) {
+	ctx := context.TODO()
+	cert, key := sendTestCertificate(t)
+	certFile := sendTestFile(t, "cert.pem", cert)
+	keyFile := sendTestFile(t, "key.pem", key)
+
+	tests := []struct {
+		name  string
+		cfg   iconfig.TLS
+		err   string
+		ca    bool
+		certs int
+	}{
+		{
+			"ca pem",
+			iconfig.TLS{CACertificate: cert},
+			"",
+			true,
+			0,
+		},
+		{
+			"ca file",
+			iconfig.TLS{CACertificate: certFile},
+			"",
+			true,
+			0,
+		},
+		{
+			"invalid ca",
+			iconfig.TLS{CACertificate: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----\n"},
+			errSendTLSInvalidCA.Error(),
+			false,
+			0,
+		},
+		{
+			"invalid ca file",
+			iconfig.TLS{CACertificate: keyFile},
+			errSendTLSInvalidCA.Error(),
+			false,
+			0,
+		},
+		{
+			"missing ca file",
+			iconfig.TLS{CACertificate: filepath.Join(t.TempDir(), "missing.pem")},
+			"ca_certificate",
+			false,
+			0,
+		},
+		{
+			"client pem",
+			iconfig.TLS{ClientCertificate: cert, ClientKey: key},
+			"",
+			false,
+			1,
+		},
+		{
+			"client file",
+			iconfig.TLS{ClientCertificate: certFile, ClientKey: keyFile},
+			"",
+			false,
+			1,
+		},
+		{
+			"client certificate without key",
+			iconfig.TLS{ClientCertificate: cert},
+			"client_certificate and client_key",
+			false,
+			0,
+		},
+		{
+			"client key without certificate",
+			iconfig.TLS{ClientKey: key},
+			"client_certificate and client_key",
+			false,
+			0,
+		},
+		{
+			"client key mismatch",
+			iconfig.TLS{ClientCertificate: cert, ClientKey: cert},
+			"tls",
+			false,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := sendTLSConfig(ctx, test.cfg)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected error containing %q, got %v", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (conf.RootCAs != nil) != test.ca {
+				t.Errorf("expected root CAs %v, got %v", test.ca, conf.RootCAs != nil)
+			}
+
+			if len(conf.Certificates) != test.certs {
+				t.Errorf("expected %d certificates, got %d", test.certs, len(conf.Certificates))
+			}
+		})
+	}
+}
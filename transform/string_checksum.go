@@ -0,0 +1,138 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type stringChecksumConfig struct {
+	// Algorithm is the checksum algorithm that validates the string.
+	//
+	// Must be one of:
+	//	- luhn: mod 10 checksum used by payment card numbers
+	//	- mod10: alias of luhn
+	//
+	// This is optional and defaults to luhn.
+	Algorithm string `json:"algorithm"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringChecksumConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringChecksumConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"luhn",
+			"mod10",
+		},
+		c.Algorithm) {
+		return fmt.Errorf("algorithm %q: %v", c.Algorithm, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringChecksum(_ context.Context, cfg config.Config) (*stringChecksum, error) {
+	conf := stringChecksumConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_checksum: %v", err)
+	}
+
+	if conf.Algorithm == "" {
+		conf.Algorithm = "luhn"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_checksum: %v", err)
+	}
+
+	tf := stringChecksum{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// stringChecksum validates the checksum of a string and replaces it with a
+// boolean. Strings that contain characters other than digits are not valid.
+type stringChecksum struct {
+	conf     stringChecksumConfig
+	isObject bool
+}
+
+func (tf *stringChecksum) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		valid := strLuhn(string(msg.Data()))
+		msg.SetData([]byte(strconv.FormatBool(valid)))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, strLuhn(value.String())); err != nil {
+		return nil, fmt.Errorf("transform: string_checksum: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringChecksum) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// strLuhn returns true if the string is a sequence of at least two digits
+// with a valid Luhn checksum.
+func strLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+
+	var sum int
+	for i := 0; i < len(s); i++ {
+		c := s[len(s)-1-i]
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,159 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringChecksum{}
+
+var stringChecksumTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{},
+		[]byte(`4111111111111111`),
+		[][]byte{
+			[]byte(`true`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`4111111111111112`),
+		[][]byte{
+			[]byte(`false`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"algorithm": "mod10",
+			},
+		},
+		[]byte(`79927398713`),
+		[][]byte{
+			[]byte(`true`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`4111-1111-1111-1111`),
+		[][]byte{
+			[]byte(`false`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`0`),
+		[][]byte{
+			[]byte(`false`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"378282246310005"}`),
+		[][]byte{
+			[]byte(`{"a":"378282246310005","b":true}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":378282246310006}`),
+		[][]byte{
+			[]byte(`{"a":378282246310006,"b":false}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"c":"378282246310005"}`),
+		[][]byte{
+			[]byte(`{"c":"378282246310005"}`),
+		},
+	},
+}
+
+func TestStringChecksum(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringChecksumTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringChecksum(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringChecksum(b *testing.B, tf *stringChecksum, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringChecksum(b *testing.B) {
+	for _, test := range stringChecksumTests {
+		tf, err := newStringChecksum(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringChecksum(b, tf, test.test)
+			},
+		)
+	}
+}
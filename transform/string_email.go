@@ -0,0 +1,145 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringEmailConfig struct {
+	// StripTags determines if tags are removed from the local part of the
+	// address (e.g., "user+tag@example.com" is "user@example.com").
+	//
+	// This is optional and defaults to false.
+	StripTags bool `json:"strip_tags"`
+	// LowercaseLocal determines if the local part of the address is converted
+	// to lowercase. The domain is always converted to lowercase.
+	//
+	// This is optional and defaults to false.
+	LowercaseLocal bool `json:"lowercase_local"`
+	// ValidKey is the key where a boolean that indicates if the address is
+	// valid is put. If the address is invalid, then the value of the target key
+	// is not changed.
+	//
+	// This is optional and has no default (validity is not put into the object).
+	ValidKey string `json:"valid_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringEmailConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringEmailConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newStringEmail(_ context.Context, cfg config.Config) (*stringEmail, error) {
+	conf := stringEmailConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_email: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_email: %v", err)
+	}
+
+	tf := stringEmail{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// stringEmail validates and normalizes email addresses, which makes addresses
+// that are received with different formatting (e.g., "User <USER@Example.COM>")
+// equal when they are compared. Invalid addresses are not dropped, instead they
+// are identified by the valid key.
+type stringEmail struct {
+	conf stringEmailConfig
+}
+
+func (tf *stringEmail) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	addr, ok := tf.normalize(value.String())
+	if ok {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, addr); err != nil {
+			return nil, fmt.Errorf("transform: string_email: %v", err)
+		}
+	}
+
+	if tf.conf.ValidKey != "" {
+		if err := msg.SetValue(tf.conf.ValidKey, ok); err != nil {
+			return nil, fmt.Errorf("transform: string_email: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringEmail) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// normalize returns the normalized address and true if the address is valid.
+func (tf *stringEmail) normalize(s string) (string, bool) {
+	a, err := mail.ParseAddress(strings.TrimSpace(s))
+	if err != nil {
+		return "", false
+	}
+
+	// The local part can contain "@" if it is quoted (e.g., "a@b"@example.com),
+	// but the domain cannot.
+	i := strings.LastIndex(a.Address, "@")
+	if i == -1 {
+		return "", false
+	}
+
+	local, domain := a.Address[:i], a.Address[i+1:]
+	// Addresses without a dot in the domain are valid, but are never used
+	// outside of local networks.
+	if !strings.Contains(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", false
+	}
+
+	if tf.conf.StripTags {
+		if l, _, found := strings.Cut(local, "+"); found && l != "" {
+			local = l
+		}
+	}
+
+	if tf.conf.LowercaseLocal {
+		local = strings.ToLower(local)
+	}
+
+	// The address is formatted by the mail package so that local parts that
+	// require quoting are quoted. Addresses without a name are formatted as
+	// <local@domain>.
+	addr := mail.Address{Address: local + "@" + strings.ToLower(domain)}
+	return strings.TrimSuffix(strings.TrimPrefix(addr.String(), "<"), ">"), true
+}
@@ -0,0 +1,152 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringEmail{}
+
+var stringEmailTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"User.Name+news@Example.COM"}`),
+		[][]byte{
+			[]byte(`{"a":"User.Name+news@Example.COM","b":"User.Name+news@example.com","valid":true}`),
+		},
+	},
+	{
+		"object strip_tags",
+		config.Config{
+			Settings: map[string]interface{}{
+				"strip_tags":      true,
+				"lowercase_local": true,
+				"valid_key":       "valid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"User Name <User.Name+news@Example.COM>"}`),
+		[][]byte{
+			[]byte(`{"a":"User Name <User.Name+news@Example.COM>","b":"user.name@example.com","valid":true}`),
+		},
+	},
+	{
+		"object quoted local",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"\"a@b\"@Example.COM"}`),
+		[][]byte{
+			[]byte(`{"a":"\"a@b\"@Example.COM","b":"\"a@b\"@example.com","valid":true}`),
+		},
+	},
+	{
+		"object invalid",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"user@@example.com"}`),
+		[][]byte{
+			[]byte(`{"a":"user@@example.com","valid":false}`),
+		},
+	},
+	{
+		"object invalid domain",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"user@localhost"}`),
+		[][]byte{
+			[]byte(`{"a":"user@localhost","valid":false}`),
+		},
+	},
+}
+
+func TestStringEmail(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringEmailTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringEmail(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringEmail(b *testing.B, tf *stringEmail, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringEmail(b *testing.B) {
+	for _, test := range stringEmailTests {
+		tf, err := newStringEmail(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringEmail(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,137 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"unicode/utf8"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type stringEntropyConfig struct {
+	// Base is the base of the logarithm that computes the entropy.
+	//
+	// Must be one of:
+	//	- 2: entropy is measured in bits
+	//	- e: entropy is measured in nats
+	//
+	// This is optional and defaults to 2.
+	Base string `json:"base"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringEntropyConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringEntropyConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"2",
+			"e",
+		},
+		c.Base) {
+		return fmt.Errorf("base %q: %v", c.Base, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringEntropy(_ context.Context, cfg config.Config) (*stringEntropy, error) {
+	conf := stringEntropyConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_entropy: %v", err)
+	}
+
+	if conf.Base == "" {
+		conf.Base = "2"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_entropy: %v", err)
+	}
+
+	tf := stringEntropy{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// stringEntropy computes the Shannon entropy of the characters in a string.
+// Random strings (e.g., domains that are generated by malware) have higher
+// entropy than natural language strings of the same length.
+type stringEntropy struct {
+	conf     stringEntropyConfig
+	isObject bool
+}
+
+func (tf *stringEntropy) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		e := tf.entropy(string(msg.Data()))
+		msg.SetData([]byte(numberFloat64ToString(e)))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, tf.entropy(value.String())); err != nil {
+		return nil, fmt.Errorf("transform: string_entropy: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringEntropy) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *stringEntropy) entropy(s string) float64 {
+	n := utf8.RuneCountInString(s)
+	if n == 0 {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var e float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		e -= p * math.Log(p)
+	}
+
+	if tf.conf.Base == "2" {
+		e /= math.Ln2
+	}
+
+	// Rounding removes floating point errors (e.g., 1.9999999999999998).
+	return math.Round(e*1e9) / 1e9
+}
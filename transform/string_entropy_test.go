@@ -0,0 +1,160 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringEntropy{}
+
+var stringEntropyTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`abcd`),
+		[][]byte{
+			[]byte(`2`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`aaaa`),
+		[][]byte{
+			[]byte(`0`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"base": "e",
+			},
+		},
+		[]byte(`abcd`),
+		[][]byte{
+			[]byte(`1.386294361`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"google"}`),
+		[][]byte{
+			[]byte(`{"a":"google","b":1.918295834}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"x8fk2j9qzv7w"}`),
+		[][]byte{
+			[]byte(`{"a":"x8fk2j9qzv7w","b":3.584962501}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"héllo"}`),
+		[][]byte{
+			[]byte(`{"a":"héllo","b":1.921928095}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":""}`),
+		[][]byte{
+			[]byte(`{"a":"","b":0}`),
+		},
+	},
+}
+
+func TestStringEntropy(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringEntropyTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringEntropy(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringEntropy(b *testing.B, tf *stringEntropy, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringEntropy(b *testing.B) {
+	for _, test := range stringEntropyTests {
+		tf, err := newStringEntropy(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringEntropy(b, tf, test.test)
+			},
+		)
+	}
+}
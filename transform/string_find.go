@@ -0,0 +1,196 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringFindConfig struct {
+	// Pattern is the regular expression used to find matches.
+	//
+	// If the pattern contains capture groups, then each match is an
+	// array of the captured values. If the pattern contains named capture
+	// groups, then each match is an object of the captured values keyed
+	// by the group names.
+	Pattern string `json:"pattern"`
+	re      *regexp.Regexp
+
+	// Limit is the maximum number of matches to find.
+	//
+	// This is optional and defaults to 0, which means that all matches
+	// are found.
+	Limit int `json:"limit"`
+	// Nth is the position of the single match to return (e.g., 1 is the first
+	// match). Negative values count backwards from the last match (e.g., -1 is
+	// the last match).
+	//
+	// This is optional and defaults to 0, which means that an array of all
+	// matches is returned.
+	Nth int `json:"nth"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringFindConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringFindConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Pattern == "" {
+		return fmt.Errorf("pattern: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Limit < 0 {
+		return fmt.Errorf("limit: %v", errors.ErrInvalidOption)
+	}
+
+	re, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return fmt.Errorf("pattern: %v", err)
+	}
+
+	c.re = re
+
+	return nil
+}
+
+func newStringFind(_ context.Context, cfg config.Config) (*stringFind, error) {
+	conf := stringFindConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_find: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_find: %v", err)
+	}
+
+	tf := stringFind{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	for _, name := range conf.re.SubexpNames() {
+		if name != "" {
+			tf.containsNamedGroup = true
+			break
+		}
+	}
+
+	return &tf, nil
+}
+
+type stringFind struct {
+	conf               stringFindConfig
+	isObject           bool
+	containsNamedGroup bool
+}
+
+func (tf *stringFind) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	limit := tf.conf.Limit
+	if limit == 0 {
+		limit = -1
+	}
+
+	subs := tf.conf.re.FindAllStringSubmatch(value.String(), limit)
+	matches := make([]interface{}, len(subs))
+	for i, s := range subs {
+		matches[i] = tf.match(s)
+	}
+
+	var result interface{} = matches
+	if tf.conf.Nth != 0 {
+		idx := tf.conf.Nth - 1
+		if tf.conf.Nth < 0 {
+			idx = len(matches) + tf.conf.Nth
+		}
+
+		// If the match does not exist, then the message is unchanged.
+		if idx < 0 || idx >= len(matches) {
+			return []*message.Message{msg}, nil
+		}
+
+		result = matches[idx]
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_find: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: string_find: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	// A single match without capture groups is a string, which is
+	// not quoted if it is the message data.
+	if s, ok := result.(string); ok {
+		msg.SetData([]byte(s))
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringFind) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// match returns the full match if the pattern has no capture groups, otherwise
+// it returns the captured values as an array or, if the pattern has named groups,
+// an object.
+func (tf *stringFind) match(sub []string) interface{} {
+	if len(sub) == 1 {
+		return sub[0]
+	}
+
+	if tf.containsNamedGroup {
+		m := make(map[string]string)
+		for i, name := range tf.conf.re.SubexpNames() {
+			if i == 0 || name == "" {
+				continue
+			}
+
+			m[name] = sub[i]
+		}
+
+		return m
+	}
+
+	return sub[1:]
+}
@@ -0,0 +1,213 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringFind{}
+
+var stringFindTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `https?://[^ ]+`,
+			},
+		},
+		[]byte(`GET http://a.com and https://b.com`),
+		[][]byte{
+			[]byte(`["http://a.com","https://b.com"]`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `https?://[^ ]+`,
+				"limit":   1,
+			},
+		},
+		[]byte(`GET http://a.com and https://b.com`),
+		[][]byte{
+			[]byte(`["http://a.com"]`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `https?://[^ ]+`,
+				"nth":     -1,
+			},
+		},
+		[]byte(`GET http://a.com and https://b.com`),
+		[][]byte{
+			[]byte(`https://b.com`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `(\w+)=(\w+)`,
+			},
+		},
+		[]byte(`a=b c=d`),
+		[][]byte{
+			[]byte(`[["a","b"],["c","d"]]`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `(?P<k>\w+)=(?P<v>\w+)`,
+				"nth":     2,
+			},
+		},
+		[]byte(`a=b c=d`),
+		[][]byte{
+			[]byte(`{"k":"c","v":"d"}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern": `\d+`,
+				"nth":     3,
+			},
+		},
+		[]byte(`1 2`),
+		[][]byte{
+			[]byte(`1 2`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"pattern": `\d+`,
+			},
+		},
+		[]byte(`{"a":"1 22 333"}`),
+		[][]byte{
+			[]byte(`{"a":"1 22 333","b":["1","22","333"]}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"pattern": `\d+`,
+			},
+		},
+		[]byte(`{"a":"bcd"}`),
+		[][]byte{
+			[]byte(`{"a":"bcd","b":[]}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"pattern": `(?P<k>\w+)=(?P<v>\w+)`,
+			},
+		},
+		[]byte(`{"a":"x=1 y=2"}`),
+		[][]byte{
+			[]byte(`{"a":"x=1 y=2","b":[{"k":"x","v":"1"},{"k":"y","v":"2"}]}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"pattern": `\d+`,
+				"nth":     1,
+			},
+		},
+		[]byte(`{"a":"1 22 333"}`),
+		[][]byte{
+			[]byte(`{"a":"1 22 333","b":"1"}`),
+		},
+	},
+}
+
+func TestStringFind(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringFindTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringFind(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringFind(b *testing.B, tf *stringFind, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringFind(b *testing.B) {
+	for _, test := range stringFindTests {
+		tf, err := newStringFind(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringFind(b, tf, test.test)
+			},
+		)
+	}
+}
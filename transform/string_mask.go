@@ -0,0 +1,154 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringMaskConfig struct {
+	// Pattern is the regular expression used to identify values to mask.
+	//
+	// This is optional and has no default. If not set, then the entire value
+	// is masked.
+	Pattern string `json:"pattern"`
+	re      *regexp.Regexp
+	// Character is the character that replaces each masked character.
+	//
+	// This is optional and defaults to "*".
+	Character string `json:"character"`
+	// PreserveLast is the number of characters at the end of each masked value
+	// that are not masked (e.g., 4 masks all but the last four digits of a credit
+	// card number). Values that are not longer than this are entirely masked.
+	//
+	// This is optional and defaults to 0 (all characters are masked).
+	PreserveLast int `json:"preserve_last"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringMaskConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringMaskConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if utf8.RuneCountInString(c.Character) != 1 {
+		return fmt.Errorf("character: %v", errors.ErrInvalidOption)
+	}
+
+	if c.PreserveLast < 0 {
+		return fmt.Errorf("preserve_last: %v", errors.ErrInvalidOption)
+	}
+
+	if c.Pattern == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return fmt.Errorf("pattern: %v", err)
+	}
+
+	c.re = re
+
+	return nil
+}
+
+func newStringMask(_ context.Context, cfg config.Config) (*stringMask, error) {
+	conf := stringMaskConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_mask: %v", err)
+	}
+
+	if conf.Character == "" {
+		conf.Character = "*"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_mask: %v", err)
+	}
+
+	tf := stringMask{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type stringMask struct {
+	conf     stringMaskConfig
+	isObject bool
+}
+
+func (tf *stringMask) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		s := tf.maskAll(string(msg.Data()))
+		msg.SetData([]byte(s))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s := tf.maskAll(value.String())
+	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+		return nil, fmt.Errorf("transform: string_mask: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringMask) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// maskAll masks every match of the pattern in the string, or the entire
+// string if there is no pattern.
+func (tf *stringMask) maskAll(s string) string {
+	if tf.conf.re == nil {
+		return tf.mask(s)
+	}
+
+	return tf.conf.re.ReplaceAllStringFunc(s, tf.mask)
+}
+
+// mask replaces each character in the string with the mask character, except
+// for the preserved characters at the end of the string. Strings that are not
+// longer than the preserved characters are entirely masked.
+func (tf *stringMask) mask(s string) string {
+	r := []rune(s)
+
+	// Values that are not longer than the preserved characters are entirely
+	// masked, otherwise short values would not be masked at all.
+	n := len(r) - tf.conf.PreserveLast
+	if n <= 0 {
+		n = len(r)
+	}
+
+	return strings.Repeat(tf.conf.Character, n) + string(r[n:])
+}
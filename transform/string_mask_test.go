@@ -0,0 +1,163 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringMask{}
+
+var stringMaskTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`secret`),
+		[][]byte{
+			[]byte(`******`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":       `\d{4}-\d{4}-\d{4}-\d{4}`,
+				"preserve_last": 4,
+			},
+		},
+		[]byte(`card 1234-5678-9012-3456 used`),
+		[][]byte{
+			[]byte(`card ***************3456 used`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":   `\d+`,
+				"character": "#",
+			},
+		},
+		[]byte(`a1b22c333`),
+		[][]byte{
+			[]byte(`a#b##c###`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"preserve_last": 10,
+			},
+		},
+		[]byte(`abc`),
+		[][]byte{
+			[]byte(`***`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":       `\d+`,
+				"preserve_last": 4,
+			},
+		},
+		[]byte(`pin 1234, code 12, card 12345`),
+		[][]byte{
+			[]byte(`pin ****, code **, card *2345`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+				"preserve_last": 2,
+			},
+		},
+		[]byte(`{"a":"äbcdé"}`),
+		[][]byte{
+			[]byte(`{"a":"***dé"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"pattern": `[a-z]+@`,
+			},
+		},
+		[]byte(`{"a":"user@example.com"}`),
+		[][]byte{
+			[]byte(`{"a":"user@example.com","b":"*****example.com"}`),
+		},
+	},
+}
+
+func TestStringMask(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringMaskTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringMask(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringMask(b *testing.B, tf *stringMask, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringMask(b *testing.B) {
+	for _, test := range stringMaskTests {
+		tf, err := newStringMask(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringMask(b, tf, test.test)
+			},
+		)
+	}
+}
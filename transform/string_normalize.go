@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+var stringNormalizeForms = map[string]norm.Form{
+	"nfc":  norm.NFC,
+	"nfd":  norm.NFD,
+	"nfkc": norm.NFKC,
+	"nfkd": norm.NFKD,
+}
+
+type stringNormalizeConfig struct {
+	// Form is the Unicode normalization form.
+	//
+	// Must be one of:
+	//	- nfc: canonical composition
+	//	- nfd: canonical decomposition
+	//	- nfkc: compatibility composition
+	//	- nfkd: compatibility decomposition
+	//
+	// This is optional and defaults to nfc.
+	Form string `json:"form"`
+	// CaseFold determines if the string is case folded after it is normalized,
+	// which removes case distinctions for caseless matching (e.g., "Straße" and
+	// "STRASSE" are both "strasse").
+	//
+	// This is optional and defaults to false.
+	CaseFold bool `json:"case_fold"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringNormalizeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringNormalizeConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"nfc",
+			"nfd",
+			"nfkc",
+			"nfkd",
+		},
+		c.Form) {
+		return fmt.Errorf("form %q: %v", c.Form, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringNormalize(_ context.Context, cfg config.Config) (*stringNormalize, error) {
+	conf := stringNormalizeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_normalize: %v", err)
+	}
+
+	if conf.Form == "" {
+		conf.Form = "nfc"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_normalize: %v", err)
+	}
+
+	tf := stringNormalize{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+		form:     stringNormalizeForms[conf.Form],
+	}
+
+	return &tf, nil
+}
+
+// stringNormalize normalizes the Unicode encoding of a string, which makes
+// strings that are visually identical (e.g., "é" as one or two code points)
+// equal when they are compared.
+type stringNormalize struct {
+	conf     stringNormalizeConfig
+	isObject bool
+
+	form norm.Form
+}
+
+func (tf *stringNormalize) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		msg.SetData([]byte(tf.normalize(string(msg.Data()))))
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, tf.normalize(value.String())); err != nil {
+		return nil, fmt.Errorf("transform: string_normalize: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringNormalize) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *stringNormalize) normalize(s string) string {
+	s = tf.form.String(s)
+	if tf.conf.CaseFold {
+		// Caser is not safe for concurrent use.
+		s = cases.Fold().String(s)
+	}
+
+	return s
+}
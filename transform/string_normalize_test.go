@@ -0,0 +1,157 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringNormalize{}
+
+var stringNormalizeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{},
+		[]byte("cafe\u0301"),
+		[][]byte{
+			[]byte("caf\u00e9"),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"form": "nfd",
+			},
+		},
+		[]byte("caf\u00e9"),
+		[][]byte{
+			[]byte("cafe\u0301"),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"form": "nfkc",
+			},
+		},
+		[]byte("\ufb01le \u2460"),
+		[][]byte{
+			[]byte("file 1"),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"form": "nfkd",
+			},
+		},
+		[]byte("\u00bd"),
+		[][]byte{
+			[]byte("1\u20442"),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"case_fold": true,
+			},
+		},
+		[]byte("Stra\u00dfe"),
+		[][]byte{
+			[]byte("strasse"),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"cafe\u0301"}`),
+		[][]byte{
+			[]byte(`{"a":"cafe\u0301","b":"café"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"case_fold": true,
+			},
+		},
+		[]byte(`{"a":"CAFÉ"}`),
+		[][]byte{
+			[]byte(`{"a":"CAFÉ","b":"café"}`),
+		},
+	},
+}
+
+func TestStringNormalize(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringNormalizeTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringNormalize(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringNormalize(b *testing.B, tf *stringNormalize, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringNormalize(b *testing.B) {
+	for _, test := range stringNormalizeTests {
+		tf, err := newStringNormalize(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringNormalize(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,144 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// stringPhoneTypes are the names of phone number types.
+var stringPhoneTypes = map[phonenumbers.PhoneNumberType]string{
+	phonenumbers.FIXED_LINE:           "fixed_line",
+	phonenumbers.MOBILE:               "mobile",
+	phonenumbers.FIXED_LINE_OR_MOBILE: "fixed_line_or_mobile",
+	phonenumbers.TOLL_FREE:            "toll_free",
+	phonenumbers.PREMIUM_RATE:         "premium_rate",
+	phonenumbers.SHARED_COST:          "shared_cost",
+	phonenumbers.VOIP:                 "voip",
+	phonenumbers.PERSONAL_NUMBER:      "personal_number",
+	phonenumbers.PAGER:                "pager",
+	phonenumbers.UAN:                  "uan",
+	phonenumbers.VOICEMAIL:            "voicemail",
+	phonenumbers.UNKNOWN:              "unknown",
+}
+
+type stringPhoneConfig struct {
+	// Region is the ISO 3166-1 alpha-2 code of the region that is used for
+	// numbers that do not have a country code (e.g., "(555) 123-4567").
+	//
+	// This is optional and defaults to US.
+	Region string `json:"region"`
+	// ValidKey is the key where a boolean that indicates if the number is
+	// valid is put. If the number is invalid, then the value of the target key
+	// is not changed.
+	//
+	// This is optional and has no default (validity is not put into the object).
+	ValidKey string `json:"valid_key"`
+	// TypeKey is the key where the type of the number (e.g., mobile, toll_free)
+	// is put. This is only put into the object if the number is valid.
+	//
+	// This is optional and has no default (the type is not put into the object).
+	TypeKey string `json:"type_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringPhoneConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringPhoneConfig) Validate() error {
+	if c.Object.SourceKey == "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if phonenumbers.GetCountryCodeForRegion(c.Region) == 0 {
+		return fmt.Errorf("region %q: %v", c.Region, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringPhone(_ context.Context, cfg config.Config) (*stringPhone, error) {
+	conf := stringPhoneConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_phone: %v", err)
+	}
+
+	if conf.Region == "" {
+		conf.Region = "US"
+	}
+
+	conf.Region = strings.ToUpper(conf.Region)
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_phone: %v", err)
+	}
+
+	tf := stringPhone{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// stringPhone validates phone numbers and normalizes them to E.164 format
+// (e.g., "(202) 555-0143" is "+12025550143"), which makes numbers that are
+// received with different formatting equal when they are compared. Invalid
+// numbers are not dropped, instead they are identified by the valid key.
+type stringPhone struct {
+	conf stringPhoneConfig
+}
+
+func (tf *stringPhone) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	num, err := phonenumbers.Parse(value.String(), tf.conf.Region)
+	valid := err == nil && phonenumbers.IsValidNumber(num)
+
+	if valid {
+		e164 := phonenumbers.Format(num, phonenumbers.E164)
+		if err := msg.SetValue(tf.conf.Object.TargetKey, e164); err != nil {
+			return nil, fmt.Errorf("transform: string_phone: %v", err)
+		}
+
+		if tf.conf.TypeKey != "" {
+			t := stringPhoneTypes[phonenumbers.GetNumberType(num)]
+			if err := msg.SetValue(tf.conf.TypeKey, t); err != nil {
+				return nil, fmt.Errorf("transform: string_phone: %v", err)
+			}
+		}
+	}
+
+	if tf.conf.ValidKey != "" {
+		if err := msg.SetValue(tf.conf.ValidKey, valid); err != nil {
+			return nil, fmt.Errorf("transform: string_phone: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringPhone) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
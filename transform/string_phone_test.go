@@ -0,0 +1,156 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringPhone{}
+
+var stringPhoneTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"type_key":  "type",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"(650) 253-0000"}`),
+		[][]byte{
+			[]byte(`{"a":"(650) 253-0000","b":"+16502530000","type":"fixed_line_or_mobile","valid":true}`),
+		},
+	},
+	{
+		"object toll_free",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"type_key":  "type",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"1-800-555-0199"}`),
+		[][]byte{
+			[]byte(`{"a":"1-800-555-0199","b":"+18005550199","type":"toll_free","valid":true}`),
+		},
+	},
+	{
+		"object region",
+		config.Config{
+			Settings: map[string]interface{}{
+				"region":    "gb",
+				"valid_key": "valid",
+				"type_key":  "type",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"07400 123456"}`),
+		[][]byte{
+			[]byte(`{"a":"07400 123456","b":"+447400123456","type":"mobile","valid":true}`),
+		},
+	},
+	{
+		"object country code",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"type_key":  "type",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"+44 20 7946 0958"}`),
+		[][]byte{
+			[]byte(`{"a":"+44 20 7946 0958","b":"+442079460958","type":"fixed_line","valid":true}`),
+		},
+	},
+	{
+		"object invalid",
+		config.Config{
+			Settings: map[string]interface{}{
+				"valid_key": "valid",
+				"type_key":  "type",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"123"}`),
+		[][]byte{
+			[]byte(`{"a":"123","valid":false}`),
+		},
+	},
+}
+
+func TestStringPhone(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringPhoneTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringPhone(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringPhone(b *testing.B, tf *stringPhone, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringPhone(b *testing.B) {
+	for _, test := range stringPhoneTests {
+		tf, err := newStringPhone(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringPhone(b, tf, test.test)
+			},
+		)
+	}
+}
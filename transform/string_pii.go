@@ -0,0 +1,265 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"github.com/tidwall/gjson"
+)
+
+// strPIIDetector identifies a type of PII. Matches of the pattern are
+// candidates that are confirmed by the optional valid function.
+type strPIIDetector struct {
+	re    *regexp.Regexp
+	valid func(string) bool
+}
+
+var strPIIDetectors = map[string]strPIIDetector{
+	"email": {
+		re: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`),
+	},
+	"ssn": {
+		re: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid: func(s string) bool {
+			// Area numbers 000, 666, and 900-999, group number 00, and
+			// serial number 0000 are never assigned.
+			return !strings.HasPrefix(s, "000") && !strings.HasPrefix(s, "666") && s[0] != '9' &&
+				s[4:6] != "00" && s[7:] != "0000"
+		},
+	},
+	"credit_card": {
+		re: regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`),
+		valid: func(s string) bool {
+			s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+			return strLuhn(s)
+		},
+	},
+	"phone": {
+		re: regexp.MustCompile(`(?:\+\d{1,3}[ .\-]?)?(?:\(\d{3}\)[ .\-]?|\b\d{3}[ .\-])\d{3}[ .\-]\d{4}\b`),
+	},
+	"ip": {
+		re: regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b|(?:[0-9A-Fa-f]{0,4}:){2,7}[0-9A-Fa-f]{0,4}`),
+		valid: func(s string) bool {
+			return net.ParseIP(s) != nil
+		},
+	},
+}
+
+type stringPIIConfig struct {
+	// Detectors are the types of PII that are identified in the value. The
+	// order of the detectors is the order that they are applied in.
+	//
+	// Must be any of:
+	//	- email
+	//	- ssn: United States Social Security number (e.g., 123-45-6789)
+	//	- credit_card: payment card number with a valid Luhn checksum
+	//	- phone: phone number (e.g., (555) 123-4567, +1 555.123.4567)
+	//	- ip: IPv4 or IPv6 address
+	//
+	// This is optional and defaults to all detectors.
+	Detectors []string `json:"detectors"`
+	// Mask determines if the detected PII is masked in the value.
+	//
+	// This is optional and defaults to false.
+	Mask bool `json:"mask"`
+	// Character is the character that replaces each masked character.
+	//
+	// This is optional and defaults to "*".
+	Character string `json:"character"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringPIIConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringPIIConfig) Validate() error {
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for _, d := range c.Detectors {
+		if _, ok := strPIIDetectors[d]; !ok {
+			return fmt.Errorf("detectors %q: %v", d, errors.ErrInvalidOption)
+		}
+	}
+
+	if utf8.RuneCountInString(c.Character) != 1 {
+		return fmt.Errorf("character: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringPII(_ context.Context, cfg config.Config) (*stringPII, error) {
+	conf := stringPIIConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_pii: %v", err)
+	}
+
+	if len(conf.Detectors) == 0 {
+		conf.Detectors = []string{"email", "ssn", "credit_card", "phone", "ip"}
+	}
+
+	if conf.Character == "" {
+		conf.Character = "*"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_pii: %v", err)
+	}
+
+	tf := stringPII{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+// stringPII detects PII in a value and puts the list of detected types into
+// the target key. If the source key is not set, then the entire message is
+// scanned; if the message is an object, then only its values are scanned. If
+// masking is enabled, then the detected PII is replaced in the source value (or
+// the message).
+type stringPII struct {
+	conf stringPIIConfig
+}
+
+func (tf *stringPII) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	detected := make(map[string]bool)
+	if tf.conf.Object.SourceKey != "" {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		s := tf.scan(value.String(), detected)
+		if tf.conf.Mask && len(detected) > 0 {
+			if err := msg.SetValue(tf.conf.Object.SourceKey, s); err != nil {
+				return nil, fmt.Errorf("transform: string_pii: %v", err)
+			}
+		}
+	} else if json.Valid(msg.Data()) {
+		// Objects are walked so that masking only rewrites values and the
+		// message remains valid JSON.
+		b := tf.scanJSON(gjson.ParseBytes(msg.Data()), detected)
+		if tf.conf.Mask && len(detected) > 0 {
+			msg.SetData(b)
+		}
+	} else {
+		s := tf.scan(string(msg.Data()), detected)
+		if tf.conf.Mask && len(detected) > 0 {
+			msg.SetData([]byte(s))
+		}
+	}
+
+	found := []string{}
+	for _, name := range tf.conf.Detectors {
+		if detected[name] {
+			found = append(found, name)
+		}
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, found); err != nil {
+		return nil, fmt.Errorf("transform: string_pii: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+// scan applies the detectors to s and records the detected types. If masking
+// is enabled, then the returned string has the detected PII replaced.
+func (tf *stringPII) scan(s string, detected map[string]bool) string {
+	for _, name := range tf.conf.Detectors {
+		d := strPIIDetectors[name]
+
+		s = d.re.ReplaceAllStringFunc(s, func(m string) string {
+			if d.valid != nil && !d.valid(m) {
+				return m
+			}
+
+			detected[name] = true
+			if !tf.conf.Mask {
+				return m
+			}
+
+			return strings.Repeat(tf.conf.Character, utf8.RuneCountInString(m))
+		})
+	}
+
+	return s
+}
+
+// scanJSON applies the detectors to every string and number in the JSON
+// value and returns the value with the detected PII masked. Numbers that
+// contain PII are replaced with a masked string.
+func (tf *stringPII) scanJSON(res gjson.Result, detected map[string]bool) []byte {
+	switch {
+	case res.IsObject(), res.IsArray():
+		var buf bytes.Buffer
+		if res.IsObject() {
+			buf.WriteByte('{')
+		} else {
+			buf.WriteByte('[')
+		}
+
+		first := true
+		res.ForEach(func(key, value gjson.Result) bool {
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			if res.IsObject() {
+				buf.WriteString(key.Raw)
+				buf.WriteByte(':')
+			}
+
+			buf.Write(tf.scanJSON(value, detected))
+			return true
+		})
+
+		if res.IsObject() {
+			buf.WriteByte('}')
+		} else {
+			buf.WriteByte(']')
+		}
+
+		return buf.Bytes()
+	case res.Type == gjson.String, res.Type == gjson.Number:
+		in := res.String()
+		if res.Type == gjson.Number {
+			in = res.Raw
+		}
+
+		out := tf.scan(in, detected)
+		if out == in {
+			return []byte(res.Raw)
+		}
+
+		b, _ := json.Marshal(out)
+		return b
+	default:
+		return []byte(res.Raw)
+	}
+}
+
+func (tf *stringPII) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
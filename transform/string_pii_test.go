@@ -0,0 +1,208 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringPII{}
+
+var stringPIITests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"contact alice@example.com or (555) 123-4567"}`),
+		[][]byte{
+			[]byte(`{"a":"contact alice@example.com or (555) 123-4567","pii":["email","phone"]}`),
+		},
+	},
+	{
+		"object ssn",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"ssn 123-45-6789, not 000-12-3456"}`),
+		[][]byte{
+			[]byte(`{"a":"ssn 123-45-6789, not 000-12-3456","pii":["ssn"]}`),
+		},
+	},
+	{
+		"object credit_card",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"card 4111 1111 1111 1111, not 4111 1111 1111 1112"}`),
+		[][]byte{
+			[]byte(`{"a":"card 4111 1111 1111 1111, not 4111 1111 1111 1112","pii":["credit_card"]}`),
+		},
+	},
+	{
+		"object ip",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"from 192.168.1.1 and 2001:db8::1 at 12:30:45, not 999.1.1.1"}`),
+		[][]byte{
+			[]byte(`{"a":"from 192.168.1.1 and 2001:db8::1 at 12:30:45, not 999.1.1.1","pii":["ip"]}`),
+		},
+	},
+	{
+		"object none",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"nothing to see here"}`),
+		[][]byte{
+			[]byte(`{"a":"nothing to see here","pii":[]}`),
+		},
+	},
+	{
+		"object detectors",
+		config.Config{
+			Settings: map[string]interface{}{
+				"detectors": []string{"ip"},
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"alice@example.com"}`),
+		[][]byte{
+			[]byte(`{"a":"alice@example.com","pii":[]}`),
+		},
+	},
+	{
+		"object mask",
+		config.Config{
+			Settings: map[string]interface{}{
+				"mask": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"alice@example.com 123-45-6789"}`),
+		[][]byte{
+			[]byte(`{"a":"***************** ***********","pii":["email","ssn"]}`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"mask":      true,
+				"character": "#",
+				"object": map[string]interface{}{
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"a":"10.0.0.1","b":"x"}`),
+		[][]byte{
+			[]byte(`{"a":"########","b":"x","pii":["ip"]}`),
+		},
+	},
+	{
+		"data numeric",
+		config.Config{
+			Settings: map[string]interface{}{
+				"mask": true,
+				"object": map[string]interface{}{
+					"target_key": "pii",
+				},
+			},
+		},
+		[]byte(`{"card":4111111111111111,"ip":"10.0.0.1","n":[1,{"e":"alice@example.com"}],"ok":true}`),
+		[][]byte{
+			[]byte(`{"card":"****************","ip":"********","n":[1,{"e":"*****************"}],"ok":true,"pii":["email","credit_card","ip"]}`),
+		},
+	},
+}
+
+func TestStringPII(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringPIITests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringPII(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringPII(b *testing.B, tf *stringPII, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringPII(b *testing.B) {
+	for _, test := range stringPIITests {
+		tf, err := newStringPII(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringPII(b, tf, test.test)
+			},
+		)
+	}
+}
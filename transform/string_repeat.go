@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringRepeatConfig struct {
+	// Count is the number of times the string is repeated.
+	Count int `json:"count"`
+	// Separator is the string inserted between each repetition.
+	//
+	// This is optional and has no default.
+	Separator string `json:"separator"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringRepeatConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringRepeatConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Count <= 0 {
+		return fmt.Errorf("count: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newStringRepeat(_ context.Context, cfg config.Config) (*stringRepeat, error) {
+	conf := stringRepeatConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_repeat: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_repeat: %v", err)
+	}
+
+	tf := stringRepeat{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type stringRepeat struct {
+	conf     stringRepeatConfig
+	isObject bool
+}
+
+func (tf *stringRepeat) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s := make([]string, tf.conf.Count)
+	for i := range s {
+		s[i] = value.String()
+	}
+
+	v := strings.Join(s, tf.conf.Separator)
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: string_repeat: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(v))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringRepeat) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,113 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringRepeat{}
+
+var stringRepeatTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"count": 3,
+			},
+		},
+		[]byte(`ab`),
+		[][]byte{
+			[]byte(`ababab`),
+		},
+	},
+	{
+		"data separator",
+		config.Config{
+			Settings: map[string]interface{}{
+				"count":     2,
+				"separator": "-",
+			},
+		},
+		[]byte(`ab`),
+		[][]byte{
+			[]byte(`ab-ab`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"count": 4,
+			},
+		},
+		[]byte(`{"a":"*"}`),
+		[][]byte{
+			[]byte(`{"a":"*","b":"****"}`),
+		},
+	},
+}
+
+func TestStringRepeat(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringRepeatTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringRepeat(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringRepeat(b *testing.B, tf *stringRepeat, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringRepeat(b *testing.B) {
+	for _, test := range stringRepeatTests {
+		tf, err := newStringRepeat(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringRepeat(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/brexhq/substation/config"
 	iconfig "github.com/brexhq/substation/internal/config"
@@ -18,6 +21,18 @@ type stringReplaceConfig struct {
 	re      *regexp.Regexp
 	// Replacement is the string to replace the matched values with.
 	Replacement string `json:"replacement"`
+	// Template is a Go template (https://pkg.go.dev/text/template) that is used
+	// to create the string that replaces each matched value. Captured groups
+	// are available by name (e.g., {{.user}}) and by index (e.g., {{index . "1"}})
+	// and can be changed with these functions:
+	//	- lower: converts the value to lowercase
+	//	- upper: converts the value to uppercase
+	//	- trim: removes leading and trailing whitespace
+	//	- replace: replaces all instances of a string (e.g., {{replace .id "-" ""}})
+	//
+	// This is optional and cannot be used with Replacement.
+	Template string `json:"template"`
+	tmpl     *template.Template
 
 	Object iconfig.Object `json:"object"`
 }
@@ -46,6 +61,19 @@ func (c *stringReplaceConfig) Validate() error {
 
 	c.re = re
 
+	if c.Template != "" && c.Replacement != "" {
+		return fmt.Errorf("template: %v", errors.ErrInvalidOption)
+	}
+
+	if c.Template != "" {
+		tmpl, err := template.New("replace").Funcs(strReplaceFuncs).Parse(c.Template)
+		if err != nil {
+			return fmt.Errorf("template: %v", err)
+		}
+
+		c.tmpl = tmpl
+	}
+
 	return nil
 }
 
@@ -81,6 +109,16 @@ func (tf *stringReplace) Transform(ctx context.Context, msg *message.Message) ([
 	}
 
 	if !tf.isObject {
+		if tf.conf.tmpl != nil {
+			s, err := tf.template(string(msg.Data()))
+			if err != nil {
+				return nil, fmt.Errorf("transform: string_replace: %v", err)
+			}
+
+			msg.SetData([]byte(s))
+			return []*message.Message{msg}, nil
+		}
+
 		b := tf.conf.re.ReplaceAll(msg.Data(), tf.r)
 		msg.SetData(b)
 
@@ -92,7 +130,16 @@ func (tf *stringReplace) Transform(ctx context.Context, msg *message.Message) ([
 		return []*message.Message{msg}, nil
 	}
 
-	s := tf.conf.re.ReplaceAllString(value.String(), string(tf.r))
+	var s string
+	if tf.conf.tmpl != nil {
+		var err error
+		if s, err = tf.template(value.String()); err != nil {
+			return nil, fmt.Errorf("transform: string_replace: %v", err)
+		}
+	} else {
+		s = tf.conf.re.ReplaceAllString(value.String(), string(tf.r))
+	}
+
 	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
 		return nil, fmt.Errorf("transform: string_replace: %v", err)
 	}
@@ -104,3 +151,42 @@ func (tf *stringReplace) String() string {
 	b, _ := json.Marshal(tf.conf)
 	return string(b)
 }
+
+// template replaces each match with the output of the template.
+func (tf *stringReplace) template(s string) (string, error) {
+	var sb strings.Builder
+	names := tf.conf.re.SubexpNames()
+
+	var last int
+	for _, m := range tf.conf.re.FindAllStringSubmatchIndex(s, -1) {
+		groups := make(map[string]string)
+		for i := 0; i < len(m)/2; i++ {
+			var v string
+			if m[2*i] >= 0 {
+				v = s[m[2*i]:m[2*i+1]]
+			}
+
+			groups[strconv.Itoa(i)] = v
+			if names[i] != "" {
+				groups[names[i]] = v
+			}
+		}
+
+		sb.WriteString(s[last:m[0]])
+		if err := tf.conf.tmpl.Execute(&sb, groups); err != nil {
+			return "", err
+		}
+
+		last = m[1]
+	}
+
+	sb.WriteString(s[last:])
+	return sb.String(), nil
+}
+
+var strReplaceFuncs = template.FuncMap{
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": func(s, old, new string) string { return strings.ReplaceAll(s, old, new) },
+}
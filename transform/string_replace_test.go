@@ -44,6 +44,19 @@ var stringReplaceTests = []struct {
 			[]byte(`ab`),
 		},
 	},
+	{
+		"data template",
+		config.Config{
+			Settings: map[string]interface{}{
+				"pattern":  `(?P<user>[a-z]+)@(?P<domain>[a-z.]+)`,
+				"template": `{{upper .user}} at {{index . "2"}}`,
+			},
+		},
+		[]byte(`from alice@example.com`),
+		[][]byte{
+			[]byte(`from ALICE at example.com`),
+		},
+	},
 	// object tests
 	{
 		"object replace",
@@ -78,6 +91,23 @@ var stringReplaceTests = []struct {
 			[]byte(`{"a":"b"}`),
 		},
 	},
+	{
+		"object template",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+				"pattern":  `/(?P<resource>users|posts)/[0-9]+`,
+				"template": `/{{.resource}}/:id`,
+			},
+		},
+		[]byte(`{"a":"/users/123/posts/456"}`),
+		[][]byte{
+			[]byte(`{"a":"/users/:id/posts/:id"}`),
+		},
+	},
 }
 
 func TestStringReplace(t *testing.T) {
@@ -0,0 +1,155 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringStatsConfig struct {
+	// Tokenizer determines how the string is split into tokens.
+	//
+	// Must be one of:
+	//	- whitespace: tokens are separated by whitespace
+	//	- word: tokens are sequences of letters and numbers, so punctuation is
+	//	not counted (e.g., "don't stop!" is "don", "t", and "stop")
+	//
+	// This is optional and defaults to whitespace.
+	Tokenizer string `json:"tokenizer"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringStatsConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringStatsConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"whitespace",
+			"word",
+		},
+		c.Tokenizer) {
+		return fmt.Errorf("tokenizer %q: %v", c.Tokenizer, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringStats(_ context.Context, cfg config.Config) (*stringStats, error) {
+	conf := stringStatsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_stats: %v", err)
+	}
+
+	if conf.Tokenizer == "" {
+		conf.Tokenizer = "whitespace"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_stats: %v", err)
+	}
+
+	tf := stringStats{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// stringStats calculates the number of bytes, characters, tokens, and lines in
+// a string (e.g., {"bytes":11,"characters":11,"tokens":2,"lines":1}). Empty
+// strings have zero lines and strings that do not end with a newline count the
+// last line.
+type stringStats struct {
+	conf     stringStatsConfig
+	isObject bool
+}
+
+func (tf *stringStats) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := json.Marshal(tf.stats(value.String()))
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_stats: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, json.RawMessage(b)); err != nil {
+			return nil, fmt.Errorf("transform: string_stats: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringStats) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+type stringStatsResult struct {
+	Bytes      int `json:"bytes"`
+	Characters int `json:"characters"`
+	Tokens     int `json:"tokens"`
+	Lines      int `json:"lines"`
+}
+
+func (tf *stringStats) stats(s string) stringStatsResult {
+	res := stringStatsResult{
+		Bytes:      len(s),
+		Characters: utf8.RuneCountInString(s),
+		Lines:      strings.Count(s, "\n"),
+	}
+
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		res.Lines++
+	}
+
+	switch tf.conf.Tokenizer {
+	case "whitespace":
+		res.Tokens = len(strings.Fields(s))
+	case "word":
+		res.Tokens = len(strings.FieldsFunc(s, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+		}))
+	}
+
+	return res
+}
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringStats{}
+
+var stringStatsTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte("héllo  world\nbye"),
+		[][]byte{
+			[]byte(`{"bytes":17,"characters":16,"tokens":3,"lines":2}`),
+		},
+	},
+	{
+		"data word",
+		config.Config{
+			Settings: map[string]interface{}{
+				"tokenizer": "word",
+			},
+		},
+		[]byte("don't stop!\n"),
+		[][]byte{
+			[]byte(`{"bytes":12,"characters":12,"tokens":3,"lines":1}`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":""}`),
+		[][]byte{
+			[]byte(`{"a":"","b":{"bytes":0,"characters":0,"tokens":0,"lines":0}}`),
+		},
+	},
+}
+
+func TestStringStats(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringStatsTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringStats(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringStats(b *testing.B, tf *stringStats, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringStats(b *testing.B) {
+	for _, test := range stringStatsTests {
+		tf, err := newStringStats(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringStats(b, tf, test.test)
+			},
+		)
+	}
+}
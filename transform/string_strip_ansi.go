@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+// stringStripANSIPattern matches ANSI escape sequences (ECMA-48), which are:
+//   - control sequences (CSI), such as colors and cursor movement
+//   - operating system commands (OSC), such as window titles and hyperlinks
+//   - single character escape sequences
+var stringStripANSIPattern = regexp.MustCompile(
+	`(?:\x1b\[|\x{9b})[0-?]*[ -/]*[@-~]` +
+		`|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)` +
+		`|\x1b[ -/]*[0-~]`,
+)
+
+func newStringStripANSI(_ context.Context, cfg config.Config) (*stringStripANSI, error) {
+	conf := strCaseConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_strip_ansi: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_strip_ansi: %v", err)
+	}
+
+	tf := stringStripANSI{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// stringStripANSI removes ANSI escape sequences (e.g., colors) from data, which
+// are common in logs from terminals, build systems, and containers.
+type stringStripANSI struct {
+	conf     strCaseConfig
+	isObject bool
+}
+
+func (tf *stringStripANSI) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		b := stringStripANSIPattern.ReplaceAll(msg.Data(), nil)
+		msg.SetData(b)
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	s := stringStripANSIPattern.ReplaceAllString(value.String(), "")
+	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+		return nil, fmt.Errorf("transform: string_strip_ansi: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringStripANSI) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
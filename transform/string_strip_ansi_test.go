@@ -0,0 +1,111 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringStripANSI{}
+
+var stringStripANSITests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data color",
+		config.Config{},
+		[]byte("\x1b[1;31mERROR\x1b[0m: failed"),
+		[][]byte{
+			[]byte("ERROR: failed"),
+		},
+	},
+	{
+		"data cursor",
+		config.Config{},
+		[]byte("\x1b[2K\x1b[1Gprogress 100%\x1b[?25h"),
+		[][]byte{
+			[]byte("progress 100%"),
+		},
+	},
+	{
+		"data osc",
+		config.Config{},
+		[]byte("\x1b]0;title\x07\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\ \x1b(Bdone"),
+		[][]byte{
+			[]byte("link done"),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"\u001b[32mok\u001b[39m"}`),
+		[][]byte{
+			[]byte(`{"a":"ok"}`),
+		},
+	},
+}
+
+func TestStringStripANSI(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringStripANSITests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringStripANSI(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringStripANSI(b *testing.B, tf *stringStripANSI, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringStripANSI(b *testing.B) {
+	for _, test := range stringStripANSITests {
+		tf, err := newStringStripANSI(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringStripANSI(b, tf, test.test)
+			},
+		)
+	}
+}
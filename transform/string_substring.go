@@ -0,0 +1,121 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringSubstringConfig struct {
+	// Start is the index of the first character in the substring. Negative
+	// values count from the end of the string (e.g., -4 is the fourth to last
+	// character).
+	//
+	// This is optional and defaults to 0.
+	Start int `json:"start"`
+	// End is the index of the character after the last character in the
+	// substring. Negative values count from the end of the string.
+	//
+	// This is optional and defaults to the end of the string.
+	End int `json:"end"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringSubstringConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringSubstringConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newStringSubstring(_ context.Context, cfg config.Config) (*stringSubstring, error) {
+	conf := stringSubstringConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_substring: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_substring: %v", err)
+	}
+
+	tf := stringSubstring{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type stringSubstring struct {
+	conf     stringSubstringConfig
+	isObject bool
+}
+
+func (tf *stringSubstring) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	v := tf.substring(value.String())
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+			return nil, fmt.Errorf("transform: string_substring: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(v))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringSubstring) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// substring returns the characters (runes) between start and end. Indices
+// that are out of range are clamped to the bounds of the string.
+func (tf *stringSubstring) substring(s string) string {
+	r := []rune(s)
+	l := len(r)
+
+	start := resolveIndex(tf.conf.Start, l)
+	end := l
+	if tf.conf.End != 0 {
+		end = resolveIndex(tf.conf.End, l)
+	}
+
+	if start >= end {
+		return ""
+	}
+
+	return string(r[start:end])
+}
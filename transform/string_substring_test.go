@@ -0,0 +1,152 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringSubstring{}
+
+var stringSubstringTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 1,
+				"end":   4,
+			},
+		},
+		[]byte(`abcdef`),
+		[][]byte{
+			[]byte(`bcd`),
+		},
+	},
+	{
+		"data negative",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": -4,
+			},
+		},
+		[]byte(`4111111111111111`),
+		[][]byte{
+			[]byte(`1111`),
+		},
+	},
+	{
+		"data unicode",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 1,
+				"end":   -1,
+			},
+		},
+		[]byte(`ñandú`),
+		[][]byte{
+			[]byte(`and`),
+		},
+	},
+	{
+		"data out of range",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 2,
+				"end":   100,
+			},
+		},
+		[]byte(`abc`),
+		[][]byte{
+			[]byte(`c`),
+		},
+	},
+	{
+		"data empty",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 4,
+				"end":   2,
+			},
+		},
+		[]byte(`abcdef`),
+		[][]byte{
+			[]byte(``),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"end": 3,
+			},
+		},
+		[]byte(`{"a":"abcdef"}`),
+		[][]byte{
+			[]byte(`{"a":"abcdef","b":"abc"}`),
+		},
+	},
+}
+
+func TestStringSubstring(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringSubstringTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringSubstring(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringSubstring(b *testing.B, tf *stringSubstring, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringSubstring(b *testing.B) {
+	for _, test := range stringSubstringTests {
+		tf, err := newStringSubstring(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringSubstring(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,206 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// stringToSeverityLevels are the canonical severity levels and their numeric
+// values, which are the same as syslog (RFC 5424) severities.
+var stringToSeverityLevels = map[string]int{
+	"emergency": 0,
+	"alert":     1,
+	"critical":  2,
+	"error":     3,
+	"warning":   4,
+	"notice":    5,
+	"info":      6,
+	"debug":     7,
+}
+
+// stringToSeverityMapping maps common severity values to canonical levels.
+// Values are matched without regard to case.
+var stringToSeverityMapping = map[string]string{
+	"0":             "emergency",
+	"emerg":         "emergency",
+	"emergency":     "emergency",
+	"panic":         "emergency",
+	"1":             "alert",
+	"a":             "alert",
+	"alert":         "alert",
+	"2":             "critical",
+	"c":             "critical",
+	"crit":          "critical",
+	"critical":      "critical",
+	"f":             "critical",
+	"fatal":         "critical",
+	"3":             "error",
+	"e":             "error",
+	"err":           "error",
+	"error":         "error",
+	"4":             "warning",
+	"w":             "warning",
+	"warn":          "warning",
+	"warning":       "warning",
+	"5":             "notice",
+	"n":             "notice",
+	"notice":        "notice",
+	"6":             "info",
+	"i":             "info",
+	"info":          "info",
+	"information":   "info",
+	"informational": "info",
+	"7":             "debug",
+	"d":             "debug",
+	"debug":         "debug",
+	"t":             "debug",
+	"trace":         "debug",
+	"v":             "debug",
+	"verbose":       "debug",
+}
+
+type stringToSeverityConfig struct {
+	// Mapping maps values to canonical severity levels. This is merged with a
+	// default mapping of common values (e.g., "WARN", "W", and "4" are mapped to
+	// "warning") and takes precedence over it.
+	//
+	// Canonical levels must be one of:
+	//	- emergency (0)
+	//	- alert (1)
+	//	- critical (2)
+	//	- error (3)
+	//	- warning (4)
+	//	- notice (5)
+	//	- info (6)
+	//	- debug (7)
+	//
+	// This is optional and has no default.
+	Mapping map[string]string `json:"mapping"`
+	// Default is the canonical level used for values that are not in the mapping.
+	//
+	// This is optional and has no default (values that are not in the mapping are
+	// not changed).
+	Default string `json:"default"`
+	// NumberKey places the numeric value of the level into a JSON object. This is
+	// only used when the transform is configured with source and target keys.
+	//
+	// This is optional and has no default.
+	NumberKey string `json:"number_key"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringToSeverityConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringToSeverityConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	for k, v := range c.Mapping {
+		if _, ok := stringToSeverityLevels[v]; !ok {
+			return fmt.Errorf("mapping %q: level %q: %v", k, v, errors.ErrInvalidOption)
+		}
+	}
+
+	if _, ok := stringToSeverityLevels[c.Default]; c.Default != "" && !ok {
+		return fmt.Errorf("default %q: %v", c.Default, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringToSeverity(_ context.Context, cfg config.Config) (*stringToSeverity, error) {
+	conf := stringToSeverityConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_to_severity: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_to_severity: %v", err)
+	}
+
+	tf := stringToSeverity{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+		mapping:  make(map[string]string, len(stringToSeverityMapping)+len(conf.Mapping)),
+	}
+
+	for k, v := range stringToSeverityMapping {
+		tf.mapping[k] = v
+	}
+
+	for k, v := range conf.Mapping {
+		tf.mapping[strings.ToLower(k)] = v
+	}
+
+	return &tf, nil
+}
+
+type stringToSeverity struct {
+	conf     stringToSeverityConfig
+	isObject bool
+
+	mapping map[string]string
+}
+
+func (tf *stringToSeverity) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	level, ok := tf.mapping[strings.ToLower(strings.TrimSpace(value.String()))]
+	if !ok {
+		level = tf.conf.Default
+	}
+
+	if level == "" {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		msg.SetData([]byte(level))
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, level); err != nil {
+		return nil, fmt.Errorf("transform: string_to_severity: %v", err)
+	}
+
+	if tf.conf.NumberKey != "" {
+		if err := msg.SetValue(tf.conf.NumberKey, stringToSeverityLevels[level]); err != nil {
+			return nil, fmt.Errorf("transform: string_to_severity: %v", err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringToSeverity) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
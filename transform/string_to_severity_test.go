@@ -0,0 +1,151 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringToSeverity{}
+
+var stringToSeverityTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`WARN`),
+		[][]byte{
+			[]byte(`warning`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`unknown`),
+		[][]byte{
+			[]byte(`unknown`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"default": "info",
+			},
+		},
+		[]byte(`unknown`),
+		[][]byte{
+			[]byte(`info`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"number_key": "c",
+			},
+		},
+		[]byte(`{"a":4}`),
+		[][]byte{
+			[]byte(`{"a":4,"b":"warning","c":4}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"number_key": "c",
+			},
+		},
+		[]byte(`{"a":"Fatal"}`),
+		[][]byte{
+			[]byte(`{"a":"Fatal","b":"critical","c":2}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"mapping": map[string]interface{}{
+					"SEV1": "critical",
+					"W":    "error",
+				},
+			},
+		},
+		[]byte(`{"a":"w"}`),
+		[][]byte{
+			[]byte(`{"a":"w","b":"error"}`),
+		},
+	},
+}
+
+func TestStringToSeverity(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringToSeverityTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringToSeverity(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringToSeverity(b *testing.B, tf *stringToSeverity, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringToSeverity(b *testing.B) {
+	for _, test := range stringToSeverityTests {
+		tf, err := newStringToSeverity(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringToSeverity(b, tf, test.test)
+			},
+		)
+	}
+}
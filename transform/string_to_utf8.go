@@ -0,0 +1,176 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringToUTF8Config struct {
+	// Charset is the IANA name of the character set that the data is
+	// encoded with (e.g., iso-8859-1, windows-1252, shift_jis). If this is
+	// "auto", then the character set is detected:
+	//	- data that starts with a UTF-8 or UTF-16 byte order mark is decoded
+	//	using the character set of the mark
+	//	- data that is valid UTF-8 is not changed
+	//	- all other data is decoded using Fallback
+	//
+	// This is optional and defaults to auto.
+	Charset string `json:"charset"`
+	// Fallback is the IANA name of the character set that is used when the
+	// character set cannot be detected. This is only used when Charset is
+	// auto.
+	//
+	// This is optional and defaults to windows-1252, which is a superset of
+	// the printable characters of iso-8859-1.
+	Fallback string `json:"fallback"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringToUTF8Config) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringToUTF8Config) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newStringToUTF8(_ context.Context, cfg config.Config) (*stringToUTF8, error) {
+	conf := stringToUTF8Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_to_utf8: %v", err)
+	}
+
+	if conf.Charset == "" {
+		conf.Charset = "auto"
+	}
+
+	if conf.Fallback == "" {
+		conf.Fallback = "windows-1252"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_to_utf8: %v", err)
+	}
+
+	tf := stringToUTF8{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	name := conf.Charset
+	if name == "auto" {
+		name = conf.Fallback
+	}
+
+	enc, err := strToUTF8Encoding(name)
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_to_utf8: %v", err)
+	}
+	tf.enc = enc
+
+	return &tf, nil
+}
+
+// stringToUTF8 converts data from a legacy character set (e.g., data from
+// Windows systems that is encoded with windows-1252) to UTF-8. Data that is
+// not converted is often displayed incorrectly ("mojibake") or replaced with
+// the Unicode replacement character.
+type stringToUTF8 struct {
+	conf     stringToUTF8Config
+	isObject bool
+
+	enc encoding.Encoding
+}
+
+func (tf *stringToUTF8) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var b []byte
+	if tf.isObject {
+		value := msg.GetValue(tf.conf.Object.SourceKey)
+		if !value.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		b = []byte(value.String())
+	} else {
+		b = msg.Data()
+	}
+
+	out, err := tf.decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_to_utf8: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, string(out)); err != nil {
+			return nil, fmt.Errorf("transform: string_to_utf8: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData(out)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringToUTF8) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *stringToUTF8) decode(b []byte) ([]byte, error) {
+	if tf.conf.Charset != "auto" {
+		return tf.enc.NewDecoder().Bytes(b)
+	}
+
+	switch {
+	case bytes.HasPrefix(b, []byte{0xEF, 0xBB, 0xBF}):
+		return b[3:], nil
+	case bytes.HasPrefix(b, []byte{0xFF, 0xFE}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder().Bytes(b)
+	case bytes.HasPrefix(b, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(b)
+	case utf8.Valid(b):
+		return b, nil
+	}
+
+	return tf.enc.NewDecoder().Bytes(b)
+}
+
+func strToUTF8Encoding(name string) (encoding.Encoding, error) {
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil {
+		return nil, fmt.Errorf("charset %q: %v", name, errors.ErrInvalidOption)
+	}
+
+	// Some character sets are known, but not supported.
+	if enc == nil {
+		return nil, fmt.Errorf("charset %q: %v", name, errors.ErrInvalidOption)
+	}
+
+	return enc, nil
+}
@@ -0,0 +1,155 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringToUTF8{}
+
+var stringToUTF8Tests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte("caf\xe9"),
+		[][]byte{
+			[]byte("café"),
+		},
+	},
+	{
+		"data utf-8",
+		config.Config{},
+		[]byte("café"),
+		[][]byte{
+			[]byte("café"),
+		},
+	},
+	{
+		"data utf-8 bom",
+		config.Config{},
+		[]byte("\xef\xbb\xbfcafé"),
+		[][]byte{
+			[]byte("café"),
+		},
+	},
+	{
+		"data utf-16 bom",
+		config.Config{},
+		[]byte("\xff\xfec\x00a\x00f\x00\xe9\x00"),
+		[][]byte{
+			[]byte("café"),
+		},
+	},
+	{
+		"data charset",
+		config.Config{
+			Settings: map[string]interface{}{
+				"charset": "iso-8859-1",
+			},
+		},
+		[]byte("\xa3100"),
+		[][]byte{
+			[]byte("£100"),
+		},
+	},
+	{
+		"data windows-1252",
+		config.Config{
+			Settings: map[string]interface{}{
+				"charset": "windows-1252",
+			},
+		},
+		[]byte("\x93quoted\x94"),
+		[][]byte{
+			[]byte("“quoted”"),
+		},
+	},
+	{
+		"data shift_jis",
+		config.Config{
+			Settings: map[string]interface{}{
+				"charset": "shift_jis",
+			},
+		},
+		[]byte("\x93\xfa\x96{"),
+		[][]byte{
+			[]byte("日本"),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte("{\"a\":\"caf\xe9\"}"),
+		[][]byte{
+			[]byte(`{"a":"café"}`),
+		},
+	},
+}
+
+func TestStringToUTF8(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringToUTF8Tests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringToUTF8(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringToUTF8(b *testing.B, tf *stringToUTF8, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringToUTF8(b *testing.B) {
+	for _, test := range stringToUTF8Tests {
+		tf, err := newStringToUTF8(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringToUTF8(b, tf, test.test)
+			},
+		)
+	}
+}
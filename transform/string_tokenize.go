@@ -0,0 +1,360 @@
+package transform
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/secrets"
+	"github.com/brexhq/substation/message"
+)
+
+// errStringTokenizeTooShort is returned when a value does not contain enough
+// characters from the alphabet to be tokenized securely.
+var errStringTokenizeTooShort = fmt.Errorf("value is too short")
+
+type stringTokenizeConfig struct {
+	// Key is the hex encoded AES key (128, 192, or 256 bits) that tokenizes
+	// values. This supports secrets interpolation.
+	Key string `json:"key"`
+	// Tweak is a public value that changes the tokens produced by the key. This
+	// can be used to produce different tokens for different types of values
+	// with the same key.
+	//
+	// This is optional and has no default.
+	Tweak string `json:"tweak"`
+	// Alphabet is the set of characters that are tokenized. Characters that
+	// are not in the alphabet (e.g., dashes in an SSN) are not changed.
+	//
+	// This is optional and defaults to digits (0123456789).
+	Alphabet string `json:"alphabet"`
+	// Decrypt determines if tokens are converted back into the original values.
+	//
+	// This is optional and defaults to false.
+	Decrypt bool `json:"decrypt"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringTokenizeConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringTokenizeConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Key == "" {
+		return fmt.Errorf("key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	n := utf8.RuneCountInString(c.Alphabet)
+	if n < 2 || n > 65536 {
+		return fmt.Errorf("alphabet: %v", errors.ErrInvalidOption)
+	}
+
+	seen := make(map[rune]bool)
+	for _, r := range c.Alphabet {
+		if seen[r] {
+			return fmt.Errorf("alphabet: %v", errors.ErrInvalidOption)
+		}
+
+		seen[r] = true
+	}
+
+	return nil
+}
+
+func newStringTokenize(_ context.Context, cfg config.Config) (*stringTokenize, error) {
+	conf := stringTokenizeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_tokenize: %v", err)
+	}
+
+	if conf.Alphabet == "" {
+		conf.Alphabet = "0123456789"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_tokenize: %v", err)
+	}
+
+	tf := stringTokenize{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+		alphabet: []rune(conf.Alphabet),
+		index:    make(map[rune]int),
+	}
+
+	for i, r := range tf.alphabet {
+		tf.index[r] = i
+	}
+
+	// FF1 requires at least one million possible values.
+	tf.minLen = int(math.Ceil(6 / math.Log10(float64(len(tf.alphabet)))))
+	if tf.minLen < 2 {
+		tf.minLen = 2
+	}
+
+	return &tf, nil
+}
+
+// stringTokenize replaces values with tokens using format-preserving
+// encryption (NIST SP 800-38G FF1). The same value always produces the same
+// token, and tokens have the same length and format as the value (e.g., an
+// SSN is tokenized into another ###-##-#### string), so tokenized values can
+// still be joined and validated by other systems. Tokens can be converted back into the original
+// values by using the same key and tweak.
+type stringTokenize struct {
+	conf     stringTokenizeConfig
+	isObject bool
+
+	alphabet []rune
+	index    map[rune]int
+	minLen   int
+
+	// The key may be a secret that changes, so the cipher is recreated when
+	// it does.
+	mu    sync.Mutex
+	key   string
+	block cipher.Block
+}
+
+func (tf *stringTokenize) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	block, err := tf.cipher(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_tokenize: %v", err)
+	}
+
+	s, err := tf.tokenize(block, value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: string_tokenize: %v", err)
+	}
+
+	if !tf.isObject {
+		msg.SetData([]byte(s))
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, s); err != nil {
+		return nil, fmt.Errorf("transform: string_tokenize: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringTokenize) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *stringTokenize) cipher(ctx context.Context) (cipher.Block, error) {
+	key, err := secrets.Interpolate(ctx, tf.conf.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if tf.block != nil && key == tf.key {
+		return tf.block, nil
+	}
+
+	k, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("key: %v", err)
+	}
+
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, fmt.Errorf("key: %v", err)
+	}
+
+	tf.key = key
+	tf.block = block
+
+	return block, nil
+}
+
+// tokenize encrypts (or decrypts) the characters in the string that are in
+// the alphabet and keeps all other characters in the same position.
+func (tf *stringTokenize) tokenize(block cipher.Block, s string) (string, error) {
+	r := []rune(s)
+
+	var pos, x []int
+	for i, c := range r {
+		if n, ok := tf.index[c]; ok {
+			pos = append(pos, i)
+			x = append(x, n)
+		}
+	}
+
+	if len(x) < tf.minLen {
+		return "", errStringTokenizeTooShort
+	}
+
+	y := strFF1(block, len(tf.alphabet), []byte(tf.conf.Tweak), x, tf.conf.Decrypt)
+	for i, p := range pos {
+		r[p] = tf.alphabet[y[i]]
+	}
+
+	return string(r), nil
+}
+
+// strFF1 implements the FF1 mode of format-preserving encryption that is
+// defined in NIST SP 800-38G. The input is a numeral string (each element is
+// less than the radix) and the output is a numeral string of the same length.
+func strFF1(block cipher.Block, radix int, tweak []byte, x []int, decrypt bool) []int {
+	n := len(x)
+	u := n / 2
+	v := n - u
+
+	a := append([]int{}, x[:u]...)
+	b := append([]int{}, x[u:]...)
+
+	// The number of bytes needed to represent a numeral string of length v.
+	bLen := int(math.Ceil(math.Ceil(float64(v)*math.Log2(float64(radix))) / 8))
+	d := 4*((bLen+3)/4) + 4
+
+	p := []byte{1, 2, 1, byte(radix >> 16), byte(radix >> 8), byte(radix), 10, byte(u)}
+	p = append(p, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	t := len(tweak)
+	p = append(p, byte(t>>24), byte(t>>16), byte(t>>8), byte(t))
+
+	pad := ((-t-bLen-1)%16 + 16) % 16
+	bigRadix := big.NewInt(int64(radix))
+
+	for j := 0; j < 10; j++ {
+		i := j
+		if decrypt {
+			i = 9 - j
+		}
+
+		// In encryption the round function is applied to B, and in decryption
+		// it is applied to A.
+		in := b
+		if decrypt {
+			in = a
+		}
+
+		q := make([]byte, 0, t+pad+1+bLen)
+		q = append(q, tweak...)
+		q = append(q, make([]byte, pad)...)
+		q = append(q, byte(i))
+
+		num := strFF1Num(in, bigRadix).Bytes()
+		q = append(q, make([]byte, bLen-len(num))...)
+		q = append(q, num...)
+
+		// PRF is the CBC-MAC of P || Q with a zero IV.
+		mac := make([]byte, 16)
+		strFF1CBCMAC(block, mac, p)
+		strFF1CBCMAC(block, mac, q)
+
+		s := append([]byte{}, mac...)
+		for k := 1; len(s) < d; k++ {
+			blk := append([]byte{}, mac...)
+			for l := 0; l < 4; l++ {
+				blk[15-l] ^= byte(k >> (8 * l))
+			}
+
+			block.Encrypt(blk, blk)
+			s = append(s, blk...)
+		}
+
+		y := new(big.Int).SetBytes(s[:d])
+
+		m := u
+		if i%2 == 1 {
+			m = v
+		}
+
+		mod := new(big.Int).Exp(bigRadix, big.NewInt(int64(m)), nil)
+
+		var c *big.Int
+		if decrypt {
+			c = new(big.Int).Sub(strFF1Num(b, bigRadix), y)
+		} else {
+			c = new(big.Int).Add(strFF1Num(a, bigRadix), y)
+		}
+
+		c.Mod(c, mod)
+
+		if decrypt {
+			b = a
+			a = strFF1Str(c, radix, m)
+		} else {
+			a = b
+			b = strFF1Str(c, radix, m)
+		}
+	}
+
+	return append(a, b...)
+}
+
+// strFF1CBCMAC updates the CBC-MAC state with data, which must be a multiple
+// of the block size.
+func strFF1CBCMAC(block cipher.Block, state, data []byte) {
+	for i := 0; i < len(data); i += 16 {
+		for j := 0; j < 16; j++ {
+			state[j] ^= data[i+j]
+		}
+
+		block.Encrypt(state, state)
+	}
+}
+
+func strFF1Num(x []int, radix *big.Int) *big.Int {
+	n := new(big.Int)
+	for _, v := range x {
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(v)))
+	}
+
+	return n
+}
+
+func strFF1Str(n *big.Int, radix, m int) []int {
+	out := make([]int, m)
+	r := big.NewInt(int64(radix))
+	n = new(big.Int).Set(n)
+
+	rem := new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		n.DivMod(n, r, rem)
+		out[i] = int(rem.Int64())
+	}
+
+	return out
+}
@@ -0,0 +1,175 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringTokenize{}
+
+var stringTokenizeTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	// NIST SP 800-38G FF1 test vectors.
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key": "2B7E151628AED2A6ABF7158809CF4F3C",
+			},
+		},
+		[]byte(`0123456789`),
+		[][]byte{
+			[]byte(`2433477484`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key":   "2B7E151628AED2A6ABF7158809CF4F3C",
+				"tweak": "9876543210",
+			},
+		},
+		[]byte(`0123456789`),
+		[][]byte{
+			[]byte(`6124200773`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key":      "2B7E151628AED2A6ABF7158809CF4F3C",
+				"tweak":    "7777pqrs777",
+				"alphabet": "0123456789abcdefghijklmnopqrstuvwxyz",
+			},
+		},
+		[]byte(`0123456789abcdefghi`),
+		[][]byte{
+			[]byte(`a9tv40mll9kdu509eum`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key":     "2B7E151628AED2A6ABF7158809CF4F3C",
+				"decrypt": true,
+			},
+		},
+		[]byte(`2433477484`),
+		[][]byte{
+			[]byte(`0123456789`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key": "2B7E151628AED2A6ABF7158809CF4F3C",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"012-34-5678"}`),
+		[][]byte{
+			[]byte(`{"a":"012-34-5678","b":"362-97-4589"}`),
+		},
+	},
+	{
+		"object decrypt",
+		config.Config{
+			Settings: map[string]interface{}{
+				"key":     "2B7E151628AED2A6ABF7158809CF4F3C",
+				"decrypt": true,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"362-97-4589"}`),
+		[][]byte{
+			[]byte(`{"a":"362-97-4589","b":"012-34-5678"}`),
+		},
+	},
+}
+
+func TestStringTokenize(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringTokenizeTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringTokenize(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringTokenize(b *testing.B, tf *stringTokenize, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringTokenize(b *testing.B) {
+	for _, test := range stringTokenizeTests {
+		tf, err := newStringTokenize(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringTokenize(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestStringTokenizeTooShort(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newStringTokenize(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"key": "2B7E151628AED2A6ABF7158809CF4F3C",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`12-34`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error")
+	}
+}
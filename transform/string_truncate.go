@@ -0,0 +1,180 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type stringTruncateConfig struct {
+	// Max is the maximum length of the value. If the value is an array, then
+	// this is the maximum number of elements.
+	Max int `json:"max"`
+	// Measurement controls how the length of strings is measured.
+	//
+	// Must be one of:
+	//	- byte: number of bytes
+	//	- char: number of characters
+	//
+	// Strings are never truncated in the middle of a character, so strings that
+	// are measured in bytes may be shorter than Max.
+	//
+	// This is optional and defaults to byte.
+	Measurement string `json:"measurement"`
+	// Suffix is appended to strings that are truncated (e.g., "..."). The
+	// length of the suffix is included in Max.
+	//
+	// This is optional and has no default.
+	Suffix string `json:"suffix"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *stringTruncateConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *stringTruncateConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Max <= 0 {
+		return fmt.Errorf("max: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"byte",
+			"char",
+		},
+		c.Measurement) {
+		return fmt.Errorf("measurement %q: %v", c.Measurement, errors.ErrInvalidOption)
+	}
+
+	if strTruncateLen(c.Suffix, c.Measurement) >= c.Max {
+		return fmt.Errorf("suffix: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newStringTruncate(_ context.Context, cfg config.Config) (*stringTruncate, error) {
+	conf := stringTruncateConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: string_truncate: %v", err)
+	}
+
+	if conf.Measurement == "" {
+		conf.Measurement = "byte"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: string_truncate: %v", err)
+	}
+
+	tf := stringTruncate{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// stringTruncate limits the length of strings and arrays, which prevents
+// values from exceeding the size limits of destinations (e.g., the maximum size
+// of a DynamoDB item).
+type stringTruncate struct {
+	conf     stringTruncateConfig
+	isObject bool
+}
+
+func (tf *stringTruncate) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !tf.isObject {
+		s := tf.truncate(string(msg.Data()))
+		msg.SetData([]byte(s))
+
+		return []*message.Message{msg}, nil
+	}
+
+	value := msg.GetValue(tf.conf.Object.SourceKey)
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	var v interface{}
+	if value.IsArray() {
+		arr := value.Array()
+		if len(arr) > tf.conf.Max {
+			arr = arr[:tf.conf.Max]
+		}
+
+		vals := make([]interface{}, len(arr))
+		for i, a := range arr {
+			vals[i] = a.Value()
+		}
+
+		v = vals
+	} else {
+		v = tf.truncate(value.String())
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, v); err != nil {
+		return nil, fmt.Errorf("transform: string_truncate: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *stringTruncate) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *stringTruncate) truncate(s string) string {
+	if strTruncateLen(s, tf.conf.Measurement) <= tf.conf.Max {
+		return s
+	}
+
+	limit := tf.conf.Max - strTruncateLen(tf.conf.Suffix, tf.conf.Measurement)
+
+	var n int
+	for i := range s {
+		size := 1
+		if tf.conf.Measurement == "byte" {
+			_, size = utf8.DecodeRuneInString(s[i:])
+		}
+
+		if n+size > limit {
+			return s[:i] + tf.conf.Suffix
+		}
+
+		n += size
+	}
+
+	return s
+}
+
+func strTruncateLen(s, measurement string) int {
+	if measurement == "char" {
+		return utf8.RuneCountInString(s)
+	}
+
+	return len(s)
+}
@@ -0,0 +1,167 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &stringTruncate{}
+
+var stringTruncateTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max": 5,
+			},
+		},
+		[]byte(`abcdefg`),
+		[][]byte{
+			[]byte(`abcde`),
+		},
+	},
+	{
+		"data short",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max": 5,
+			},
+		},
+		[]byte(`abc`),
+		[][]byte{
+			[]byte(`abc`),
+		},
+	},
+	{
+		"data suffix",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max":    6,
+				"suffix": "...",
+			},
+		},
+		[]byte(`abcdefg`),
+		[][]byte{
+			[]byte(`abc...`),
+		},
+	},
+	{
+		"data byte",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max": 5,
+			},
+		},
+		[]byte(`héllo`),
+		[][]byte{
+			[]byte(`héll`),
+		},
+	},
+	{
+		"data char",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max":         4,
+				"measurement": "char",
+				"suffix":      "…",
+			},
+		},
+		[]byte(`héllo`),
+		[][]byte{
+			[]byte(`hél…`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max": 2,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":"abc"}`),
+		[][]byte{
+			[]byte(`{"a":"ab"}`),
+		},
+	},
+	{
+		"object array",
+		config.Config{
+			Settings: map[string]interface{}{
+				"max": 2,
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "a",
+				},
+			},
+		},
+		[]byte(`{"a":[1,{"b":2},3]}`),
+		[][]byte{
+			[]byte(`{"a":[1,{"b":2}]}`),
+		},
+	},
+}
+
+func TestStringTruncate(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range stringTruncateTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newStringTruncate(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkStringTruncate(b *testing.B, tf *stringTruncate, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkStringTruncate(b *testing.B) {
+	for _, test := range stringTruncateTests {
+		tf, err := newStringTruncate(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkStringTruncate(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	iconfig "github.com/brexhq/substation/internal/config"
 	"github.com/brexhq/substation/internal/errors"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -59,6 +60,65 @@ func (c *timePatternConfig) Validate() error {
 	return nil
 }
 
+type timeDurationConfig struct {
+	// Format is the format of the duration string.
+	//
+	// Must be one of:
+	//	- go: Go duration (e.g., 1h30m, 1.5s, 300ms)
+	//	- iso8601: ISO 8601 duration (e.g., PT1H30M, P1DT12H). Years and months
+	//	are not supported because their length varies.
+	//	- seconds: number of seconds (e.g., 5400, 1.5)
+	//
+	// This is optional. When converting from a duration, the default is to
+	// detect the format from each value. When converting to a duration, the
+	// default is go.
+	Format string `json:"format"`
+	// Unit is the unit of the numeric duration.
+	//
+	// Must be one of:
+	//	- ns
+	//	- us
+	//	- ms
+	//	- s
+	//	- m
+	//	- h
+	//
+	// This is optional and defaults to s.
+	Unit string `json:"unit"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *timeDurationConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *timeDurationConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Format != "" && !slices.Contains(
+		[]string{
+			"go",
+			"iso8601",
+			"seconds",
+		},
+		c.Format) {
+		return fmt.Errorf("format %q: %v", c.Format, errors.ErrInvalidOption)
+	}
+
+	if _, ok := timeDeltaUnits[c.Unit]; !ok {
+		return fmt.Errorf("unit %q: %v", c.Unit, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func timeUnixToBytes(t time.Time) []byte {
 	return []byte(fmt.Sprintf("%d", t.UnixNano()))
 }
@@ -0,0 +1,162 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/exp/slices"
+)
+
+type timeBucketConfig struct {
+	// Duration is the size of each time window (e.g., 5m). Timestamps are
+	// rounded down to the start of the window that contains them.
+	Duration string `json:"duration"`
+	// Alignment determines where time windows start.
+	//
+	// Must be one of:
+	//	- epoch: windows are aligned to the Unix epoch (e.g., 5m windows start at 00:00, 00:05, ...)
+	//	- first: windows are aligned to the first timestamp that is seen by the transform
+	//
+	// This is optional and defaults to epoch.
+	Alignment string `json:"alignment"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *timeBucketConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *timeBucketConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Duration == "" {
+		return fmt.Errorf("duration: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if !slices.Contains(
+		[]string{
+			"epoch",
+			"first",
+		},
+		c.Alignment) {
+		return fmt.Errorf("alignment %q: %v", c.Alignment, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newTimeBucket(_ context.Context, cfg config.Config) (*timeBucket, error) {
+	conf := timeBucketConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: time_bucket: %v", err)
+	}
+
+	if conf.Alignment == "" {
+		conf.Alignment = "epoch"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: time_bucket: %v", err)
+	}
+
+	dur, err := time.ParseDuration(conf.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_bucket: duration: %v", err)
+	}
+
+	if dur <= 0 {
+		return nil, fmt.Errorf("transform: time_bucket: duration: %v", errors.ErrInvalidOption)
+	}
+
+	tf := timeBucket{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+		dur:      int64(dur),
+	}
+
+	return &tf, nil
+}
+
+type timeBucket struct {
+	conf     timeBucketConfig
+	isObject bool
+
+	dur int64
+
+	mu sync.Mutex
+	// origin is the start of the first window if windows are aligned
+	// to the first timestamp.
+	origin    int64
+	hasOrigin bool
+}
+
+func (tf *timeBucket) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	ts := tf.bucket(value.Int())
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, ts); err != nil {
+			return nil, fmt.Errorf("transform: time_bucket: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(fmt.Sprintf("%d", ts)))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *timeBucket) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// bucket returns the start of the window (in UnixNano) that contains the timestamp.
+func (tf *timeBucket) bucket(ts int64) int64 {
+	var origin int64
+	if tf.conf.Alignment == "first" {
+		tf.mu.Lock()
+		if !tf.hasOrigin {
+			tf.origin = ts
+			tf.hasOrigin = true
+		}
+		origin = tf.origin
+		tf.mu.Unlock()
+	}
+
+	// Timestamps before the origin are rounded down, not towards zero.
+	offset := (ts - origin) % tf.dur
+	if offset < 0 {
+		offset += tf.dur
+	}
+
+	return ts - offset
+}
@@ -0,0 +1,158 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &timeBucket{}
+
+var timeBucketTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"duration": "5m",
+			},
+		},
+		[]byte(`1639877490061000000`),
+		[][]byte{
+			[]byte(`1639877400000000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"duration": "1h",
+			},
+		},
+		[]byte(`1639877490061000000`),
+		[][]byte{
+			[]byte(`1639875600000000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"duration":  "5m",
+				"alignment": "first",
+			},
+		},
+		[]byte(`1639877490061000000`),
+		[][]byte{
+			[]byte(`1639877490061000000`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"duration": "5m",
+			},
+		},
+		[]byte(`{"a":1639877490061000000}`),
+		[][]byte{
+			[]byte(`{"a":1639877490061000000,"b":1639877400000000000}`),
+		},
+	},
+}
+
+func TestTimeBucket(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range timeBucketTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newTimeBucket(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func TestTimeBucketAlignmentFirst(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newTimeBucket(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"duration":  "5m",
+			"alignment": "first",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first timestamp is the start of the first window, so later and
+	// earlier timestamps are bucketed relative to it.
+	tests := [][]string{
+		{"1639877490061000000", "1639877490061000000"},
+		{"1639877910061000000", "1639877790061000000"},
+		{"1639877430061000000", "1639877190061000000"},
+	}
+
+	for _, test := range tests {
+		msg := message.New().SetData([]byte(test[0]))
+		result, err := tf.Transform(ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(result[0].Data()) != test[1] {
+			t.Errorf("expected %s, got %s", test[1], result[0].Data())
+		}
+	}
+}
+
+func benchmarkTimeBucket(b *testing.B, tf *timeBucket, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkTimeBucket(b *testing.B) {
+	for _, test := range timeBucketTests {
+		tf, err := newTimeBucket(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkTimeBucket(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,182 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// errTimeDeltaNegative is returned when the end time is before the start time
+// and the transform is configured to not allow negative deltas.
+var errTimeDeltaNegative = fmt.Errorf("negative time delta")
+
+var timeDeltaUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+type timeDeltaConfig struct {
+	// StartKey retrieves the start time from a JSON object.
+	StartKey string `json:"start_key"`
+	// StartFormat is the format of the start time.
+	//
+	// Must be one of:
+	//	- unix: seconds since the Unix epoch
+	//	- unix_milli: milliseconds since the Unix epoch
+	//	- unix_nano: nanoseconds since the Unix epoch
+	//	- any Go time layout (e.g., 2006-01-02T15:04:05Z07:00)
+	//
+	// This is optional and defaults to unix_nano.
+	StartFormat string `json:"start_format"`
+	// EndKey retrieves the end time from a JSON object.
+	EndKey string `json:"end_key"`
+	// EndFormat is the format of the end time. This uses the same
+	// formats as StartFormat.
+	//
+	// This is optional and defaults to unix_nano.
+	EndFormat string `json:"end_format"`
+	// Location is the timezone of time layouts that do not include
+	// a timezone.
+	//
+	// This is optional and defaults to UTC.
+	Location string `json:"location"`
+	// Unit is the unit of the delta (end time minus start time). Partial
+	// units are truncated (e.g., 1999ms is 1s).
+	//
+	// Must be one of:
+	//	- ns
+	//	- us
+	//	- ms
+	//	- s
+	//	- m
+	//	- h
+	//
+	// This is optional and defaults to ms.
+	Unit string `json:"unit"`
+	// ErrorOnNegative determines if an error is returned when the end
+	// time is before the start time.
+	//
+	// This is optional and defaults to false (negative deltas are allowed).
+	ErrorOnNegative bool `json:"error_on_negative"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *timeDeltaConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *timeDeltaConfig) Validate() error {
+	if c.StartKey == "" {
+		return fmt.Errorf("start_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.EndKey == "" {
+		return fmt.Errorf("end_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if _, ok := timeDeltaUnits[c.Unit]; !ok {
+		return fmt.Errorf("unit %q: %v", c.Unit, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newTimeDelta(_ context.Context, cfg config.Config) (*timeDelta, error) {
+	conf := timeDeltaConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: time_delta: %v", err)
+	}
+
+	if conf.StartFormat == "" {
+		conf.StartFormat = "unix_nano"
+	}
+
+	if conf.EndFormat == "" {
+		conf.EndFormat = "unix_nano"
+	}
+
+	if conf.Unit == "" {
+		conf.Unit = "ms"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: time_delta: %v", err)
+	}
+
+	tf := timeDelta{
+		conf: conf,
+	}
+
+	return &tf, nil
+}
+
+type timeDelta struct {
+	conf timeDeltaConfig
+}
+
+func (tf *timeDelta) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	startVal := msg.GetValue(tf.conf.StartKey)
+	endVal := msg.GetValue(tf.conf.EndKey)
+	if !startVal.Exists() || !endVal.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	start, err := tf.parse(startVal, tf.conf.StartFormat)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_delta: start: %v", err)
+	}
+
+	end, err := tf.parse(endVal, tf.conf.EndFormat)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_delta: end: %v", err)
+	}
+
+	delta := end.Sub(start)
+	if delta < 0 && tf.conf.ErrorOnNegative {
+		return nil, fmt.Errorf("transform: time_delta: %v", errTimeDeltaNegative)
+	}
+
+	d := int64(delta / timeDeltaUnits[tf.conf.Unit])
+	if err := msg.SetValue(tf.conf.Object.TargetKey, d); err != nil {
+		return nil, fmt.Errorf("transform: time_delta: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *timeDelta) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *timeDelta) parse(v message.Value, format string) (time.Time, error) {
+	switch format {
+	case "unix":
+		return time.Unix(v.Int(), 0), nil
+	case "unix_milli":
+		return time.UnixMilli(v.Int()), nil
+	case "unix_nano":
+		return time.Unix(0, v.Int()), nil
+	default:
+		return timeStrToUnix(v.String(), format, tf.conf.Location)
+	}
+}
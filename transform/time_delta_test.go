@@ -0,0 +1,178 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &timeDelta{}
+
+var timeDeltaTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+				"start_key": "a",
+				"end_key":   "b",
+			},
+		},
+		[]byte(`{"a":1639877490061000000,"b":1639877491561000000}`),
+		[][]byte{
+			[]byte(`{"a":1639877490061000000,"b":1639877491561000000,"c":1500}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+				"start_key": "a",
+				"end_key":   "b",
+				"unit":      "s",
+			},
+		},
+		[]byte(`{"a":1639877490061000000,"b":1639877491561000000}`),
+		[][]byte{
+			[]byte(`{"a":1639877490061000000,"b":1639877491561000000,"c":1}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+				"start_key":    "a",
+				"end_key":      "b",
+				"start_format": "2006-01-02T15:04:05Z07:00",
+				"end_format":   "unix_milli",
+			},
+		},
+		[]byte(`{"a":"2021-12-19T01:31:30Z","b":1639877489000}`),
+		[][]byte{
+			[]byte(`{"a":"2021-12-19T01:31:30Z","b":1639877489000,"c":-1000}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+				"start_key":    "a",
+				"end_key":      "b",
+				"start_format": "unix",
+				"end_format":   "unix",
+				"unit":         "m",
+			},
+		},
+		[]byte(`{"a":1639877400,"b":1639880400}`),
+		[][]byte{
+			[]byte(`{"a":1639877400,"b":1639880400,"c":50}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "c",
+				},
+				"start_key": "a",
+				"end_key":   "b",
+			},
+		},
+		[]byte(`{"a":1639877490061000000}`),
+		[][]byte{
+			[]byte(`{"a":1639877490061000000}`),
+		},
+	},
+}
+
+func TestTimeDelta(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range timeDeltaTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newTimeDelta(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkTimeDelta(b *testing.B, tf *timeDelta, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkTimeDelta(b *testing.B) {
+	for _, test := range timeDeltaTests {
+		tf, err := newTimeDelta(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkTimeDelta(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestTimeDeltaErrorOnNegative(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newTimeDelta(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"object": map[string]interface{}{
+				"target_key": "c",
+			},
+			"start_key":         "a",
+			"end_key":           "b",
+			"error_on_negative": true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":2,"b":1}`))
+	if _, err := tf.Transform(ctx, msg); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
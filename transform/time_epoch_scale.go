@@ -0,0 +1,188 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+// errTimeEpochScaleOverflow is returned when the timestamp cannot be represented
+// as nanoseconds since the Unix epoch.
+var errTimeEpochScaleOverflow = fmt.Errorf("timestamp overflows nanoseconds")
+
+// timeEpochScaleUnits are the number of nanoseconds in each unit.
+var timeEpochScaleUnits = map[string]int64{
+	"s":  1e9,
+	"ms": 1e6,
+	"us": 1e3,
+	"ns": 1,
+}
+
+type timeEpochScaleConfig struct {
+	// From is the unit of the input timestamp.
+	//
+	// Must be one of:
+	//	- s: seconds
+	//	- ms: milliseconds
+	//	- us: microseconds
+	//	- ns: nanoseconds
+	//	- auto: the unit is detected from the magnitude of the timestamp
+	//
+	// Detection assumes that timestamps are between 1973 and 5138 (e.g.,
+	// 1700000000 is seconds and 1700000000000 is milliseconds).
+	//
+	// This is optional and defaults to auto.
+	From string `json:"from"`
+	// To is the unit of the output timestamp. This uses the same units as
+	// From, except auto.
+	//
+	// This is optional and defaults to ns.
+	To string `json:"to"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *timeEpochScaleConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *timeEpochScaleConfig) Validate() error {
+	if c.Object.SourceKey == "" && c.Object.TargetKey != "" {
+		return fmt.Errorf("object_source_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Object.SourceKey != "" && c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if _, ok := timeEpochScaleUnits[c.From]; !ok && c.From != "auto" {
+		return fmt.Errorf("from %q: %v", c.From, errors.ErrInvalidOption)
+	}
+
+	if _, ok := timeEpochScaleUnits[c.To]; !ok {
+		return fmt.Errorf("to %q: %v", c.To, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newTimeEpochScale(_ context.Context, cfg config.Config) (*timeEpochScale, error) {
+	conf := timeEpochScaleConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: time_epoch_scale: %v", err)
+	}
+
+	if conf.From == "" {
+		conf.From = "auto"
+	}
+
+	if conf.To == "" {
+		conf.To = "ns"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: time_epoch_scale: %v", err)
+	}
+
+	tf := timeEpochScale{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+type timeEpochScale struct {
+	conf     timeEpochScaleConfig
+	isObject bool
+}
+
+func (tf *timeEpochScale) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	ts, err := tf.scale(value.String())
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_epoch_scale: %v", err)
+	}
+
+	if tf.isObject {
+		if err := msg.SetValue(tf.conf.Object.TargetKey, ts); err != nil {
+			return nil, fmt.Errorf("transform: time_epoch_scale: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	msg.SetData([]byte(strconv.FormatInt(ts, 10)))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *timeEpochScale) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// scale converts the timestamp to nanoseconds and then to the output unit.
+// Partial units are truncated.
+func (tf *timeEpochScale) scale(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+
+	// Fractional timestamps (e.g., 1700000000.123) are common for seconds.
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	unit := tf.conf.From
+	if unit == "auto" {
+		unit = timeEpochScaleDetect(f)
+	}
+
+	if math.Abs(f) > float64(math.MaxInt64/timeEpochScaleUnits[unit]) {
+		return 0, errTimeEpochScaleOverflow
+	}
+
+	var ns int64
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		ns = i * timeEpochScaleUnits[unit]
+	} else {
+		ns = int64(math.Round(f * float64(timeEpochScaleUnits[unit])))
+	}
+
+	return ns / timeEpochScaleUnits[tf.conf.To], nil
+}
+
+// timeEpochScaleDetect returns the unit of the timestamp based on its magnitude.
+func timeEpochScaleDetect(f float64) string {
+	switch a := math.Abs(f); {
+	case a < 1e11:
+		return "s"
+	case a < 1e14:
+		return "ms"
+	case a < 1e17:
+		return "us"
+	default:
+		return "ns"
+	}
+}
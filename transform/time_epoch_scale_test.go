@@ -0,0 +1,188 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &timeEpochScale{}
+
+var timeEpochScaleTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	{
+		"data",
+		config.Config{},
+		[]byte(`1639877490`),
+		[][]byte{
+			[]byte(`1639877490000000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`1639877490061`),
+		[][]byte{
+			[]byte(`1639877490061000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`1639877490061000`),
+		[][]byte{
+			[]byte(`1639877490061000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`1639877490061000000`),
+		[][]byte{
+			[]byte(`1639877490061000000`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"to": "ms",
+			},
+		},
+		[]byte(`1639877490.061`),
+		[][]byte{
+			[]byte(`1639877490061`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": "ms",
+				"to":   "s",
+			},
+		},
+		[]byte(`1639877490061`),
+		[][]byte{
+			[]byte(`1639877490`),
+		},
+	},
+	// Explicit units override detection.
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"from": "us",
+				"to":   "ms",
+			},
+		},
+		[]byte(`1639877490061`),
+		[][]byte{
+			[]byte(`1639877490`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"to": "s",
+			},
+		},
+		[]byte(`{"a":1639877490061000}`),
+		[][]byte{
+			[]byte(`{"a":1639877490061000,"b":1639877490}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+				"to": "us",
+			},
+		},
+		[]byte(`{"a":"1639877490061"}`),
+		[][]byte{
+			[]byte(`{"a":"1639877490061","b":1639877490061000}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"c":1639877490}`),
+		[][]byte{
+			[]byte(`{"c":1639877490}`),
+		},
+	},
+}
+
+func TestTimeEpochScale(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range timeEpochScaleTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newTimeEpochScale(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkTimeEpochScale(b *testing.B, tf *timeEpochScale, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkTimeEpochScale(b *testing.B) {
+	for _, test := range timeEpochScaleTests {
+		tf, err := newTimeEpochScale(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkTimeEpochScale(b, tf, test.test)
+			},
+		)
+	}
+}
@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+// errTimeFromDurationInvalid is returned when a value is not a valid duration
+// in the configured format.
+var errTimeFromDurationInvalid = fmt.Errorf("invalid duration")
+
+// errTimeFromDurationOverflow is returned when a duration is too large to be
+// represented in nanoseconds (about 292 years).
+var errTimeFromDurationOverflow = fmt.Errorf("duration out of range")
+
+// timeFromDurationISO8601 matches ISO 8601 durations that have a fixed length
+// (weeks, days, hours, minutes, and seconds). Fractions can use a period or a
+// comma.
+var timeFromDurationISO8601 = regexp.MustCompile(`^([-+])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+func newTimeFromDuration(_ context.Context, cfg config.Config) (*timeFromDuration, error) {
+	conf := timeDurationConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: time_from_duration: %v", err)
+	}
+
+	if conf.Unit == "" {
+		conf.Unit = "s"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: time_from_duration: %v", err)
+	}
+
+	tf := timeFromDuration{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// timeFromDuration converts a duration string into a number in the
+// configured unit (e.g., 1h30m is 5400 seconds). Partial units are kept as
+// fractions (e.g., 90s is 1.5 minutes).
+type timeFromDuration struct {
+	conf     timeDurationConfig
+	isObject bool
+}
+
+func (tf *timeFromDuration) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	d, err := timeFromDurationParse(strings.TrimSpace(value.String()), tf.conf.Format)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_from_duration: %q: %v", value.String(), err)
+	}
+
+	// Durations are converted through a string to remove floating point
+	// errors (e.g., 0.30000000000000004).
+	f := float64(d) / float64(timeDeltaUnits[tf.conf.Unit])
+	str := strconv.FormatFloat(f, 'f', 9, 64)
+	str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+
+	if !tf.isObject {
+		msg.SetData([]byte(str))
+		return []*message.Message{msg}, nil
+	}
+
+	f, err = strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_from_duration: %v", err)
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, f); err != nil {
+		return nil, fmt.Errorf("transform: time_from_duration: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *timeFromDuration) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func timeFromDurationParse(s, format string) (time.Duration, error) {
+	if format == "" {
+		switch {
+		case strings.HasPrefix(strings.TrimLeft(s, "+-"), "P"):
+			format = "iso8601"
+		case strings.Trim(s, "+-.0123456789") == "":
+			format = "seconds"
+		default:
+			format = "go"
+		}
+	}
+
+	switch format {
+	case "iso8601":
+		return timeFromDurationParseISO8601(s)
+	case "seconds":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, errTimeFromDurationInvalid
+		}
+
+		if math.Abs(f*float64(time.Second)) >= math.MaxInt64 {
+			return 0, errTimeFromDurationOverflow
+		}
+
+		return time.Duration(f * float64(time.Second)), nil
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, errTimeFromDurationInvalid
+		}
+
+		return d, nil
+	}
+}
+
+func timeFromDurationParseISO8601(s string) (time.Duration, error) {
+	m := timeFromDurationISO8601.FindStringSubmatch(s)
+	// "P" and "PT" are not valid durations, at least one value is required.
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, errTimeFromDurationInvalid
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d float64
+	for i, u := range units {
+		v := m[i+2]
+		if v == "" {
+			continue
+		}
+
+		f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil {
+			return 0, errTimeFromDurationInvalid
+		}
+
+		// Each component is checked so that the sum cannot overflow when it
+		// is converted to a time.Duration.
+		d += f * float64(u)
+		if d >= math.MaxInt64 {
+			return 0, errTimeFromDurationOverflow
+		}
+	}
+
+	if m[1] == "-" {
+		d = -d
+	}
+
+	return time.Duration(d), nil
+}
@@ -0,0 +1,202 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &timeFromDuration{}
+
+var timeFromDurationTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`1h30m`),
+		[][]byte{
+			[]byte(`5400`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`PT1H30M`),
+		[][]byte{
+			[]byte(`5400`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`P1DT0,5S`),
+		[][]byte{
+			[]byte(`86400.5`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`-PT1M`),
+		[][]byte{
+			[]byte(`-60`),
+		},
+	},
+	{
+		"data",
+		config.Config{},
+		[]byte(`1.5`),
+		[][]byte{
+			[]byte(`1.5`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unit": "m",
+			},
+		},
+		[]byte(`90s`),
+		[][]byte{
+			[]byte(`1.5`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unit":   "ms",
+				"format": "iso8601",
+			},
+		},
+		[]byte(`P1W`),
+		[][]byte{
+			[]byte(`604800000`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"300ms"}`),
+		[][]byte{
+			[]byte(`{"a":"300ms","b":0.3}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unit": "h",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":5400}`),
+		[][]byte{
+			[]byte(`{"a":5400,"b":1.5}`),
+		},
+	},
+}
+
+func TestTimeFromDuration(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range timeFromDurationTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newTimeFromDuration(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkTimeFromDuration(b *testing.B, tf *timeFromDuration, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkTimeFromDuration(b *testing.B) {
+	for _, test := range timeFromDurationTests {
+		tf, err := newTimeFromDuration(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkTimeFromDuration(b, tf, test.test)
+			},
+		)
+	}
+}
+
+func TestTimeFromDurationInvalid(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range []struct {
+		format string
+		data   []byte
+	}{
+		{"", []byte(`1x`)},
+		{"", []byte(`P1M`)},
+		{"iso8601", []byte(`PT`)},
+		{"iso8601", []byte(`1h`)},
+		{"seconds", []byte(`1h`)},
+		// These overflow int64 nanoseconds.
+		{"", []byte(`P999999999999DT1H`)},
+		{"iso8601", []byte(`-P106752DT1H`)},
+		{"iso8601", []byte(`PT9223372037S`)},
+		{"seconds", []byte(`9223372037`)},
+		{"seconds", []byte(`-9223372037`)},
+	} {
+		tf, err := newTimeFromDuration(ctx, config.Config{
+			Settings: map[string]interface{}{
+				"format": test.format,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		msg := message.New().SetData(test.data)
+		if _, err := tf.Transform(ctx, msg); err == nil {
+			t.Errorf("expected error for %s", test.data)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+func newTimeToDuration(_ context.Context, cfg config.Config) (*timeToDuration, error) {
+	conf := timeDurationConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: time_to_duration: %v", err)
+	}
+
+	if conf.Format == "" {
+		conf.Format = "go"
+	}
+
+	if conf.Unit == "" {
+		conf.Unit = "s"
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: time_to_duration: %v", err)
+	}
+
+	tf := timeToDuration{
+		conf:     conf,
+		isObject: conf.Object.SourceKey != "" && conf.Object.TargetKey != "",
+	}
+
+	return &tf, nil
+}
+
+// timeToDuration converts a number in the configured unit into a duration
+// string (e.g., 5400 seconds is 1h30m0s or PT1H30M).
+type timeToDuration struct {
+	conf     timeDurationConfig
+	isObject bool
+}
+
+func (tf *timeToDuration) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var value message.Value
+	if tf.isObject {
+		value = msg.GetValue(tf.conf.Object.SourceKey)
+	} else {
+		value = bytesToValue(msg.Data())
+	}
+
+	if !value.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+	if err != nil {
+		return nil, fmt.Errorf("transform: time_to_duration: %q: %v", value.String(), errTimeFromDurationInvalid)
+	}
+
+	d := time.Duration(f * float64(timeDeltaUnits[tf.conf.Unit]))
+
+	var str string
+	switch tf.conf.Format {
+	case "iso8601":
+		str = timeToDurationISO8601(d)
+	case "seconds":
+		str = numberFloat64ToString(d.Seconds())
+	default:
+		str = d.String()
+	}
+
+	if !tf.isObject {
+		msg.SetData([]byte(str))
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, str); err != nil {
+		return nil, fmt.Errorf("transform: time_to_duration: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *timeToDuration) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// timeToDurationISO8601 formats the duration using hours, minutes, and
+// seconds. Days are not used because they are not always 24 hours.
+func timeToDurationISO8601(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+
+	b.WriteString("PT")
+
+	if h := d / time.Hour; h > 0 {
+		b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+
+	if m := d / time.Minute; m > 0 {
+		b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+
+	if d > 0 {
+		b.WriteString(numberFloat64ToString(d.Seconds()) + "S")
+	}
+
+	return b.String()
+}
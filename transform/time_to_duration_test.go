@@ -0,0 +1,150 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &timeToDuration{}
+
+var timeToDurationTests = []struct {
+	name     string
+	cfg      config.Config
+	test     []byte
+	expected [][]byte
+}{
+	// data tests
+	{
+		"data",
+		config.Config{},
+		[]byte(`5400`),
+		[][]byte{
+			[]byte(`1h30m0s`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "iso8601",
+			},
+		},
+		[]byte(`5400.25`),
+		[][]byte{
+			[]byte(`PT1H30M0.25S`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "iso8601",
+			},
+		},
+		[]byte(`0`),
+		[][]byte{
+			[]byte(`PT0S`),
+		},
+	},
+	{
+		"data",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "iso8601",
+				"unit":   "ms",
+			},
+		},
+		[]byte(`-90000`),
+		[][]byte{
+			[]byte(`-PT1M30S`),
+		},
+	},
+	// object tests
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"unit": "ms",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":1500}`),
+		[][]byte{
+			[]byte(`{"a":1500,"b":"1.5s"}`),
+		},
+	},
+	{
+		"object",
+		config.Config{
+			Settings: map[string]interface{}{
+				"format": "seconds",
+				"unit":   "h",
+				"object": map[string]interface{}{
+					"source_key": "a",
+					"target_key": "b",
+				},
+			},
+		},
+		[]byte(`{"a":"0.5"}`),
+		[][]byte{
+			[]byte(`{"a":"0.5","b":"1800"}`),
+		},
+	},
+}
+
+func TestTimeToDuration(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range timeToDurationTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newTimeToDuration(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := message.New().SetData(test.test)
+			result, err := tf.Transform(ctx, msg)
+			if err != nil {
+				t.Error(err)
+			}
+
+			var r [][]byte
+			for _, c := range result {
+				r = append(r, c.Data())
+			}
+
+			if !reflect.DeepEqual(r, test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, r)
+			}
+		})
+	}
+}
+
+func benchmarkTimeToDuration(b *testing.B, tf *timeToDuration, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkTimeToDuration(b *testing.B) {
+	for _, test := range timeToDurationTests {
+		tf, err := newTimeToDuration(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkTimeToDuration(b, tf, test.test)
+			},
+		)
+	}
+}
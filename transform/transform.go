@@ -4,9 +4,11 @@ package transform
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/brexhq/substation/config"
 	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/internal/log"
 	"github.com/brexhq/substation/message"
 )
 
@@ -25,22 +27,40 @@ type Factory func(context.Context, config.Config) (Transformer, error)
 func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint: cyclop, gocyclo // ignore cyclomatic complexity
 	switch cfg.Type {
 	// Aggregation transforms.
+	case "aggregate_cumulative_sum":
+		return newAggregateCumulativeSum(ctx, cfg)
 	case "aggregate_from_array":
 		return newAggregateFromArray(ctx, cfg)
 	case "aggregate_to_array":
 		return newAggregateToArray(ctx, cfg)
+	case "aggregate_to_stats":
+		return newAggregateToStats(ctx, cfg)
 	case "aggregate_from_string":
 		return newAggregateFromString(ctx, cfg)
 	case "aggregate_to_string":
 		return newAggregateToString(ctx, cfg)
+	case "aggregate_multiline":
+		return newAggregateMultiline(ctx, cfg)
 	// Array transforms.
+	case "array_index":
+		return newArrayIndex(ctx, cfg)
 	case "array_join":
 		return newArrayJoin(ctx, cfg)
+	case "array_set":
+		return newArraySet(ctx, cfg)
+	case "array_slice":
+		return newArraySlice(ctx, cfg)
+	case "array_sort":
+		return newArraySort(ctx, cfg)
+	case "array_unique":
+		return newArrayUnique(ctx, cfg)
 	case "array_zip":
 		return newArrayZip(ctx, cfg)
 	// Enrichment transforms.
 	case "enrich_aws_dynamodb":
 		return newEnrichAWSDynamoDB(ctx, cfg)
+	case "enrich_aws_dynamodb_get_item":
+		return newEnrichAWSDynamoDBGetItem(ctx, cfg)
 	case "enrich_aws_lambda":
 		return newEnrichAWSLambda(ctx, cfg)
 	case "enrich_dns_ip_lookup":
@@ -49,6 +69,8 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newEnrichDNSDomainLookup(ctx, cfg)
 	case "enrich_dns_text_lookup":
 		return newEnrichDNSTxtLookup(ctx, cfg)
+	case "enrich_geofence":
+		return newEnrichGeofence(ctx, cfg)
 	case "enrich_http_get":
 		return newEnrichHTTPGet(ctx, cfg)
 	case "enrich_http_post":
@@ -60,20 +82,52 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 	// Format transforms.
 	case "format_from_base64":
 		return newFormatFromBase64(ctx, cfg)
+	case "format_from_clf":
+		return newFormatFromCLF(ctx, cfg)
+	case "format_from_fixed_width":
+		return newFormatFromFixedWidth(ctx, cfg)
+	case "format_from_geohash":
+		return newFormatFromGeohash(ctx, cfg)
 	case "format_to_base64":
 		return newFormatToBase64(ctx, cfg)
+	case "format_to_geohash":
+		return newFormatToGeohash(ctx, cfg)
 	case "format_from_gzip":
 		return newFormatFromGzip(ctx, cfg)
 	case "format_to_gzip":
 		return newFormatToGzip(ctx, cfg)
+	case "format_from_json":
+		return newFormatFromJSON(ctx, cfg)
+	case "format_to_json":
+		return newFormatToJSON(ctx, cfg)
+	case "format_from_jwt":
+		return newFormatFromJWT(ctx, cfg)
+	case "format_from_msgpack":
+		return newFormatFromMsgPack(ctx, cfg)
+	case "format_to_msgpack":
+		return newFormatToMsgPack(ctx, cfg)
 	case "format_from_pretty_print":
 		return newFormatFromPrettyPrint(ctx, cfg)
+	case "format_from_query_string":
+		return newFormatFromQueryString(ctx, cfg)
+	case "format_from_syslog":
+		return newFormatFromSyslog(ctx, cfg)
+	case "format_from_url":
+		return newFormatFromURL(ctx, cfg)
+	case "format_to_url":
+		return newFormatToURL(ctx, cfg)
 	// Hash transforms.
 	case "hash_md5":
 		return newHashMD5(ctx, cfg)
+	case "hash_id":
+		return newHashID(ctx, cfg)
 	case "hash_sha256":
 		return newHashSHA256(ctx, cfg)
 	// Meta transforms.
+	case "meta_concurrency":
+		return newMetaConcurrency(ctx, cfg)
+	case "meta_disk_buffer":
+		return newMetaDiskBuffer(ctx, cfg)
 	case "meta_err":
 		return newMetaErr(ctx, cfg)
 	case "meta_for_each":
@@ -85,10 +139,18 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 	case "meta_switch":
 		return newMetaSwitch(ctx, cfg)
 	// Number transforms.
+	case "number_format":
+		return newNumberFormat(ctx, cfg)
+	case "number_base":
+		return newNumberBase(ctx, cfg)
+	case "number_bucket":
+		return newNumberBucket(ctx, cfg)
 	case "number_math_addition":
 		return newNumberMathAddition(ctx, cfg)
 	case "number_math_division":
 		return newNumberMathDivision(ctx, cfg)
+	case "number_math_fields":
+		return newNumberMathFields(ctx, cfg)
 	case "number_math_multiplication":
 		return newNumberMathMultiplication(ctx, cfg)
 	case "number_math_subtraction":
@@ -100,15 +162,45 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newNetworkDomainSubdomain(ctx, cfg)
 	case "network_domain_top_level_domain":
 		return newNetworkDomainTopLevelDomain(ctx, cfg)
+	case "network_http_status":
+		return newNetworkHTTPStatus(ctx, cfg)
 	// Object transforms.
+	case "object_canonicalize":
+		return newObjectCanonicalize(ctx, cfg)
+	case "object_coerce":
+		return newObjectCoerce(ctx, cfg)
 	case "object_copy":
 		return newObjectCopy(ctx, cfg)
 	case "object_delete":
 		return newObjectDelete(ctx, cfg)
+	case "object_diff":
+		return newObjectDiff(ctx, cfg)
+	case "object_envelope":
+		return newObjectEnvelope(ctx, cfg)
+	case "object_expression":
+		return newObjectExpression(ctx, cfg)
 	case "object_insert":
 		return newObjectInsert(ctx, cfg)
 	case "object_jq":
 		return newObjectJQ(ctx, cfg)
+	case "object_keys_diff":
+		return newObjectKeysDiff(ctx, cfg)
+	case "object_length":
+		return newObjectLength(ctx, cfg)
+	case "object_merge":
+		return newObjectMerge(ctx, cfg)
+	case "object_move":
+		return newObjectMove(ctx, cfg)
+	case "object_patch":
+		return newObjectPatch(ctx, cfg)
+	case "object_pivot":
+		return newObjectPivot(ctx, cfg)
+	case "object_project":
+		return newObjectProject(ctx, cfg)
+	case "object_resolve_refs":
+		return newObjectResolveRefs(ctx, cfg)
+	case "object_to_array":
+		return newObjectToArray(ctx, cfg)
 	case "object_to_boolean":
 		return newObjectToBoolean(ctx, cfg)
 	case "object_to_float":
@@ -119,6 +211,8 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newObjectToString(ctx, cfg)
 	case "object_to_unsigned_integer":
 		return newObjectToUnsignedInteger(ctx, cfg)
+	case "object_unpivot":
+		return newObjectUnpivot(ctx, cfg)
 	// Send transforms.
 	case "send_aws_dynamodb":
 		return newSendAWSDynamoDB(ctx, cfg)
@@ -136,8 +230,16 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newSendAWSSQS(ctx, cfg)
 	case "send_file":
 		return newSendFile(ctx, cfg)
+	case "send_grpc":
+		return newSendGRPC(ctx, cfg)
 	case "send_http_post":
 		return newSendHTTPPost(ctx, cfg)
+	case "send_null":
+		return newSendNull(ctx, cfg)
+	case "send_prometheus_pushgateway":
+		return newSendPrometheusPushgateway(ctx, cfg)
+	case "send_sqlite":
+		return newSendSQLite(ctx, cfg)
 	case "send_stdout":
 		return newSendStdout(ctx, cfg)
 	// String transforms.
@@ -145,19 +247,59 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newStringAppend(ctx, cfg)
 	case "string_capture":
 		return newStringCapture(ctx, cfg)
+	case "string_checksum":
+		return newStringChecksum(ctx, cfg)
+	case "string_email":
+		return newStringEmail(ctx, cfg)
+	case "string_entropy":
+		return newStringEntropy(ctx, cfg)
+	case "string_find":
+		return newStringFind(ctx, cfg)
+	case "string_mask":
+		return newStringMask(ctx, cfg)
+	case "string_normalize":
+		return newStringNormalize(ctx, cfg)
+	case "string_phone":
+		return newStringPhone(ctx, cfg)
+	case "string_pii":
+		return newStringPII(ctx, cfg)
 	case "string_to_lower":
 		return newStringToLower(ctx, cfg)
+	case "string_to_severity":
+		return newStringToSeverity(ctx, cfg)
 	case "string_to_snake":
 		return newStringToSnake(ctx, cfg)
+	case "string_to_utf8":
+		return newStringToUTF8(ctx, cfg)
 	case "string_to_upper":
 		return newStringToUpper(ctx, cfg)
 	case "string_replace":
 		return newStringReplace(ctx, cfg)
+	case "string_repeat":
+		return newStringRepeat(ctx, cfg)
 	case "string_split":
 		return newStringSplit(ctx, cfg)
+	case "string_stats":
+		return newStringStats(ctx, cfg)
+	case "string_strip_ansi":
+		return newStringStripANSI(ctx, cfg)
+	case "string_substring":
+		return newStringSubstring(ctx, cfg)
+	case "string_tokenize":
+		return newStringTokenize(ctx, cfg)
+	case "string_truncate":
+		return newStringTruncate(ctx, cfg)
 	case "string_uuid":
 		return newStringUUID(ctx, cfg)
 	// Time transforms.
+	case "time_bucket":
+		return newTimeBucket(ctx, cfg)
+	case "time_delta":
+		return newTimeDelta(ctx, cfg)
+	case "time_epoch_scale":
+		return newTimeEpochScale(ctx, cfg)
+	case "time_from_duration":
+		return newTimeFromDuration(ctx, cfg)
 	case "time_from_string":
 		return newTimeFromString(ctx, cfg)
 	case "time_from_unix":
@@ -166,6 +308,8 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newTimeFromUnixMilli(ctx, cfg)
 	case "time_now":
 		return newTimeNow(ctx, cfg)
+	case "time_to_duration":
+		return newTimeToDuration(ctx, cfg)
 	case "time_to_string":
 		return newTimeToString(ctx, cfg)
 	case "time_to_unix":
@@ -173,6 +317,8 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 	case "time_to_unix_milli":
 		return newTimeToUnixMilli(ctx, cfg)
 	// Utility transforms.
+	case "utility_control":
+		return newUtilityControl(ctx, cfg)
 	case "utility_delay":
 		return newUtilityDelay(ctx, cfg)
 	case "utility_drop":
@@ -183,8 +329,12 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) { //nolint
 		return newUtilityMetricBytes(ctx, cfg)
 	case "utility_metric_count":
 		return newUtilityMetricCount(ctx, cfg)
+	case "utility_rate_limit":
+		return newUtilityRateLimit(ctx, cfg)
 	case "utility_secret":
 		return newUtilitySecret(ctx, cfg)
+	case "utility_sequence":
+		return newUtilitySequence(ctx, cfg)
 	default:
 		return nil, fmt.Errorf("transform: new: type %q settings %+v: %v", cfg.Type, cfg.Settings, errors.ErrInvalidFactoryInput)
 	}
@@ -196,6 +346,8 @@ func Apply(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*
 	copy(resultMsgs, msgs)
 
 	for i := 0; len(resultMsgs) > 0 && i < len(tf); i++ {
+		start := time.Now()
+
 		var nextResultMsgs []*message.Message
 		for _, m := range resultMsgs {
 			rMsgs, err := tf[i].Transform(ctx, m)
@@ -206,6 +358,17 @@ func Apply(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*
 			}
 			nextResultMsgs = append(nextResultMsgs, rMsgs...)
 		}
+
+		// The time spent in each transform is logged so that slow transforms
+		// can be identified in a chain.
+		if log.IsDebug() {
+			log.WithField("transform", fmt.Sprintf("%T", tf[i])).
+				WithField("index", i).
+				WithField("messages", len(resultMsgs)).
+				WithField("duration", time.Since(start).String()).
+				Debug("applied transform")
+		}
+
 		resultMsgs = nextResultMsgs
 	}
 
@@ -225,3 +388,22 @@ func anyToBytes(v any) []byte {
 
 	return msg.GetValue("_").Bytes()
 }
+
+// resolveIndex resolves an index into a sequence (e.g., an array or a string)
+// of length l. Negative values count from the end of the sequence and out of
+// range values are clamped to the bounds of the sequence.
+func resolveIndex(i, l int) int {
+	if i < 0 {
+		i += l
+	}
+
+	if i < 0 {
+		return 0
+	}
+
+	if i > l {
+		return l
+	}
+
+	return i
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/message"
+)
+
+type utilityControlConfig struct {
+	// Batch determines when control messages are inserted into the pipeline.
+	// A control message is inserted when the number of messages (count) or
+	// the amount of data (size) reaches the limit, or when the duration has
+	// passed since the first message in the batch.
+	//
+	// Control messages can only be inserted when a message is received, so
+	// the duration is checked when each message is received. This means that
+	// a flush requires incoming traffic: if no more messages are received,
+	// then data that is already batched by later transforms is not flushed
+	// until the next message arrives or the application sends its own
+	// control message (e.g., when it exits).
+	//
+	// This is optional and defaults to 1000 messages, 1 MiB, and 1 minute.
+	Batch iconfig.Batch `json:"batch"`
+}
+
+func (c *utilityControlConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func newUtilityControl(_ context.Context, cfg config.Config) (*utilityControl, error) {
+	conf := utilityControlConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: utility_control: %v", err)
+	}
+
+	if conf.Batch.Count < 1 {
+		conf.Batch.Count = 1000
+	}
+
+	if conf.Batch.Size < 1 {
+		conf.Batch.Size = 1024 * 1024
+	}
+
+	if conf.Batch.Duration == "" {
+		conf.Batch.Duration = "1m"
+	}
+
+	dur, err := time.ParseDuration(conf.Batch.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("transform: utility_control: duration: %v", err)
+	}
+
+	tf := utilityControl{
+		conf: conf,
+		dur:  dur,
+	}
+
+	return &tf, nil
+}
+
+// utilityControl inserts control messages into the pipeline, which causes
+// transforms that batch data (e.g., send transforms) to flush their data. This
+// caps the latency of long-running applications that rarely receive control
+// messages, as long as the applications keep receiving data.
+type utilityControl struct {
+	conf utilityControlConfig
+	dur  time.Duration
+
+	// mu protects the current batch.
+	mu    sync.Mutex
+	count int
+	size  int
+	start time.Time
+}
+
+func (tf *utilityControl) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		// The batch is reset to avoid inserting a control message immediately
+		// after one is received.
+		tf.count, tf.size = 0, 0
+		return []*message.Message{msg}, nil
+	}
+
+	size := len(msg.Data())
+	if size > tf.conf.Batch.Size {
+		return nil, fmt.Errorf("transform: utility_control: %v", errSendBatchMisconfigured)
+	}
+
+	// If the batch is empty, then there is no data to flush.
+	if tf.count == 0 {
+		tf.count, tf.size, tf.start = 1, size, time.Now()
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.count+1 <= tf.conf.Batch.Count && tf.size+size <= tf.conf.Batch.Size && time.Since(tf.start) < tf.dur {
+		tf.count++
+		tf.size += size
+
+		return []*message.Message{msg}, nil
+	}
+
+	tf.count, tf.size, tf.start = 1, size, time.Now()
+
+	// The control message comes first so that the message is not flushed with
+	// the previous batch.
+	ctrl := message.New().AsControl()
+	return []*message.Message{ctrl, msg}, nil
+}
+
+func (tf *utilityControl) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
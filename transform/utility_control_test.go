@@ -0,0 +1,161 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &utilityControl{}
+
+var utilityControlTests = []struct {
+	name     string
+	cfg      config.Config
+	data     []string
+	expected []string
+}{
+	{
+		"count",
+		config.Config{
+			Settings: map[string]interface{}{
+				"batch": map[string]interface{}{
+					"count": 2,
+				},
+			},
+		},
+		[]string{"a", "b", "c", "d", "e"},
+		[]string{"a", "b", "ctrl", "c", "d", "ctrl", "e"},
+	},
+	{
+		"size",
+		config.Config{
+			Settings: map[string]interface{}{
+				"batch": map[string]interface{}{
+					"size": 4,
+				},
+			},
+		},
+		[]string{"ab", "cd", "e", "fgh"},
+		[]string{"ab", "cd", "ctrl", "e", "fgh"},
+	},
+	// Control messages reset the batch.
+	{
+		"control",
+		config.Config{
+			Settings: map[string]interface{}{
+				"batch": map[string]interface{}{
+					"count": 2,
+				},
+			},
+		},
+		[]string{"a", "ctrl", "b", "c", "d"},
+		[]string{"a", "ctrl", "b", "c", "ctrl", "d"},
+	},
+}
+
+func utilityControlApply(ctx context.Context, tf *utilityControl, data []string) ([]string, error) {
+	var output []string
+	for _, d := range data {
+		msg := message.New().SetData([]byte(d))
+		if d == "ctrl" {
+			msg = message.New().AsControl()
+		}
+
+		result, err := tf.Transform(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range result {
+			if r.IsControl() {
+				output = append(output, "ctrl")
+				continue
+			}
+
+			output = append(output, string(r.Data()))
+		}
+	}
+
+	return output, nil
+}
+
+func TestUtilityControl(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range utilityControlTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newUtilityControl(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := utilityControlApply(ctx, tf, test.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(output, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, output)
+			}
+		})
+	}
+}
+
+func TestUtilityControlDuration(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newUtilityControl(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"batch": map[string]interface{}{
+				"duration": "50ms",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := utilityControlApply(ctx, tf, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The duration is measured from the first message in the batch, so
+	// messages that arrive regularly do not extend the batch.
+	for _, d := range []string{"c", "d"} {
+		time.Sleep(30 * time.Millisecond)
+
+		result, err := utilityControlApply(ctx, tf, []string{d})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output = append(output, result...)
+	}
+
+	expected := []string{"a", "b", "c", "ctrl", "d"}
+	if !slices.Equal(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
+
+func TestUtilityControlMisconfigured(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newUtilityControl(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"batch": map[string]interface{}{
+				"size": 1,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte("ab"))); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -4,15 +4,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/brexhq/substation/config"
 	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
 	"github.com/brexhq/substation/internal/metrics"
 	"github.com/brexhq/substation/message"
 )
 
+// utilityMetricsCountOther replaces attribute values when the maximum number
+// of attribute combinations is reached.
+const utilityMetricsCountOther = "Other"
+
+// utilityMetricsCountMaxAttributes is the maximum number of dimensions that
+// CloudWatch accepts for a metric.
+const utilityMetricsCountMaxAttributes = 30
+
 type utilityMetricsCountConfig struct {
+	// AttributeKeys maps attribute names to keys in the message. Messages are
+	// counted separately for each unique combination of attribute values, which
+	// are added to the metric's attributes. Keys that are missing or empty are
+	// not added. Together with the metric's static attributes, there can be at
+	// most 30 attributes.
+	//
+	// This is optional and has no default.
+	AttributeKeys map[string]string `json:"attribute_keys"`
+	// MaxCardinality is the maximum number of unique combinations of attribute
+	// values that are counted between flushes. Messages that exceed this limit
+	// are counted with all attribute values set to "Other". This is only used
+	// when AttributeKeys is set.
+	//
+	// This is optional and defaults to 100.
+	MaxCardinality int `json:"max_cardinality"`
+
 	Metric iconfig.Metric `json:"metric"`
 }
 
@@ -20,13 +46,39 @@ func (c *utilityMetricsCountConfig) Decode(in interface{}) error {
 	return iconfig.Decode(in, c)
 }
 
+func (c *utilityMetricsCountConfig) Validate() error {
+	// Attributes from the message replace static attributes with the same name.
+	names := make(map[string]struct{}, len(c.Metric.Attributes)+len(c.AttributeKeys))
+	for name := range c.Metric.Attributes {
+		names[name] = struct{}{}
+	}
+
+	for name := range c.AttributeKeys {
+		names[name] = struct{}{}
+	}
+
+	if len(names) > utilityMetricsCountMaxAttributes {
+		return fmt.Errorf("attribute_keys: %d attributes exceeds the limit of %d: %v", len(names), utilityMetricsCountMaxAttributes, errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func newUtilityMetricCount(ctx context.Context, cfg config.Config) (*utilityMetricsCount, error) {
-	// conf gets validated when calling metrics.New.
+	// conf.Metric.Destination gets validated when calling metrics.New.
 	conf := utilityMetricsCountConfig{}
 	if err := conf.Decode(cfg.Settings); err != nil {
 		return nil, fmt.Errorf("transform: utility_metric_count: %v", err)
 	}
 
+	if conf.MaxCardinality == 0 {
+		conf.MaxCardinality = 100
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: utility_metric_count: %v", err)
+	}
+
 	m, err := metrics.New(ctx, conf.Metric.Destination)
 	if err != nil {
 		return nil, fmt.Errorf("transform: utility_metric_count: %v", err)
@@ -35,19 +87,32 @@ func newUtilityMetricCount(ctx context.Context, cfg config.Config) (*utilityMetr
 	tf := utilityMetricsCount{
 		conf:   conf,
 		metric: m,
+		groups: make(map[string]*utilityMetricsCountGroup),
 	}
 
 	return &tf, nil
 }
 
+type utilityMetricsCountGroup struct {
+	attributes map[string]string
+	count      uint32
+}
+
 type utilityMetricsCount struct {
 	conf utilityMetricsCountConfig
 
 	metric metrics.Generator
 	count  uint32
+
+	mu     sync.Mutex
+	groups map[string]*utilityMetricsCountGroup
 }
 
 func (tf *utilityMetricsCount) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if len(tf.conf.AttributeKeys) != 0 {
+		return tf.transformGroups(ctx, msg)
+	}
+
 	if msg.IsControl() {
 		if err := tf.metric.Generate(ctx, metrics.Data{
 			Name:       tf.conf.Metric.Name,
@@ -69,3 +134,62 @@ func (tf *utilityMetricsCount) String() string {
 	b, _ := json.Marshal(tf.conf)
 	return string(b)
 }
+
+// transformGroups counts messages for each combination of attribute values and
+// sends all counts in a single batch when the transform is flushed.
+func (tf *utilityMetricsCount) transformGroups(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		data := make([]metrics.Data, 0, len(tf.groups))
+		for _, g := range tf.groups {
+			d := metrics.Data{
+				Name:  tf.conf.Metric.Name,
+				Value: g.count,
+			}
+
+			d.AddAttributes(tf.conf.Metric.Attributes)
+			d.AddAttributes(g.attributes)
+			data = append(data, d)
+		}
+
+		// Counts are reset even if they are not sent, otherwise they are
+		// counted again in the next flush.
+		tf.groups = make(map[string]*utilityMetricsCountGroup)
+		if err := metrics.GenerateBatch(ctx, tf.metric, data); err != nil {
+			return nil, fmt.Errorf("transform: utility_metric_count: %v", err)
+		}
+
+		return []*message.Message{msg}, nil
+	}
+
+	attr := make(map[string]string, len(tf.conf.AttributeKeys))
+	for name, key := range tf.conf.AttributeKeys {
+		// Empty values are not valid attributes (for example, CloudWatch
+		// rejects empty dimension values), so they are left out.
+		if v := msg.GetValue(key).String(); v != "" {
+			attr[name] = v
+		}
+	}
+
+	// Maps are printed in sorted order, so the same attributes always
+	// have the same key.
+	key := fmt.Sprint(attr)
+	if _, ok := tf.groups[key]; !ok && len(tf.groups) >= tf.conf.MaxCardinality {
+		for name := range attr {
+			attr[name] = utilityMetricsCountOther
+		}
+
+		key = fmt.Sprint(attr)
+	}
+
+	g, ok := tf.groups[key]
+	if !ok {
+		g = &utilityMetricsCountGroup{attributes: attr}
+		tf.groups[key] = g
+	}
+
+	g.count++
+	return []*message.Message{msg}, nil
+}
@@ -0,0 +1,255 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/internal/metrics"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &utilityMetricsCount{}
+
+// utilityMetricCountRecorder records metrics that are sent in batches.
+type utilityMetricCountRecorder struct {
+	data []metrics.Data
+	err  error
+}
+
+func (r *utilityMetricCountRecorder) Generate(_ context.Context, d metrics.Data) error {
+	r.data = append(r.data, d)
+	return nil
+}
+
+func (r *utilityMetricCountRecorder) GenerateBatch(_ context.Context, data []metrics.Data) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.data = append(r.data, data...)
+	return nil
+}
+
+// utilityMetricCountSettings returns settings that send metrics to a
+// destination that is replaced in tests.
+func utilityMetricCountSettings(settings map[string]interface{}) map[string]interface{} {
+	s := map[string]interface{}{
+		"metric": map[string]interface{}{
+			"name": "Count",
+			"attributes": map[string]string{
+				"app": "test",
+			},
+			"destination": map[string]interface{}{
+				"type": "aws_cloudwatch_embedded_metrics",
+			},
+		},
+	}
+
+	for k, v := range settings {
+		s[k] = v
+	}
+
+	return s
+}
+
+var utilityMetricCountTests = []struct {
+	name     string
+	settings map[string]interface{}
+	test     []string
+	expected []string
+}{
+	{
+		"count",
+		nil,
+		[]string{`{"a":"b"}`, `{"a":"c"}`, `{"a":"b"}`},
+		[]string{
+			"app=test: 3",
+		},
+	},
+	{
+		"attribute keys",
+		map[string]interface{}{
+			"attribute_keys": map[string]string{
+				"name": "a",
+			},
+		},
+		[]string{`{"a":"b"}`, `{"a":"c"}`, `{"a":"b"}`},
+		[]string{
+			"app=test name=b: 2",
+			"app=test name=c: 1",
+		},
+	},
+	{
+		"missing attribute key",
+		map[string]interface{}{
+			"attribute_keys": map[string]string{
+				"name": "a",
+				"type": "b",
+			},
+		},
+		[]string{`{"a":"x","b":"1"}`, `{"a":"x"}`, `{"a":"x","b":""}`, `{"c":"y"}`},
+		[]string{
+			"app=test name=x type=1: 1",
+			"app=test name=x: 2",
+			"app=test: 1",
+		},
+	},
+	{
+		"max cardinality",
+		map[string]interface{}{
+			"attribute_keys": map[string]string{
+				"name": "a",
+				"type": "b",
+			},
+			"max_cardinality": 2,
+		},
+		[]string{
+			`{"a":"x","b":"1"}`,
+			`{"a":"y","b":"2"}`,
+			`{"a":"x","b":"1"}`,
+			// These exceed the limit and are counted as Other.
+			`{"a":"z","b":"3"}`,
+			`{"a":"w","b":"1"}`,
+		},
+		[]string{
+			"app=test name=Other type=Other: 2",
+			"app=test name=x type=1: 2",
+			"app=test name=y type=2: 1",
+		},
+	},
+}
+
+// utilityMetricCountFormat returns a sorted, printable version of the metrics.
+func utilityMetricCountFormat(data []metrics.Data) []string {
+	out := make([]string, 0, len(data))
+	for _, d := range data {
+		attr := make([]string, 0, len(d.Attributes))
+		for k, v := range d.Attributes {
+			attr = append(attr, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(attr)
+
+		out = append(out, fmt.Sprintf("%s: %v", strings.Join(attr, " "), d.Value))
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+func TestUtilityMetricCount(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, test := range utilityMetricCountTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newUtilityMetricCount(ctx, config.Config{
+				Settings: utilityMetricCountSettings(test.settings),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := &utilityMetricCountRecorder{}
+			tf.metric = rec
+
+			for _, d := range test.test {
+				if _, err := tf.Transform(ctx, message.New().SetData([]byte(d))); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := utilityMetricCountFormat(rec.data); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+
+			// Counts are reset after they are sent.
+			rec.data = nil
+			if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, d := range rec.data {
+				if fmt.Sprint(d.Value) != "0" {
+					t.Errorf("expected counts to be reset, got %v", utilityMetricCountFormat(rec.data))
+				}
+			}
+		})
+	}
+}
+
+func TestUtilityMetricCountMaxAttributes(t *testing.T) {
+	keys := make(map[string]string)
+	for i := 0; i < utilityMetricsCountMaxAttributes; i++ {
+		keys[fmt.Sprintf("attr%d", i)] = fmt.Sprintf("key%d", i)
+	}
+
+	// The static attribute "app" exceeds the limit.
+	_, err := newUtilityMetricCount(context.TODO(), config.Config{
+		Settings: utilityMetricCountSettings(map[string]interface{}{
+			"attribute_keys": keys,
+		}),
+	})
+	if err == nil {
+		t.Error("expected error")
+	}
+
+	// Attribute keys that replace static attributes do not count twice.
+	delete(keys, "attr0")
+	keys["app"] = "key0"
+
+	if _, err := newUtilityMetricCount(context.TODO(), config.Config{
+		Settings: utilityMetricCountSettings(map[string]interface{}{
+			"attribute_keys": keys,
+		}),
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUtilityMetricCountSendError(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newUtilityMetricCount(ctx, config.Config{
+		Settings: utilityMetricCountSettings(map[string]interface{}{
+			"attribute_keys": map[string]string{
+				"name": "a",
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &utilityMetricCountRecorder{err: fmt.Errorf("send failed")}
+	tf.metric = rec
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"a":"b"}`))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err == nil {
+		t.Error("expected error")
+	}
+
+	// Counts from the failed flush are not sent again.
+	rec.err = nil
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"a":"c"}`))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"app=test name=c: 1"}
+	if got := utilityMetricCountFormat(rec.data); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
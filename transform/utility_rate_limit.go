@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+	"golang.org/x/time/rate"
+)
+
+type utilityRateLimitConfig struct {
+	// Rate is the maximum number of messages per second that are allowed
+	// through the transform.
+	Rate float64 `json:"rate"`
+	// Burst is the maximum number of messages that can pass through the
+	// transform at once.
+	//
+	// This is optional and defaults to 1.
+	Burst int `json:"burst"`
+}
+
+func (c *utilityRateLimitConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *utilityRateLimitConfig) Validate() error {
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate: %v", errors.ErrMissingRequiredOption)
+	}
+
+	if c.Burst < 0 {
+		return fmt.Errorf("burst: %v", errors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func newUtilityRateLimit(_ context.Context, cfg config.Config) (*utilityRateLimit, error) {
+	conf := utilityRateLimitConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: utility_rate_limit: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: utility_rate_limit: %v", err)
+	}
+
+	if conf.Burst == 0 {
+		conf.Burst = 1
+	}
+
+	tf := utilityRateLimit{
+		conf:    conf,
+		limiter: rate.NewLimiter(rate.Limit(conf.Rate), conf.Burst),
+	}
+
+	return &tf, nil
+}
+
+type utilityRateLimit struct {
+	conf utilityRateLimitConfig
+
+	// limiter is safe for concurrent use and is shared by all
+	// goroutines that call the transform.
+	limiter *rate.Limiter
+}
+
+func (tf *utilityRateLimit) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Messages are never dropped, instead the transform blocks until
+	// the message is allowed or the context is cancelled.
+	if err := tf.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("transform: utility_rate_limit: %v", err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *utilityRateLimit) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,158 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &utilityRateLimit{}
+
+var utilityRateLimitConfigTests = []struct {
+	name     string
+	cfg      config.Config
+	expected utilityRateLimitConfig
+	err      bool
+}{
+	{
+		"defaults",
+		config.Config{
+			Settings: map[string]interface{}{
+				"rate": 10,
+			},
+		},
+		utilityRateLimitConfig{Rate: 10, Burst: 1},
+		false,
+	},
+	{
+		"burst",
+		config.Config{
+			Settings: map[string]interface{}{
+				"rate":  0.5,
+				"burst": 5,
+			},
+		},
+		utilityRateLimitConfig{Rate: 0.5, Burst: 5},
+		false,
+	},
+	{
+		"missing rate",
+		config.Config{},
+		utilityRateLimitConfig{},
+		true,
+	},
+	{
+		"negative rate",
+		config.Config{
+			Settings: map[string]interface{}{
+				"rate": -1,
+			},
+		},
+		utilityRateLimitConfig{},
+		true,
+	},
+	{
+		"negative burst",
+		config.Config{
+			Settings: map[string]interface{}{
+				"rate":  1,
+				"burst": -1,
+			},
+		},
+		utilityRateLimitConfig{},
+		true,
+	},
+}
+
+func TestUtilityRateLimitConfig(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range utilityRateLimitConfigTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newUtilityRateLimit(ctx, test.cfg)
+			if test.err {
+				if err == nil {
+					t.Error("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tf.conf != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, tf.conf)
+			}
+		})
+	}
+}
+
+func TestUtilityRateLimit(t *testing.T) {
+	ctx := context.TODO()
+	tf, err := newUtilityRateLimit(ctx, config.Config{
+		Settings: map[string]interface{}{
+			"rate":  20,
+			"burst": 2,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The burst passes immediately, then each message waits 50ms.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		msgs, err := tf.Transform(ctx, message.New().SetData([]byte("a")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(msgs) != 1 || string(msgs[0].Data()) != "a" {
+			t.Errorf("expected message to pass through, got %v", msgs)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the transform to block for at least 150ms, took %v", elapsed)
+	}
+
+	// Control messages are not rate limited.
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		if _, err := tf.Transform(ctx, message.New().AsControl()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected control messages to pass immediately, took %v", elapsed)
+	}
+}
+
+func TestUtilityRateLimitCancel(t *testing.T) {
+	tf, err := newUtilityRateLimit(context.TODO(), config.Config{
+		Settings: map[string]interface{}{
+			"rate": 0.001,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// The first message uses the burst and the second message would wait
+	// longer than the context allows.
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte("a"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte("b"))); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,100 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/brexhq/substation/config"
+	iconfig "github.com/brexhq/substation/internal/config"
+	"github.com/brexhq/substation/internal/errors"
+	"github.com/brexhq/substation/message"
+)
+
+type utilitySequenceConfig struct {
+	// Start is the first number in each sequence.
+	//
+	// This is optional and defaults to 0.
+	Start int64 `json:"start"`
+
+	Object iconfig.Object `json:"object"`
+}
+
+func (c *utilitySequenceConfig) Decode(in interface{}) error {
+	return iconfig.Decode(in, c)
+}
+
+func (c *utilitySequenceConfig) Validate() error {
+	if c.Object.TargetKey == "" {
+		return fmt.Errorf("object_target_key: %v", errors.ErrMissingRequiredOption)
+	}
+
+	return nil
+}
+
+func newUtilitySequence(_ context.Context, cfg config.Config) (*utilitySequence, error) {
+	conf := utilitySequenceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform: utility_sequence: %v", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: utility_sequence: %v", err)
+	}
+
+	tf := utilitySequence{
+		conf: conf,
+		seq:  make(map[string]int64),
+	}
+
+	return &tf, nil
+}
+
+// utilitySequence puts an incrementing number into each message in the order
+// that messages are received by the transform. If a batch key is set, then each
+// value of the key has its own sequence.
+//
+// Applications transform messages concurrently, so the order that messages are
+// received by the transform is not guaranteed to be the order of the input. To
+// number messages in input order, the application must transform messages one
+// at a time (e.g., with a concurrency of 1), or, in the AWS Lambda app, use an
+// ordering key that is the same as the batch key.
+//
+// Sequences are reset when a control message is received, so each batch
+// (e.g., each file read by the source of the pipeline) starts over.
+type utilitySequence struct {
+	conf utilitySequenceConfig
+
+	mu  sync.Mutex
+	seq map[string]int64
+}
+
+func (tf *utilitySequence) Transform(_ context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		tf.seq = make(map[string]int64)
+		return []*message.Message{msg}, nil
+	}
+
+	key := msg.GetValue(tf.conf.Object.BatchKey).String()
+	n, ok := tf.seq[key]
+	if !ok {
+		n = tf.conf.Start
+	}
+
+	if err := msg.SetValue(tf.conf.Object.TargetKey, n); err != nil {
+		return nil, fmt.Errorf("transform: utility_sequence: %v", err)
+	}
+
+	tf.seq[key] = n + 1
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *utilitySequence) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
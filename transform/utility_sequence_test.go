@@ -0,0 +1,157 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/brexhq/substation/config"
+	"github.com/brexhq/substation/message"
+)
+
+var _ Transformer = &utilitySequence{}
+
+var utilitySequenceTests = []struct {
+	name     string
+	cfg      config.Config
+	data     []string
+	expected []string
+}{
+	{
+		"default",
+		config.Config{
+			Settings: map[string]interface{}{
+				"object": map[string]interface{}{
+					"target_key": "seq",
+				},
+			},
+		},
+		[]string{`{"a":"b"}`, `{"a":"c"}`, `{"a":"d"}`},
+		[]string{`{"a":"b","seq":0}`, `{"a":"c","seq":1}`, `{"a":"d","seq":2}`},
+	},
+	{
+		"start",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 10,
+				"object": map[string]interface{}{
+					"target_key": "seq",
+				},
+			},
+		},
+		[]string{`{"a":"b"}`, `{"a":"c"}`},
+		[]string{`{"a":"b","seq":10}`, `{"a":"c","seq":11}`},
+	},
+	{
+		"batch_key",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 1,
+				"object": map[string]interface{}{
+					"batch_key":  "a",
+					"target_key": "seq",
+				},
+			},
+		},
+		[]string{`{"a":"x"}`, `{"a":"y"}`, `{"a":"x"}`, `{"b":"z"}`, `{"a":"y"}`, `{"b":"z"}`},
+		[]string{
+			`{"a":"x","seq":1}`,
+			`{"a":"y","seq":1}`,
+			`{"a":"x","seq":2}`,
+			// Messages without the batch key share a sequence.
+			`{"b":"z","seq":1}`,
+			`{"a":"y","seq":2}`,
+			`{"b":"z","seq":2}`,
+		},
+	},
+	// Control messages reset all sequences.
+	{
+		"control",
+		config.Config{
+			Settings: map[string]interface{}{
+				"start": 1,
+				"object": map[string]interface{}{
+					"batch_key":  "a",
+					"target_key": "seq",
+				},
+			},
+		},
+		[]string{`{"a":"x"}`, `{"a":"y"}`, `{"a":"x"}`, "ctrl", `{"a":"x"}`, `{"a":"y"}`},
+		[]string{
+			`{"a":"x","seq":1}`,
+			`{"a":"y","seq":1}`,
+			`{"a":"x","seq":2}`,
+			"ctrl",
+			`{"a":"x","seq":1}`,
+			`{"a":"y","seq":1}`,
+		},
+	},
+}
+
+func TestUtilitySequence(t *testing.T) {
+	ctx := context.TODO()
+	for _, test := range utilitySequenceTests {
+		t.Run(test.name, func(t *testing.T) {
+			tf, err := newUtilitySequence(ctx, test.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var output []string
+			for _, d := range test.data {
+				msg := message.New().SetData([]byte(d))
+				if d == "ctrl" {
+					msg = message.New().AsControl()
+				}
+
+				result, err := tf.Transform(ctx, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, r := range result {
+					if r.IsControl() {
+						output = append(output, "ctrl")
+						continue
+					}
+
+					output = append(output, string(r.Data()))
+				}
+			}
+
+			if !slices.Equal(output, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, output)
+			}
+		})
+	}
+}
+
+func TestUtilitySequenceMissingTargetKey(t *testing.T) {
+	if _, err := newUtilitySequence(context.TODO(), config.Config{}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func benchmarkUtilitySequence(b *testing.B, tf *utilitySequence, data []byte) {
+	ctx := context.TODO()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(data)
+		_, _ = tf.Transform(ctx, msg)
+	}
+}
+
+func BenchmarkUtilitySequence(b *testing.B) {
+	for _, test := range utilitySequenceTests {
+		tf, err := newUtilitySequence(context.TODO(), test.cfg)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(test.name,
+			func(b *testing.B) {
+				benchmarkUtilitySequence(b, tf, []byte(test.data[0]))
+			},
+		)
+	}
+}